@@ -0,0 +1,160 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/welldigital/callme/api/response"
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/logger"
+)
+
+// DefaultLimit is the number of dead letters returned by List when no limit is specified.
+const DefaultLimit = 100
+
+// MaxLimit is the maximum number of dead letters that can be listed or requeued in a single request.
+const MaxLimit = 1000
+
+// Handler is the HTTP handler for the /deadletter path of the API.
+type Handler struct {
+	DeadLettersGetter  data.DeadLettersGetter
+	DeadLetterRequeuer data.DeadLetterRequeuer
+}
+
+// New creates a new handler.
+func New(getter data.DeadLettersGetter, requeuer data.DeadLetterRequeuer) *Handler {
+	return &Handler{
+		DeadLettersGetter:  getter,
+		DeadLetterRequeuer: requeuer,
+	}
+}
+
+const pkg = "github.com/welldigital/callme/api/deadletter/handler"
+
+// ListResponse is the response to the List operation.
+type ListResponse struct {
+	DeadLetters []data.DeadLetter `json:"deadLetters"`
+	// Next is the value of the after parameter used to get the next page, it's null when there are no more results.
+	Next *int64 `json:"next"`
+}
+
+// List lists dead letters, using the requeued, after and limit query parameters.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "List").WithField("url", r.URL).Info("start")
+	q := r.URL.Query()
+	requeued := false
+	if s := q.Get("requeued"); s != "" {
+		var err error
+		if requeued, err = strconv.ParseBool(s); err != nil {
+			logger.For(pkg, "List").WithError(err).WithField("requeued", s).Error("failed to parse requeued")
+			response.ErrorString("failed to parse requeued", w, http.StatusBadRequest)
+			return
+		}
+	}
+	var after int64
+	if s := q.Get("after"); s != "" {
+		var err error
+		if after, err = strconv.ParseInt(s, 10, 64); err != nil {
+			logger.For(pkg, "List").WithError(err).WithField("after", s).Error("failed to parse after")
+			response.ErrorString("failed to parse after", w, http.StatusBadRequest)
+			return
+		}
+	}
+	limit := DefaultLimit
+	if s := q.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 || l > MaxLimit {
+			logger.For(pkg, "List").WithField("limit", s).Error("invalid limit")
+			response.ErrorString("limit must be between 1 and 1000", w, http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+	dls, err := h.DeadLettersGetter(requeued, after, limit)
+	if err != nil {
+		logger.For(pkg, "List").WithError(err).Error("failed to retrieve dead letters")
+		response.ErrorString("failed to retrieve dead letters", w, http.StatusInternalServerError)
+		return
+	}
+	lr := ListResponse{
+		DeadLetters: dls,
+	}
+	if len(dls) == limit {
+		next := dls[len(dls)-1].DeadLetterID
+		lr.Next = &next
+	}
+	response.JSON(lr, w, http.StatusOK)
+}
+
+// RequeueRequest is the request that must be passed to requeue dead letters.
+type RequeueRequest struct {
+	DeadLetterIDs []int64 `json:"deadLetterIds"`
+}
+
+// Validate that the RequeueRequest is valid.
+func (rr RequeueRequest) Validate() error {
+	if len(rr.DeadLetterIDs) == 0 {
+		return errors.New("at least one dead letter ID must be provided")
+	}
+	if len(rr.DeadLetterIDs) > MaxLimit {
+		return errors.New("a maximum of 1000 dead letters can be requeued at once")
+	}
+	return nil
+}
+
+// Requeued is a dead letter which was requeued as a new job.
+type Requeued struct {
+	DeadLetterID int64 `json:"deadLetterId"`
+	JobID        int64 `json:"jobId"`
+}
+
+// RequeueResponse is the response to the Requeue operation.
+type RequeueResponse struct {
+	Requeued []Requeued `json:"requeued"`
+	// NotRequeued contains the IDs of dead letters which don't exist or have already been requeued.
+	NotRequeued []int64 `json:"notRequeued"`
+}
+
+// Requeue requeues dead letters, creating a new job for each one.
+func (h *Handler) Requeue(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Requeue").WithField("url", r.URL).Info("start")
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.For(pkg, "Requeue").WithError(err).Error("failed to read body")
+		response.ErrorString("failed to read body", w, http.StatusBadRequest)
+		return
+	}
+	var rr RequeueRequest
+	if err := json.Unmarshal(body, &rr); err != nil {
+		logger.For(pkg, "Requeue").WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return
+	}
+	if err = rr.Validate(); err != nil {
+		logger.For(pkg, "Requeue").WithError(err).Error("failed to validate requeue request")
+		response.Error(err, w, http.StatusUnprocessableEntity)
+		return
+	}
+	jobIDs, err := h.DeadLetterRequeuer(rr.DeadLetterIDs)
+	if err != nil {
+		logger.For(pkg, "Requeue").WithError(err).Error("failed to requeue dead letters")
+		response.ErrorString("failed to requeue dead letters", w, http.StatusInternalServerError)
+		return
+	}
+	rs := RequeueResponse{
+		Requeued:    make([]Requeued, 0),
+		NotRequeued: make([]int64, 0),
+	}
+	for _, id := range rr.DeadLetterIDs {
+		if jobID, ok := jobIDs[id]; ok {
+			rs.Requeued = append(rs.Requeued, Requeued{DeadLetterID: id, JobID: jobID})
+			continue
+		}
+		rs.NotRequeued = append(rs.NotRequeued, id)
+	}
+	logger.For(pkg, "Requeue").WithField("requeued", len(rs.Requeued)).WithField("notRequeued", len(rs.NotRequeued)).Info("requeued dead letters")
+	response.JSON(rs, w, http.StatusOK)
+}
@@ -0,0 +1,187 @@
+package deadletter
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/welldigital/callme/data"
+)
+
+func TestList(t *testing.T) {
+	deadLetter := data.DeadLetter{
+		DeadLetterID: 2,
+		Job: data.Job{
+			JobID:        1,
+			ARN:          "https://example.com",
+			Payload:      "testpayload",
+			When:         time.Date(2000, time.January, 1, 1, 1, 0, 0, time.UTC),
+			AttemptCount: 3,
+		},
+		Time:  time.Date(2000, time.January, 1, 1, 2, 0, 0, time.UTC),
+		Error: "received status code: 500",
+	}
+	const deadLetterJSON = `{"deadLetterId":2,"job":{"jobId":1,"scheduleId":null,"when":"2000-01-01T01:01:00Z","arn":"https://example.com","payload":"testpayload","httpRequest":null,"retryPolicy":null,"attemptCount":3,"dedupeKey":""},"time":"2000-01-01T01:02:00Z","error":"received status code: 500","forwardedTo":"","forwardError":"","requeuedJobId":null,"requeuedDate":"0001-01-01T00:00:00Z"}`
+
+	tests := []struct {
+		name             string
+		g                data.DeadLettersGetter
+		r                *http.Request
+		expectedRequeued bool
+		expectedAfter    int64
+		expectedLimit    int
+		expectedStatus   int
+		expectedBody     string
+	}{
+		{
+			name: "defaults",
+			g: func(requeued bool, afterID int64, limit int) ([]data.DeadLetter, error) {
+				return []data.DeadLetter{deadLetter}, nil
+			},
+			r:              httptest.NewRequest("GET", "/deadletter", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"deadLetters":[` + deadLetterJSON + `],"next":null}`,
+		},
+		{
+			name: "full page returns next",
+			g: func(requeued bool, afterID int64, limit int) ([]data.DeadLetter, error) {
+				return []data.DeadLetter{deadLetter}, nil
+			},
+			r:                httptest.NewRequest("GET", "/deadletter?requeued=true&after=1&limit=1", nil),
+			expectedRequeued: true,
+			expectedAfter:    1,
+			expectedLimit:    1,
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"deadLetters":[` + deadLetterJSON + `],"next":2}`,
+		},
+		{
+			name:           "invalid requeued",
+			r:              httptest.NewRequest("GET", "/deadletter?requeued=maybe", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse requeued"}`,
+		},
+		{
+			name:           "invalid after",
+			r:              httptest.NewRequest("GET", "/deadletter?after=first", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse after"}`,
+		},
+		{
+			name:           "limit too large",
+			r:              httptest.NewRequest("GET", "/deadletter?limit=1001", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"limit must be between 1 and 1000"}`,
+		},
+		{
+			name: "failed to get dead letters",
+			g: func(requeued bool, afterID int64, limit int) ([]data.DeadLetter, error) {
+				return nil, errors.New("database error")
+			},
+			r:              httptest.NewRequest("GET", "/deadletter", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve dead letters"}`,
+		},
+	}
+
+	for _, test := range tests {
+		var actualRequeued bool
+		var actualAfter int64
+		var actualLimit int
+		var g data.DeadLettersGetter
+		if test.g != nil {
+			g = func(requeued bool, afterID int64, limit int) ([]data.DeadLetter, error) {
+				actualRequeued, actualAfter, actualLimit = requeued, afterID, limit
+				return test.g(requeued, afterID, limit)
+			}
+		}
+		router := mux.NewRouter()
+		h := New(g, nil)
+		router.Path("/deadletter").Methods(http.MethodGet).HandlerFunc(h.List)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+		if actualRequeued != test.expectedRequeued || actualAfter != test.expectedAfter || actualLimit != test.expectedLimit {
+			t.Errorf("%s: expected requeued=%v, after=%v, limit=%v, got requeued=%v, after=%v, limit=%v", test.name,
+				test.expectedRequeued, test.expectedAfter, test.expectedLimit, actualRequeued, actualAfter, actualLimit)
+		}
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	tests := []struct {
+		name           string
+		rq             data.DeadLetterRequeuer
+		r              *http.Request
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			rq: func(deadLetterIDs []int64) (map[int64]int64, error) {
+				return map[int64]int64{1: 10, 3: 11}, nil
+			},
+			r:              httptest.NewRequest("POST", "/deadletter/requeue", strings.NewReader(`{"deadLetterIds":[1,2,3]}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"requeued":[{"deadLetterId":1,"jobId":10},{"deadLetterId":3,"jobId":11}],"notRequeued":[2]}`,
+		},
+		{
+			name:           "invalid JSON body",
+			r:              httptest.NewRequest("POST", "/deadletter/requeue", strings.NewReader("_not_json_")),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"failed to parse request"}`,
+		},
+		{
+			name:           "no IDs",
+			r:              httptest.NewRequest("POST", "/deadletter/requeue", strings.NewReader(`{"deadLetterIds":[]}`)),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"at least one dead letter ID must be provided"}`,
+		},
+		{
+			name: "failed to requeue",
+			rq: func(deadLetterIDs []int64) (map[int64]int64, error) {
+				return nil, errors.New("database error")
+			},
+			r:              httptest.NewRequest("POST", "/deadletter/requeue", strings.NewReader(`{"deadLetterIds":[1]}`)),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to requeue dead letters"}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		h := New(nil, test.rq)
+		router.Path("/deadletter/requeue").Methods(http.MethodPost).HandlerFunc(h.Requeue)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
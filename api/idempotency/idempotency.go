@@ -0,0 +1,53 @@
+// Package idempotency reads the idempotency keys of requests which create jobs and schedules, so that a client can
+// safely retry a request without creating a second job or schedule.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Header is the HTTP header which contains the idempotency key.
+const Header = "Idempotency-Key"
+
+// MaxLength is the maximum length of an idempotency key.
+const MaxLength = 256
+
+type bodyKey struct {
+	IdempotencyKey string `json:"idempotencyKey"`
+}
+
+// Key returns the idempotency key of the request, taken from the Idempotency-Key header or the idempotencyKey
+// field of the JSON body. An empty key is returned if the request doesn't have one.
+func Key(r *http.Request, body []byte) (string, error) {
+	var bk bodyKey
+	if err := json.Unmarshal(body, &bk); err != nil {
+		return "", errors.New("failed to parse idempotencyKey")
+	}
+	key := r.Header.Get(Header)
+	if key != "" && bk.IdempotencyKey != "" && key != bk.IdempotencyKey {
+		return "", errors.New("the Idempotency-Key header and idempotencyKey field must match")
+	}
+	if key == "" {
+		key = bk.IdempotencyKey
+	}
+	if len(key) > MaxLength {
+		return "", errors.New("maximum length of the idempotency key is 256 characters")
+	}
+	return key, nil
+}
+
+// Hash returns a hash of the parsed request, used to detect an idempotency key which is reused for a different
+// request. Hashing the parsed request rather than the body means that differences in whitespace or field order
+// aren't treated as a different request.
+func Hash(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
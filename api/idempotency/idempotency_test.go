@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		body          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "no key",
+			body:     `{ "arn": "https://example.com" }`,
+			expected: "",
+		},
+		{
+			name:     "header",
+			header:   "abc",
+			body:     `{ "arn": "https://example.com" }`,
+			expected: "abc",
+		},
+		{
+			name:     "body field",
+			body:     `{ "arn": "https://example.com", "idempotencyKey": "def" }`,
+			expected: "def",
+		},
+		{
+			name:     "matching header and body field",
+			header:   "abc",
+			body:     `{ "idempotencyKey": "abc" }`,
+			expected: "abc",
+		},
+		{
+			name:          "different header and body field",
+			header:        "abc",
+			body:          `{ "idempotencyKey": "def" }`,
+			expectedError: "the Idempotency-Key header and idempotencyKey field must match",
+		},
+		{
+			name:          "body field isn't a string",
+			body:          `{ "idempotencyKey": 1 }`,
+			expectedError: "failed to parse idempotencyKey",
+		},
+		{
+			name:          "too long",
+			header:        strings.Repeat("a", MaxLength+1),
+			body:          `{}`,
+			expectedError: "maximum length of the idempotency key is 256 characters",
+		},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", "/job", strings.NewReader(test.body))
+		if test.header != "" {
+			r.Header.Set(Header, test.header)
+		}
+		actual, err := Key(r, []byte(test.body))
+		if test.expectedError != "" {
+			if err == nil || err.Error() != test.expectedError {
+				t.Errorf("%s: expected error '%v', got '%v'", test.name, test.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected key '%v', got '%v'", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	type request struct {
+		ARN     string `json:"arn"`
+		Payload string `json:"payload"`
+	}
+	a, err := Hash(request{ARN: "https://example.com", Payload: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a) != 64 {
+		t.Errorf("expected a 64 character hash, got '%v'", a)
+	}
+	same, err := Hash(request{ARN: "https://example.com", Payload: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a != same {
+		t.Errorf("expected the same request to have the same hash, got '%v' and '%v'", a, same)
+	}
+	different, err := Hash(request{ARN: "https://example.com", Payload: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == different {
+		t.Errorf("expected a different request to have a different hash")
+	}
+}
@@ -1,37 +1,92 @@
 package job
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"math"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/welldigital/callme/api/idempotency"
 	"github.com/welldigital/callme/api/response"
 	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/executor"
 	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/retry"
+	"github.com/welldigital/callme/web"
 )
 
+// DefaultLimit is the number of jobs returned by List when no limit is specified.
+const DefaultLimit = 100
+
+// MaxLimit is the maximum number of jobs that can be listed in a single request.
+const MaxLimit = 1000
+
+// MaxBatchSize is the maximum number of jobs that can be started in a single batch request.
+const MaxBatchSize = 1000
+
+// batchChunkSize is the number of jobs started in each transaction of a best effort batch.
+const batchChunkSize = 100
+
+// The modes of a batch request.
+const (
+	// BatchModeAllOrNothing starts all of the jobs in a single transaction, or none of them if any are invalid.
+	BatchModeAllOrNothing = "allOrNothing"
+	// BatchModeBestEffort starts each valid job, reporting an error for each job which can't be started.
+	BatchModeBestEffort = "bestEffort"
+)
+
+// DefaultDedupeWindow is how long a job's dedupeKey is held for when no window is configured.
+const DefaultDedupeWindow = 24 * time.Hour
+
 // Handler is the HTTP handler for the /job path of the API.
 type Handler struct {
 	JobAndResponseByIDGetter data.JobAndResponseByIDGetter
+	JobAttemptsGetter        data.JobAttemptsGetter
+	JobDuplicatesGetter      data.JobDuplicatesGetter
+	JobsLister               data.JobsLister
 	JobStarter               data.JobStarter
+	IdempotentJobStarter     data.IdempotentJobStarter
+	JobsStarter              data.JobsStarter
 	JobDeleter               data.JobDeleter
+	ARNValidator             executor.Validator
+	RequestValidator         web.RequestValidator
+	// DedupeWindow is how long a job's dedupeKey is held for, so that another job with the same key is a duplicate.
+	DedupeWindow time.Duration
 }
 
 // New creates a new handler.
-func New(getter data.JobAndResponseByIDGetter, starter data.JobStarter, deleter data.JobDeleter) *Handler {
+func New(getter data.JobAndResponseByIDGetter, attemptsGetter data.JobAttemptsGetter, duplicatesGetter data.JobDuplicatesGetter, lister data.JobsLister, starter data.JobStarter, idempotentStarter data.IdempotentJobStarter, batchStarter data.JobsStarter, deleter data.JobDeleter, arnValidator executor.Validator, requestValidator web.RequestValidator, dedupeWindow time.Duration) *Handler {
 	return &Handler{
 		JobAndResponseByIDGetter: getter,
+		JobAttemptsGetter:        attemptsGetter,
+		JobDuplicatesGetter:      duplicatesGetter,
+		JobsLister:               lister,
 		JobStarter:               starter,
+		IdempotentJobStarter:     idempotentStarter,
+		JobsStarter:              batchStarter,
 		JobDeleter:               deleter,
+		ARNValidator:             arnValidator,
+		RequestValidator:         requestValidator,
+		DedupeWindow:             dedupeWindow,
 	}
 }
 
 const pkg = "github.com/welldigital/callme/api/job/handler"
 
+// PostResponse is the response to the Post operation. Duplicate is true when a job had already been started with the
+// same dedupeKey within the dedupe window, in which case the existing job is returned instead of starting another.
+type PostResponse struct {
+	data.Job
+	Duplicate bool `json:"duplicate"`
+}
+
 // Post handles the creation of new jobs.
 func (h *Handler) Post(w http.ResponseWriter, r *http.Request) {
 	logger.For(pkg, "Post").WithField("url", r.URL).Info("start")
@@ -49,22 +104,231 @@ func (h *Handler) Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Validate the job.
-	if err = validateJob(j); err != nil {
+	if err = validateJob(j, h.ARNValidator, h.RequestValidator); err != nil {
 		logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to validate job")
 		response.Error(err, w, http.StatusUnprocessableEntity)
 		return
 	}
+	key, err := idempotency.Key(r, body)
+	if err != nil {
+		logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to read idempotency key")
+		response.Error(err, w, http.StatusBadRequest)
+		return
+	}
+	if key != "" {
+		var ir idempotentRequest
+		if err := json.Unmarshal(body, &ir); err != nil {
+			logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to parse request")
+			response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+			return
+		}
+		if len(ir.By) > 256 {
+			response.ErrorString("maximum length of the By field is 256 characters", w, http.StatusUnprocessableEntity)
+			return
+		}
+		h.postIdempotently(key, ir.By, j, w)
+		return
+	}
 	// Start it.
-	j, err = h.JobStarter(j.When, j.ARN, j.Payload, nil)
+	j, duplicate, err := h.JobStarter(j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, nil, j.DedupeKey, h.DedupeWindow)
+	if err != nil {
+		logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to start job")
+		response.ErrorString("failed to start job", w, http.StatusInternalServerError)
+		return
+	}
+	if duplicate {
+		logger.WithJob(pkg, "Post", j).WithField("dedupeKey", j.DedupeKey).Info("job was already started with the dedupe key")
+		response.JSON(PostResponse{Job: j, Duplicate: true}, w, http.StatusOK)
+		return
+	}
+	response.JSON(PostResponse{Job: j}, w, http.StatusCreated)
+}
+
+// idempotentRequest contains the fields of a job request which are only used by idempotency keys.
+type idempotentRequest struct {
+	// By identifies the caller, so that different callers can use the same idempotency keys.
+	By string `json:"by"`
+}
+
+// postIdempotently starts the job unless a job was already started with the idempotency key by the same caller, in
+// which case that job is returned with a 200 status instead of 201.
+func (h *Handler) postIdempotently(key, by string, j data.Job, w http.ResponseWriter) {
+	requestHash, err := idempotency.Hash(j)
+	if err != nil {
+		logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to hash request")
+		response.ErrorString("failed to start job", w, http.StatusInternalServerError)
+		return
+	}
+	ik := data.IdempotencyKey{Key: key, Caller: by, RequestHash: requestHash}
+	j, existing, duplicate, err := h.IdempotentJobStarter(ik, j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, j.DedupeKey, h.DedupeWindow)
+	if err == data.ErrIdempotencyKeyConflict {
+		logger.WithJob(pkg, "Post", j).WithField("idempotencyKey", key).Warn("idempotency key was used for a different request")
+		response.Error(err, w, http.StatusConflict)
+		return
+	}
 	if err != nil {
 		logger.WithJob(pkg, "Post", j).WithError(err).Error("failed to start job")
 		response.ErrorString("failed to start job", w, http.StatusInternalServerError)
 		return
 	}
-	response.JSON(j, w, http.StatusCreated)
+	if existing {
+		logger.WithJob(pkg, "Post", j).WithField("idempotencyKey", key).Info("job was already started with the idempotency key")
+		response.JSON(PostResponse{Job: j}, w, http.StatusOK)
+		return
+	}
+	if duplicate {
+		logger.WithJob(pkg, "Post", j).WithField("dedupeKey", j.DedupeKey).Info("job was already started with the dedupe key")
+		response.JSON(PostResponse{Job: j, Duplicate: true}, w, http.StatusOK)
+		return
+	}
+	response.JSON(PostResponse{Job: j}, w, http.StatusCreated)
+}
+
+// BatchResult is the outcome of one of the jobs in a batch request.
+type BatchResult struct {
+	// JobID is the ID of the started job, or of the existing job if Duplicate is true, it's null if the job wasn't
+	// started.
+	JobID     *int64 `json:"jobId"`
+	Duplicate bool   `json:"duplicate"`
+	// Error is why the job wasn't started. It's empty for valid jobs which weren't started because another job in an
+	// all-or-nothing batch was invalid.
+	Error string `json:"error"`
+}
+
+// BatchResponse is the response to the Batch operation, with a result for each job in the order of the request.
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// Batch starts a batch of jobs, sent as a JSON array, or as newline delimited JSON with a Content-Type of
+// application/x-ndjson. The mode query parameter is allOrNothing, the default, or bestEffort.
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Batch").WithField("url", r.URL).Info("start")
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = BatchModeAllOrNothing
+	}
+	if mode != BatchModeAllOrNothing && mode != BatchModeBestEffort {
+		logger.For(pkg, "Batch").WithField("mode", mode).Error("invalid mode")
+		response.ErrorString("mode must be allOrNothing or bestEffort", w, http.StatusBadRequest)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.For(pkg, "Batch").WithError(err).Error("failed to read body")
+		response.ErrorString("failed to read body", w, http.StatusBadRequest)
+		return
+	}
+	items, err := splitBatch(body, r.Header.Get("Content-Type"))
+	if err != nil {
+		logger.For(pkg, "Batch").WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return
+	}
+	if len(items) == 0 {
+		response.ErrorString("at least one job must be provided", w, http.StatusUnprocessableEntity)
+		return
+	}
+	if len(items) > MaxBatchSize {
+		response.ErrorString("a maximum of 1000 jobs can be started at once", w, http.StatusUnprocessableEntity)
+		return
+	}
+	// Parse and validate every job before starting any of them, keeping the index of each valid job in the request.
+	results := make([]BatchResult, len(items))
+	jobs := make([]data.Job, 0, len(items))
+	indexes := make([]int, 0, len(items))
+	for i, item := range items {
+		var j data.Job
+		if err := json.Unmarshal(item, &j); err != nil {
+			results[i].Error = "failed to parse job"
+			continue
+		}
+		if err := validateJob(j, h.ARNValidator, h.RequestValidator); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		jobs = append(jobs, j)
+		indexes = append(indexes, i)
+	}
+	invalid := len(items) - len(jobs)
+	if mode == BatchModeAllOrNothing {
+		if invalid > 0 {
+			logger.For(pkg, "Batch").WithField("invalid", invalid).Error("failed to validate jobs")
+			response.JSON(BatchResponse{Results: results}, w, http.StatusUnprocessableEntity)
+			return
+		}
+		started, err := h.JobsStarter(jobs, h.DedupeWindow)
+		if err != nil {
+			logger.For(pkg, "Batch").WithError(err).Error("failed to start jobs")
+			response.ErrorString("failed to start jobs", w, http.StatusInternalServerError)
+			return
+		}
+		setBatchResults(results, indexes, started)
+		logger.For(pkg, "Batch").WithField("started", len(started)).Info("started jobs")
+		response.JSON(BatchResponse{Results: results}, w, http.StatusCreated)
+		return
+	}
+	failed := invalid + h.startBestEffort(jobs, indexes, results)
+	logger.For(pkg, "Batch").WithField("started", len(items)-failed).WithField("failed", failed).Info("started jobs")
+	if failed > 0 {
+		response.JSON(BatchResponse{Results: results}, w, http.StatusOK)
+		return
+	}
+	response.JSON(BatchResponse{Results: results}, w, http.StatusCreated)
+}
+
+// splitBatch splits the body of a batch request into the JSON of each job.
+func splitBatch(body []byte, contentType string) (items []json.RawMessage, err error) {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/x-ndjson" {
+		err = json.Unmarshal(body, &items)
+		return
+	}
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			items = append(items, line)
+		}
+	}
+	return
 }
 
-func validateJob(j data.Job) error {
+// startBestEffort starts the jobs in transactions of up to batchChunkSize jobs. If a transaction fails, its jobs are
+// started one at a time, so that a job which can't be started doesn't stop the others. It returns the number of jobs
+// which couldn't be started.
+func (h *Handler) startBestEffort(jobs []data.Job, indexes []int, results []BatchResult) (failed int) {
+	for from := 0; from < len(jobs); from += batchChunkSize {
+		to := from + batchChunkSize
+		if to > len(jobs) {
+			to = len(jobs)
+		}
+		started, err := h.JobsStarter(jobs[from:to], h.DedupeWindow)
+		if err == nil {
+			setBatchResults(results, indexes[from:to], started)
+			continue
+		}
+		logger.For(pkg, "Batch").WithError(err).Warn("failed to start jobs, starting them one at a time")
+		for i := from; i < to; i++ {
+			j, duplicate, err := h.JobStarter(jobs[i].When, jobs[i].ARN, jobs[i].Payload, jobs[i].HTTPRequest, jobs[i].RetryPolicy, nil, jobs[i].DedupeKey, h.DedupeWindow)
+			if err != nil {
+				logger.WithJob(pkg, "Batch", jobs[i]).WithError(err).Error("failed to start job")
+				results[indexes[i]].Error = "failed to start job"
+				failed++
+				continue
+			}
+			setBatchResults(results, indexes[i:i+1], []data.StartedJob{{Job: j, Duplicate: duplicate}})
+		}
+	}
+	return
+}
+
+func setBatchResults(results []BatchResult, indexes []int, started []data.StartedJob) {
+	for i, s := range started {
+		jobID := s.Job.JobID
+		results[indexes[i]].JobID = &jobID
+		results[indexes[i]].Duplicate = s.Duplicate
+	}
+}
+
+func validateJob(j data.Job, validateARN executor.Validator, validateRequest web.RequestValidator) error {
 	if j.JobID > 0 {
 		return errors.New("cannot post to an existing job")
 	}
@@ -80,6 +344,18 @@ func validateJob(j data.Job) error {
 	if j.ScheduleID != nil {
 		return errors.New("cannot post to an existing schedule")
 	}
+	if len(j.DedupeKey) > 256 {
+		return errors.New("maximum length of the dedupeKey is 256 characters")
+	}
+	if err := validateARN(j.ARN); err != nil {
+		return err
+	}
+	if err := validateRequest(j.ARN, j.HTTPRequest); err != nil {
+		return err
+	}
+	if err := retry.Validate(j.RetryPolicy); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -110,14 +386,109 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	attempts, err := h.JobAttemptsGetter(jobID)
+	if err != nil {
+		logger.For(pkg, "Get").WithError(err).WithField("jobID", jobID).Error("failed to retrieve job attempts")
+		response.ErrorString("failed to retrieve job attempts", w, http.StatusInternalServerError)
+		return
+	}
+	duplicates, err := h.JobDuplicatesGetter(jobID)
+	if err != nil {
+		logger.For(pkg, "Get").WithError(err).WithField("jobID", jobID).Error("failed to retrieve job duplicates")
+		response.ErrorString("failed to retrieve job duplicates", w, http.StatusInternalServerError)
+		return
+	}
 	jr := data.JobAndResponse{
 		Job:            job,
 		JobResponse:    jobResp,
 		HasJobResponse: responseOK,
+		Attempts:       attempts,
+		Duplicates:     duplicates,
 	}
 	response.JSON(jr, w, http.StatusOK)
 }
 
+// ListResponse is the response to the List operation.
+type ListResponse struct {
+	Jobs []data.JobSummary `json:"jobs"`
+	// Next is the value of the after parameter used to get the next page, it's null when there are no more results.
+	Next *int64 `json:"next"`
+}
+
+// List lists jobs, using the scheduleId, state, whenFrom, whenTo, arnPrefix, isError, after and limit query
+// parameters. The whenFrom and whenTo parameters are RFC 3339 times, and match jobs which are due at or after
+// whenFrom, and before whenTo.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "List").WithField("url", r.URL).Info("start")
+	filter, after, limit, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		logger.For(pkg, "List").WithError(err).Error("failed to parse query")
+		response.Error(err, w, http.StatusBadRequest)
+		return
+	}
+	jobs, err := h.JobsLister(filter, after, limit)
+	if err != nil {
+		logger.For(pkg, "List").WithError(err).Error("failed to retrieve jobs")
+		response.ErrorString("failed to retrieve jobs", w, http.StatusInternalServerError)
+		return
+	}
+	lr := ListResponse{
+		Jobs: jobs,
+	}
+	if len(jobs) == limit {
+		next := jobs[len(jobs)-1].Job.JobID
+		lr.Next = &next
+	}
+	response.JSON(lr, w, http.StatusOK)
+}
+
+func parseListQuery(q url.Values) (filter data.JobFilter, after int64, limit int, err error) {
+	if s := q.Get("scheduleId"); s != "" {
+		scheduleID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return filter, after, limit, errors.New("failed to parse scheduleId")
+		}
+		filter.ScheduleID = &scheduleID
+	}
+	switch filter.State = q.Get("state"); filter.State {
+	case "", data.JobStatePending, data.JobStateLeased, data.JobStateSucceeded, data.JobStateFailed:
+	default:
+		return filter, after, limit, errors.New("state must be one of pending, leased, succeeded or failed")
+	}
+	if s := q.Get("whenFrom"); s != "" {
+		if filter.WhenFrom, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, after, limit, errors.New("failed to parse whenFrom")
+		}
+	}
+	if s := q.Get("whenTo"); s != "" {
+		if filter.WhenTo, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, after, limit, errors.New("failed to parse whenTo")
+		}
+	}
+	filter.ARNPrefix = q.Get("arnPrefix")
+	if s := q.Get("isError"); s != "" {
+		isError, err := strconv.ParseBool(s)
+		if err != nil {
+			return filter, after, limit, errors.New("failed to parse isError")
+		}
+		filter.IsError = &isError
+	}
+	if s := q.Get("after"); s != "" {
+		if after, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return filter, after, limit, errors.New("failed to parse after")
+		}
+	}
+	limit = DefaultLimit
+	if s := q.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 || l > MaxLimit {
+			return filter, after, limit, errors.New("limit must be between 1 and 1000")
+		}
+		limit = l
+	}
+	return filter, after, limit, nil
+}
+
 // Delete deletes a job by its id.
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	logger.For(pkg, "Delete").WithField("url", r.URL).Info("start")
@@ -5,12 +5,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/executor"
+	"github.com/welldigital/callme/web"
 )
 
 const notFoundMessage = `404 page not found` + "\n"
@@ -19,6 +22,8 @@ func TestGetByID(t *testing.T) {
 	tests := []struct {
 		name           string
 		g              data.JobAndResponseByIDGetter
+		a              data.JobAttemptsGetter
+		d              data.JobDuplicatesGetter
 		r              *http.Request
 		expectedStatus int
 		expectedBody   string
@@ -27,18 +32,43 @@ func TestGetByID(t *testing.T) {
 			name: "success",
 			g: func(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
 				j = data.Job{
-					ARN:        "testarn",
-					JobID:      1,
-					Payload:    "testpayload",
-					ScheduleID: nil,
-					When:       time.Date(2000, time.January, 1, 1, 1, 0, 0, time.UTC),
+					ARN:          "testarn",
+					JobID:        1,
+					Payload:      "testpayload",
+					ScheduleID:   nil,
+					When:         time.Date(2000, time.January, 1, 1, 1, 0, 0, time.UTC),
+					AttemptCount: 1,
 				}
 				jobOK = true
 				return
 			},
+			a: func(jobID int64) ([]data.JobAttempt, error) {
+				return []data.JobAttempt{
+					{
+						JobAttemptID: 1,
+						JobID:        jobID,
+						Attempt:      1,
+						Time:         time.Date(2000, time.January, 1, 1, 1, 1, 0, time.UTC),
+						IsError:      true,
+						Error:        "failed",
+						RetryAt:      time.Date(2000, time.January, 1, 1, 1, 2, 0, time.UTC),
+					},
+				}, nil
+			},
+			d: func(jobID int64) ([]data.JobDuplicate, error) {
+				return []data.JobDuplicate{
+					{
+						JobDuplicateID: 1,
+						JobID:          jobID,
+						JobLeaseID:     2,
+						Time:           time.Date(2000, time.January, 1, 1, 1, 3, 0, time.UTC),
+						Response:       "late",
+					},
+				}, nil
+			},
 			r:              httptest.NewRequest("GET", "/job/1", nil),
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"job":{"jobId":1,"scheduleId":null,"when":"2000-01-01T01:01:00Z","arn":"testarn","payload":"testpayload"},"response":{"jobResponseId":0,"jobId":0,"time":"0001-01-01T00:00:00Z","response":"","isError":false,"error":""},"hasJobResponse":false}`,
+			expectedBody:   `{"job":{"jobId":1,"scheduleId":null,"when":"2000-01-01T01:01:00Z","arn":"testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"attemptCount":1,"dedupeKey":""},"response":{"jobResponseId":0,"jobId":0,"time":"0001-01-01T00:00:00Z","response":"","isError":false,"error":""},"hasJobResponse":false,"attempts":[{"jobAttemptId":1,"jobId":1,"attempt":1,"time":"2000-01-01T01:01:01Z","response":"","isError":true,"error":"failed","retryAt":"2000-01-01T01:01:02Z"}],"duplicates":[{"jobDuplicateId":1,"jobId":1,"jobLeaseId":2,"time":"2000-01-01T01:01:03Z","response":"late","isError":false,"error":""}]}`,
 		},
 		{
 			name:           "missing id",
@@ -63,6 +93,35 @@ func TestGetByID(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"err":"failed to retrieve job"}`,
 		},
+		{
+			name: "failed to get job attempts",
+			g: func(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+				jobOK = true
+				return
+			},
+			a: func(jobID int64) ([]data.JobAttempt, error) {
+				return nil, errors.New("failed to get job attempts")
+			},
+			r:              httptest.NewRequest("GET", "/job/1", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve job attempts"}`,
+		},
+		{
+			name: "failed to get job duplicates",
+			g: func(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+				jobOK = true
+				return
+			},
+			a: func(jobID int64) ([]data.JobAttempt, error) {
+				return nil, nil
+			},
+			d: func(jobID int64) ([]data.JobDuplicate, error) {
+				return nil, errors.New("failed to get job duplicates")
+			},
+			r:              httptest.NewRequest("GET", "/job/1", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve job duplicates"}`,
+		},
 		{
 			name: "job not found",
 			g: func(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
@@ -77,7 +136,7 @@ func TestGetByID(t *testing.T) {
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		jh := New(test.g, nil, nil)
+		jh := New(test.g, test.a, test.d, nil, nil, nil, nil, nil, nil, nil, DefaultDedupeWindow)
 		router.Path("/job/{id}").Methods(http.MethodGet).HandlerFunc(jh.Get)
 
 		w := httptest.NewRecorder()
@@ -96,6 +155,154 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestList(t *testing.T) {
+	summary := data.JobSummary{
+		Job: data.Job{
+			JobID:        3,
+			ARN:          "https://example.com",
+			Payload:      "testpayload",
+			When:         time.Date(2000, time.January, 1, 1, 1, 0, 0, time.UTC),
+			AttemptCount: 2,
+		},
+		State: data.JobStateFailed,
+	}
+	const summaryJSON = `{"job":{"jobId":3,"scheduleId":null,"when":"2000-01-01T01:01:00Z","arn":"https://example.com","payload":"testpayload","httpRequest":null,"retryPolicy":null,"attemptCount":2,"dedupeKey":""},"state":"failed"}`
+	scheduleID := int64(42)
+	isError := true
+
+	tests := []struct {
+		name           string
+		l              data.JobsLister
+		r              *http.Request
+		expectedFilter data.JobFilter
+		expectedAfter  int64
+		expectedLimit  int
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "defaults",
+			l: func(filter data.JobFilter, afterID int64, limit int) ([]data.JobSummary, error) {
+				return []data.JobSummary{summary}, nil
+			},
+			r:              httptest.NewRequest("GET", "/job", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"jobs":[` + summaryJSON + `],"next":null}`,
+		},
+		{
+			name: "filters and full page returns next",
+			l: func(filter data.JobFilter, afterID int64, limit int) ([]data.JobSummary, error) {
+				return []data.JobSummary{summary}, nil
+			},
+			r: httptest.NewRequest("GET", "/job?scheduleId=42&state=failed&whenFrom=2000-01-01T00:00:00Z&whenTo=2000-01-02T00:00:00%2B01:00"+
+				"&arnPrefix=https://example.com&isError=true&after=2&limit=1", nil),
+			expectedFilter: data.JobFilter{
+				ScheduleID: &scheduleID,
+				State:      data.JobStateFailed,
+				WhenFrom:   time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+				WhenTo:     time.Date(2000, time.January, 1, 23, 0, 0, 0, time.UTC),
+				ARNPrefix:  "https://example.com",
+				IsError:    &isError,
+			},
+			expectedAfter:  2,
+			expectedLimit:  1,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"jobs":[` + summaryJSON + `],"next":3}`,
+		},
+		{
+			name:           "invalid scheduleId",
+			r:              httptest.NewRequest("GET", "/job?scheduleId=first", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse scheduleId"}`,
+		},
+		{
+			name:           "invalid state",
+			r:              httptest.NewRequest("GET", "/job?state=asleep", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"state must be one of pending, leased, succeeded or failed"}`,
+		},
+		{
+			name:           "invalid whenFrom",
+			r:              httptest.NewRequest("GET", "/job?whenFrom=yesterday", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse whenFrom"}`,
+		},
+		{
+			name:           "invalid whenTo",
+			r:              httptest.NewRequest("GET", "/job?whenTo=2000-01-01", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse whenTo"}`,
+		},
+		{
+			name:           "invalid isError",
+			r:              httptest.NewRequest("GET", "/job?isError=maybe", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse isError"}`,
+		},
+		{
+			name:           "invalid after",
+			r:              httptest.NewRequest("GET", "/job?after=first", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse after"}`,
+		},
+		{
+			name:           "limit too large",
+			r:              httptest.NewRequest("GET", "/job?limit=1001", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"limit must be between 1 and 1000"}`,
+		},
+		{
+			name: "failed to list jobs",
+			l: func(filter data.JobFilter, afterID int64, limit int) ([]data.JobSummary, error) {
+				return nil, errors.New("database error")
+			},
+			r:              httptest.NewRequest("GET", "/job", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve jobs"}`,
+		},
+	}
+
+	for _, test := range tests {
+		var actualFilter data.JobFilter
+		var actualAfter int64
+		var actualLimit int
+		var l data.JobsLister
+		if test.l != nil {
+			l = func(filter data.JobFilter, afterID int64, limit int) ([]data.JobSummary, error) {
+				actualFilter, actualAfter, actualLimit = filter, afterID, limit
+				return test.l(filter, afterID, limit)
+			}
+		}
+		router := mux.NewRouter()
+		jh := New(nil, nil, nil, l, nil, nil, nil, nil, nil, nil, DefaultDedupeWindow)
+		router.Path("/job").Methods(http.MethodGet).HandlerFunc(jh.List)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+		if !actualFilter.WhenTo.Equal(test.expectedFilter.WhenTo) {
+			t.Errorf("%s: expected whenTo %v, got %v", test.name, test.expectedFilter.WhenTo, actualFilter.WhenTo)
+		}
+		actualFilter.WhenTo, test.expectedFilter.WhenTo = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(actualFilter, test.expectedFilter) || actualAfter != test.expectedAfter || actualLimit != test.expectedLimit {
+			t.Errorf("%s: expected filter=%+v, after=%v, limit=%v, got filter=%+v, after=%v, limit=%v", test.name,
+				test.expectedFilter, test.expectedAfter, test.expectedLimit, actualFilter, actualAfter, actualLimit)
+		}
+	}
+}
+
 func TestDelete(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -143,7 +350,7 @@ func TestDelete(t *testing.T) {
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		jh := New(nil, nil, test.d)
+		jh := New(nil, nil, nil, nil, nil, nil, nil, test.d, nil, nil, DefaultDedupeWindow)
 		router.Path("/job/{id}/delete").Methods(http.MethodPost).HandlerFunc(jh.Delete)
 
 		w := httptest.NewRecorder()
@@ -173,17 +380,19 @@ func TestPost(t *testing.T) {
 		{
 			name: "successful post",
 			r: httptest.NewRequest("POST", "/job/",
-				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "example.com", "payload": "test_payload" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"example.com","payload":"test_payload"}`,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":null,"retryPolicy":null,"attemptCount":0,"dedupeKey":"","duplicate":false}`,
 		},
 		{
 			name:           "malformed body",
@@ -202,9 +411,9 @@ func TestPost(t *testing.T) {
 		{
 			name: "failure to start job",
 			r: httptest.NewRequest("POST", "/job/",
-				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "example.com", "payload": "test_payload" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
-				return data.Job{}, errors.New("failed to start job")
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
+				return data.Job{}, false, errors.New("failed to start job")
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"err":"failed to start job"}`,
@@ -212,14 +421,16 @@ func TestPost(t *testing.T) {
 		{
 			name: "try and update an existing job fails",
 			r: httptest.NewRequest("POST", "/job/",
-				strings.NewReader(`{ "jobId": 1, "when": "2000-01-01T00:00:00Z", "arn": "example.com", "payload": "test_payload" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+				strings.NewReader(`{ "jobId": 1, "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"cannot post to an existing job"}`,
@@ -227,14 +438,16 @@ func TestPost(t *testing.T) {
 		{
 			name: "try and update a schedule fails",
 			r: httptest.NewRequest("POST", "/job/",
-				strings.NewReader(`{ "scheduleId": 35, "when": "2000-01-01T00:00:00Z", "arn": "example.com", "payload": "test_payload" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+				strings.NewReader(`{ "scheduleId": 35, "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"cannot post to an existing schedule"}`,
@@ -243,13 +456,15 @@ func TestPost(t *testing.T) {
 			name: "missing ARN fails",
 			r: httptest.NewRequest("POST", "/job/",
 				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "payload": "test_payload" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"ARN is required"}`,
@@ -258,13 +473,15 @@ func TestPost(t *testing.T) {
 			name: "ARN is too big",
 			r: httptest.NewRequest("POST", "/job/",
 				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "payload": "test_payload", "arn": "`+longString(3000)+`" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"maximum length of the ARN is 2048 characters"}`,
@@ -273,22 +490,116 @@ func TestPost(t *testing.T) {
 			name: "Payload is too big",
 			r: httptest.NewRequest("POST", "/job/",
 				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "test_arn", "payload": "`+longString(1024*1024*32)+`" }`)),
-			s: func(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
 				return data.Job{
-					JobID:      1,
-					When:       when,
-					ARN:        arn,
-					Payload:    payload,
-					ScheduleID: scheduleID}, nil
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"exceeded maximum length of payload"}`,
 		},
+		{
+			name: "unsupported ARN scheme fails",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "ftp://example.com", "payload": "test_payload" }`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"unsupported ARN scheme 'ftp'"}`,
+		},
+		{
+			name: "ARN without a scheme fails",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "example.com", "payload": "test_payload" }`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"ARN must be an AWS ARN or an absolute URL"}`,
+		},
+		{
+			name: "successful post with HTTP request",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "httpRequest": { "method": "PUT", "headers": { "Authorization": "Bearer token" }, "contentType": "text/plain" } }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
+				return data.Job{
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":{"method":"PUT","headers":{"Authorization":"Bearer token"},"contentType":"text/plain","signingKey":""},"retryPolicy":null,"attemptCount":0,"dedupeKey":"","duplicate":false}`,
+		},
+		{
+			name: "successful post with retry policy",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "retryPolicy": { "maxAttempts": 3, "initialIntervalSeconds": 60 } }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
+				return data.Job{
+					JobID:       1,
+					When:        when,
+					ARN:         arn,
+					Payload:     payload,
+					HTTPRequest: httpRequest,
+					RetryPolicy: retryPolicy,
+					ScheduleID:  scheduleID}, false, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":null,"retryPolicy":{"maxAttempts":3,"initialIntervalSeconds":60,"maxIntervalSeconds":0,"multiplier":0},"attemptCount":0,"dedupeKey":"","duplicate":false}`,
+		},
+		{
+			name: "invalid retry policy fails",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "retryPolicy": { "maxAttempts": 1000 } }`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"retryPolicy.maxAttempts must be 0 (default) or between 1 and 100"}`,
+		},
+		{
+			name: "duplicate job",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "dedupeKey": "order_1" }`)),
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
+				if dedupeWindow != DefaultDedupeWindow {
+					return data.Job{}, false, errors.New("unexpected dedupe window")
+				}
+				return data.Job{
+					JobID:     1,
+					When:      when,
+					ARN:       arn,
+					Payload:   "first_payload",
+					DedupeKey: dedupeKey}, true, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"first_payload","httpRequest":null,"retryPolicy":null,"attemptCount":0,"dedupeKey":"order_1","duplicate":true}`,
+		},
+		{
+			name: "dedupe key too long fails",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "dedupeKey": "`+longString(257)+`" }`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"maximum length of the dedupeKey is 256 characters"}`,
+		},
+		{
+			name: "invalid HTTP request fails",
+			r: httptest.NewRequest("POST", "/job/",
+				strings.NewReader(`{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "httpRequest": { "method": "CONNECT" } }`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"unsupported HTTP method 'CONNECT'"}`,
+		},
 	}
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		jh := New(nil, test.s, nil)
+		jh := New(nil, nil, nil, nil, test.s, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest, DefaultDedupeWindow)
 		router.Path("/job/").Methods(http.MethodPost).HandlerFunc(jh.Post)
 
 		w := httptest.NewRecorder()
@@ -307,6 +618,279 @@ func TestPost(t *testing.T) {
 	}
 }
 
+func TestPostIdempotently(t *testing.T) {
+	body := `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`
+	expectedBody := `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":null,"retryPolicy":null,"attemptCount":0,"dedupeKey":"","duplicate":false}`
+	started := func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+		if key.Key != "abc" || key.Caller != "" || len(key.RequestHash) != 64 {
+			return data.Job{}, false, false, errors.New("unexpected key")
+		}
+		return data.Job{JobID: 1, When: when, ARN: arn, Payload: payload}, false, false, nil
+	}
+	tests := []struct {
+		name           string
+		key            string
+		body           string
+		s              data.IdempotentJobStarter
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "new job",
+			key:            "abc",
+			body:           body,
+			s:              started,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   expectedBody,
+		},
+		{
+			name:           "key in the body",
+			body:           `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "idempotencyKey": "abc" }`,
+			s:              started,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   expectedBody,
+		},
+		{
+			name: "key scoped to the caller",
+			key:  "abc",
+			body: `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "by": "system_a" }`,
+			s: func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+				if key.Key != "abc" || key.Caller != "system_a" {
+					return data.Job{}, false, false, errors.New("unexpected key")
+				}
+				return data.Job{JobID: 1, When: when, ARN: arn, Payload: payload}, false, false, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   expectedBody,
+		},
+		{
+			name:           "caller too long",
+			key:            "abc",
+			body:           `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "by": "` + longString(257) + `" }`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"maximum length of the By field is 256 characters"}`,
+		},
+		{
+			name: "existing job",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+				return data.Job{JobID: 1, When: when, ARN: arn, Payload: payload, AttemptCount: 1}, true, false, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":null,"retryPolicy":null,"attemptCount":1,"dedupeKey":"","duplicate":false}`,
+		},
+		{
+			name: "duplicate job",
+			key:  "abc",
+			body: `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "dedupeKey": "order_1" }`,
+			s: func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+				return data.Job{JobID: 1, When: when, ARN: arn, Payload: payload, DedupeKey: dedupeKey}, false, true, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"jobId":1,"scheduleId":null,"when":"2000-01-01T00:00:00Z","arn":"https://example.com","payload":"test_payload","httpRequest":null,"retryPolicy":null,"attemptCount":0,"dedupeKey":"order_1","duplicate":true}`,
+		},
+		{
+			name: "key used for a different request",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+				return data.Job{}, false, false, data.ErrIdempotencyKeyConflict
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"err":"the idempotency key was used for a different request"}`,
+		},
+		{
+			name: "failure to start job",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, bool, error) {
+				return data.Job{}, false, false, errors.New("failed to access database")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to start job"}`,
+		},
+		{
+			name:           "header and body keys differ",
+			key:            "abc",
+			body:           `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "idempotencyKey": "def" }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"the Idempotency-Key header and idempotencyKey field must match"}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		jh := New(nil, nil, nil, nil, nil, test.s, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest, DefaultDedupeWindow)
+		router.Path("/job/").Methods(http.MethodPost).HandlerFunc(jh.Post)
+
+		r := httptest.NewRequest("POST", "/job/", strings.NewReader(test.body))
+		if test.key != "" {
+			r.Header.Set("Idempotency-Key", test.key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	validJob := `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload" }`
+	duplicateJob := `{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "test_payload", "dedupeKey": "order_1" }`
+	invalidJob := `{ "when": "2000-01-01T00:00:00Z", "arn": "", "payload": "test_payload" }`
+	// started numbers each job from 1, and treats jobs with the order_1 dedupe key as a duplicate of job 100.
+	started := func(jobs []data.Job, dedupeWindow time.Duration) ([]data.StartedJob, error) {
+		if dedupeWindow != DefaultDedupeWindow {
+			return nil, errors.New("unexpected dedupe window")
+		}
+		s := make([]data.StartedJob, len(jobs))
+		for i, j := range jobs {
+			if j.DedupeKey == "order_1" {
+				s[i] = data.StartedJob{Job: data.Job{JobID: 100, DedupeKey: j.DedupeKey}, Duplicate: true}
+				continue
+			}
+			s[i] = data.StartedJob{Job: data.Job{JobID: int64(i + 1)}}
+		}
+		return s, nil
+	}
+	failed := func(jobs []data.Job, dedupeWindow time.Duration) ([]data.StartedJob, error) {
+		return nil, errors.New("failed to access database")
+	}
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		bs             data.JobsStarter
+		s              data.JobStarter
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "JSON array",
+			body:           "[" + validJob + "," + duplicateJob + "," + validJob + "]",
+			bs:             started,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"results":[{"jobId":1,"duplicate":false,"error":""},{"jobId":100,"duplicate":true,"error":""},{"jobId":3,"duplicate":false,"error":""}]}`,
+		},
+		{
+			name:           "newline delimited JSON",
+			contentType:    "application/x-ndjson",
+			body:           validJob + "\n\n" + validJob + "\r\n",
+			bs:             started,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"results":[{"jobId":1,"duplicate":false,"error":""},{"jobId":2,"duplicate":false,"error":""}]}`,
+		},
+		{
+			name:           "invalid jobs aren't started in an all-or-nothing batch",
+			body:           "[" + validJob + "," + invalidJob + `,"not_a_job"]`,
+			bs:             nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"results":[{"jobId":null,"duplicate":false,"error":""},{"jobId":null,"duplicate":false,"error":"ARN is required"},{"jobId":null,"duplicate":false,"error":"failed to parse job"}]}`,
+		},
+		{
+			name:           "failure to start an all-or-nothing batch",
+			body:           "[" + validJob + "]",
+			bs:             failed,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to start jobs"}`,
+		},
+		{
+			name:           "valid jobs are started in a best effort batch",
+			query:          "?mode=bestEffort",
+			body:           "[" + invalidJob + "," + validJob + "]",
+			bs:             started,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"results":[{"jobId":null,"duplicate":false,"error":"ARN is required"},{"jobId":1,"duplicate":false,"error":""}]}`,
+		},
+		{
+			name:  "best effort batch starts jobs one at a time if the transaction fails",
+			query: "?mode=bestEffort",
+			body:  "[" + validJob + "," + duplicateJob + `,{ "when": "2000-01-01T00:00:00Z", "arn": "https://example.com", "payload": "fail" }]`,
+			bs:    failed,
+			s: func(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (data.Job, bool, error) {
+				if payload == "fail" {
+					return data.Job{}, false, errors.New("failed to start job")
+				}
+				if dedupeKey != "" {
+					return data.Job{JobID: 100, DedupeKey: dedupeKey}, true, nil
+				}
+				return data.Job{JobID: 1}, false, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"results":[{"jobId":1,"duplicate":false,"error":""},{"jobId":100,"duplicate":true,"error":""},{"jobId":null,"duplicate":false,"error":"failed to start job"}]}`,
+		},
+		{
+			name:           "best effort batch with every job started",
+			query:          "?mode=bestEffort",
+			body:           "[" + validJob + "]",
+			bs:             started,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"results":[{"jobId":1,"duplicate":false,"error":""}]}`,
+		},
+		{
+			name:           "invalid mode",
+			query:          "?mode=some",
+			body:           "[" + validJob + "]",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"mode must be allOrNothing or bestEffort"}`,
+		},
+		{
+			name:           "invalid JSON body",
+			body:           validJob,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"failed to parse request"}`,
+		},
+		{
+			name:           "empty batch",
+			body:           "[]",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"at least one job must be provided"}`,
+		},
+		{
+			name:           "too many jobs",
+			contentType:    "application/x-ndjson",
+			body:           strings.Repeat(validJob+"\n", MaxBatchSize+1),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"a maximum of 1000 jobs can be started at once"}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		jh := New(nil, nil, nil, nil, test.s, nil, test.bs, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest, DefaultDedupeWindow)
+		router.Path("/jobs:batch").Methods(http.MethodPost).HandlerFunc(jh.Batch)
+
+		r := httptest.NewRequest("POST", "/jobs:batch"+test.query, strings.NewReader(test.body))
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
 type ReaderFunc struct {
 	F func(p []byte) (n int, err error)
 }
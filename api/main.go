@@ -10,13 +10,14 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/welldigital/callme/api/schedule"
-
-	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/welldigital/callme/api/job"
+	"github.com/welldigital/callme/api/routes"
+	"github.com/welldigital/callme/executor"
 	"github.com/welldigital/callme/logger"
-	"github.com/welldigital/callme/mysql"
+	"github.com/welldigital/callme/metrics"
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/web"
 )
 
 const pkg = "github.com/welldigital/callme/api"
@@ -33,6 +34,12 @@ func main() {
 	}
 	apiPort := getIntegerSetting("CALLME_API_PORT", 8080)
 	prometheusPort := getIntegerSetting("CALLME_PROMETHEUS_PORT", 7777)
+	dedupeWindow := time.Minute * time.Duration(getIntegerSetting("CALLME_DEDUPE_WINDOW_MINUTES", int(job.DefaultDedupeWindow/time.Minute)))
+	poolOptions := storage.PoolOptions{
+		MaxOpenConnections:    getIntegerSetting("CALLME_DB_MAX_OPEN_CONNECTIONS", 0),
+		MaxIdleConnections:    getIntegerSetting("CALLME_DB_MAX_IDLE_CONNECTIONS", 2),
+		ConnectionMaxLifetime: time.Second * time.Duration(getIntegerSetting("CALLME_DB_MAX_LIFETIME_SECONDS", 300)),
+	}
 
 	go func() {
 		logger.For(pkg, "main").Info("starting prometheus listener")
@@ -41,18 +48,22 @@ func main() {
 		http.ListenAndServe(fmt.Sprintf(":%v", prometheusPort), r)
 	}()
 
-	logger.For(pkg, "main").Info("creating job handler and router")
-
-	r := mux.NewRouter()
-	r.NotFoundHandler = NotFoundHandler{}
+	store, err := storage.Open(connectionString, poolOptions)
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Error("failed to open the database")
+		os.Exit(-1)
+	}
+	logger.For(pkg, "main").WithField("backend", store.Backend).Info("using storage backend")
+	if store.DB != nil {
+		prometheus.MustRegister(metrics.NewDatabaseStatsCollector(store.DB))
+	}
+	if store.Backend == storage.Memory {
+		logger.For(pkg, "main").Warn("the memory backend isn't shared with workers, run the worker with CALLME_API_PORT set instead")
+	}
 
-	jm := mysql.NewJobManager(connectionString)
-	jh := job.New(jm.GetJobResponse, jm.StartJob, jm.DeleteJob)
-	addJobRoutes(r, jh)
+	logger.For(pkg, "main").Info("creating job handler and router")
 
-	sm := mysql.NewScheduleManager(connectionString)
-	sh := schedule.New(sm.Create, sm.GetScheduleByID, sm.Deactivate)
-	addScheduleRoutes(r, sh)
+	r := routes.New(store, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest, dedupeWindow)
 
 	s := &http.Server{
 		Addr:           fmt.Sprintf(":%v", apiPort),
@@ -72,10 +83,14 @@ func main() {
 
 	<-sigs
 	logger.For(pkg, "main").Info("shutting down")
-	err := s.Close()
+	err = s.Close()
 	if err != nil {
 		logger.For(pkg, "main").WithError(err).Error("failed to shut down server")
 	}
+	err = store.Close()
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Error("failed to close the database")
+	}
 	logger.For(pkg, "main").Info("complete")
 }
 
@@ -93,15 +108,3 @@ func getIntegerSetting(n string, def int) int {
 	}
 	return int(i)
 }
-
-func addJobRoutes(r *mux.Router, jh *job.Handler) {
-	r.Path("/job").Methods(http.MethodPost).HandlerFunc(jh.Post)
-	r.Path("/job/{id}").Methods(http.MethodGet).HandlerFunc(jh.Get)
-	r.Path("/job/{id}/delete").Methods(http.MethodPost).HandlerFunc(jh.Delete)
-}
-
-func addScheduleRoutes(r *mux.Router, sh *schedule.Handler) {
-	r.Path("/schedule").Methods(http.MethodPost).HandlerFunc(sh.Post)
-	r.Path("/schedule/{id}").Methods(http.MethodGet).HandlerFunc(sh.Get)
-	r.Path("/schedule/{id}/deactivate").Methods(http.MethodPost).HandlerFunc(sh.Deactivate)
-}
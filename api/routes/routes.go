@@ -0,0 +1,61 @@
+// Package routes creates the API's router, so that the API can be served by the api executable, or by the worker
+// when the worker and API run as a single process.
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/welldigital/callme/api/deadletter"
+	"github.com/welldigital/callme/api/job"
+	"github.com/welldigital/callme/api/schedule"
+	"github.com/welldigital/callme/executor"
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/web"
+)
+
+const pkg = "github.com/welldigital/callme/api/routes"
+
+// New creates the API's router, using the store to manage jobs, dead letters and schedules. Jobs with a dedupeKey hold
+// the key for the dedupeWindow.
+func New(store storage.Store, arnValidator executor.Validator, requestValidator web.RequestValidator, dedupeWindow time.Duration) *mux.Router {
+	r := mux.NewRouter()
+	r.NotFoundHandler = NotFoundHandler{}
+
+	jh := job.New(store.JobAndResponseByIDGetter, store.JobAttemptsGetter, store.JobDuplicatesGetter, store.JobsLister, store.JobStarter, store.IdempotentJobStarter, store.JobsStarter, store.JobDeleter, arnValidator, requestValidator, dedupeWindow)
+	addJobRoutes(r, jh)
+
+	dh := deadletter.New(store.DeadLettersGetter, store.DeadLetterRequeuer)
+	addDeadLetterRoutes(r, dh)
+
+	sh := schedule.New(store.ScheduleCreator, store.IdempotentScheduleCreator, store.ScheduleUpdater, store.ScheduleByIDGetter, store.SchedulesLister,
+		store.ScheduleDeactivator, store.SchedulePauser, store.ScheduleResumer, arnValidator, requestValidator)
+	addScheduleRoutes(r, sh)
+
+	return r
+}
+
+func addJobRoutes(r *mux.Router, jh *job.Handler) {
+	r.Path("/job").Methods(http.MethodGet).HandlerFunc(jh.List)
+	r.Path("/job").Methods(http.MethodPost).HandlerFunc(jh.Post)
+	r.Path("/jobs:batch").Methods(http.MethodPost).HandlerFunc(jh.Batch)
+	r.Path("/job/{id}").Methods(http.MethodGet).HandlerFunc(jh.Get)
+	r.Path("/job/{id}/delete").Methods(http.MethodPost).HandlerFunc(jh.Delete)
+}
+
+func addDeadLetterRoutes(r *mux.Router, dh *deadletter.Handler) {
+	r.Path("/deadletter").Methods(http.MethodGet).HandlerFunc(dh.List)
+	r.Path("/deadletter/requeue").Methods(http.MethodPost).HandlerFunc(dh.Requeue)
+}
+
+func addScheduleRoutes(r *mux.Router, sh *schedule.Handler) {
+	r.Path("/schedule").Methods(http.MethodGet).HandlerFunc(sh.List)
+	r.Path("/schedule").Methods(http.MethodPost).HandlerFunc(sh.Post)
+	r.Path("/schedule/{id}").Methods(http.MethodGet).HandlerFunc(sh.Get)
+	r.Path("/schedule/{id}").Methods(http.MethodPut).HandlerFunc(sh.Put)
+	r.Path("/schedule/{id}").Methods(http.MethodPatch).HandlerFunc(sh.Patch)
+	r.Path("/schedule/{id}/deactivate").Methods(http.MethodPost).HandlerFunc(sh.Deactivate)
+	r.Path("/schedule/{id}/pause").Methods(http.MethodPost).HandlerFunc(sh.Pause)
+	r.Path("/schedule/{id}/resume").Methods(http.MethodPost).HandlerFunc(sh.Resume)
+}
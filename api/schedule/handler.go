@@ -1,43 +1,70 @@
 package schedule
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strconv"
 	"time"
 
+	"github.com/welldigital/callme/api/idempotency"
 	"github.com/welldigital/callme/api/response"
+	"github.com/welldigital/callme/crontab"
 	"github.com/welldigital/callme/data"
-	cron "gopkg.in/robfig/cron.v2"
+	"github.com/welldigital/callme/executor"
 
 	"github.com/gorilla/mux"
 	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/retry"
+	"github.com/welldigital/callme/web"
 )
 
+// DefaultLimit is the number of schedules returned by List when no limit is specified.
+const DefaultLimit = 100
+
+// MaxLimit is the maximum number of schedules that can be listed in a single request.
+const MaxLimit = 1000
+
 // Handler is the HTTP handler for the /schedule path of the API.
 type Handler struct {
-	ScheduleCreator     data.ScheduleCreator
-	ScheduleByIDGetter  data.ScheduleByIDGetter
-	ScheduleDeactivator data.ScheduleDeactivator
+	ScheduleCreator           data.ScheduleCreator
+	IdempotentScheduleCreator data.IdempotentScheduleCreator
+	ScheduleUpdater           data.ScheduleUpdater
+	ScheduleByIDGetter        data.ScheduleByIDGetter
+	SchedulesLister           data.SchedulesLister
+	ScheduleDeactivator       data.ScheduleDeactivator
+	SchedulePauser            data.SchedulePauser
+	ScheduleResumer           data.ScheduleResumer
+	ARNValidator              executor.Validator
+	RequestValidator          web.RequestValidator
 }
 
 // PostRequest is the request that must be passed to create a schedule.
 type PostRequest struct {
-	ScheduleID int64     `json:"scheduleId"`
-	From       time.Time `json:"from"`
-	ARN        string    `json:"arn"`
-	Payload    string    `json:"payload"`
-	Crontabs   []string  `json:"crontabs"`
-	ExternalID string    `json:"externalId"`
-	By         string    `json:"by"`
+	ScheduleID    int64             `json:"scheduleId"`
+	From          time.Time         `json:"from"`
+	ARN           string            `json:"arn"`
+	Payload       string            `json:"payload"`
+	HTTPRequest   *data.HTTPRequest `json:"httpRequest"`
+	RetryPolicy   *data.RetryPolicy `json:"retryPolicy"`
+	Crontabs      []string          `json:"crontabs"`
+	Timezone      string            `json:"timezone"`
+	Until         time.Time         `json:"until"`
+	MaxRuns       int               `json:"maxRuns"`
+	MisfirePolicy string            `json:"misfirePolicy"`
+	ExternalID    string            `json:"externalId"`
+	By            string            `json:"by"`
 }
 
-// Validate that the SchedulePostRequest is valid.
-func (spr PostRequest) Validate() error {
+// Validate that the SchedulePostRequest is valid, using validateARN to check that the ARN can be executed, and
+// validateRequest to check that the httpRequest can be used with it.
+func (spr PostRequest) Validate(validateARN executor.Validator, validateRequest web.RequestValidator) error {
 	if spr.ScheduleID > 0 {
 		return errors.New("cannot post to an existing schedule")
 	}
@@ -62,25 +89,257 @@ func (spr PostRequest) Validate() error {
 	if len(spr.Crontabs) == 0 {
 		return errors.New("at least one crontab must be provided")
 	}
+	if !spr.Until.IsZero() && !spr.Until.After(spr.From) {
+		return errors.New("until must be after from")
+	}
+	if !spr.Until.IsZero() && !spr.Until.After(time.Now()) {
+		return errors.New("until must be in the future")
+	}
+	if spr.MaxRuns < 0 {
+		return errors.New("maxRuns must not be negative")
+	}
+	switch spr.MisfirePolicy {
+	case "", data.MisfirePolicyFireAll, data.MisfirePolicyFireOnce, data.MisfirePolicySkip:
+	default:
+		return errors.New("misfirePolicy must be one of fireAll, fireOnce or skip")
+	}
+	if _, err := crontab.Location(spr.Timezone); err != nil {
+		return err
+	}
 	for _, ct := range spr.Crontabs {
-		_, err := cron.Parse(ct)
+		_, err := crontab.Parse(ct, spr.Timezone)
 		if err != nil {
 			logger.For(pkg, "PostRequest.Validate").
 				WithField("crontab", ct).
+				WithField("timezone", spr.Timezone).
 				WithError(err).
 				Error("failed to parse")
 			return fmt.Errorf("failed to parse crontab with error '%v'", err)
 		}
 	}
+	if err := validateARN(spr.ARN); err != nil {
+		return err
+	}
+	if err := validateRequest(spr.ARN, spr.HTTPRequest); err != nil {
+		return err
+	}
+	if err := retry.Validate(spr.RetryPolicy); err != nil {
+		return err
+	}
 	return nil
 }
 
+// PutRequest is the request that must be passed to update a schedule. The scheduleId is taken from the URL.
+type PutRequest struct {
+	ScheduleID    int64             `json:"scheduleId"`
+	ARN           string            `json:"arn"`
+	Payload       string            `json:"payload"`
+	HTTPRequest   *data.HTTPRequest `json:"httpRequest"`
+	RetryPolicy   *data.RetryPolicy `json:"retryPolicy"`
+	Crontabs      []string          `json:"crontabs"`
+	Timezone      string            `json:"timezone"`
+	Until         time.Time         `json:"until"`
+	MaxRuns       int               `json:"maxRuns"`
+	MisfirePolicy string            `json:"misfirePolicy"`
+	ExternalID    string            `json:"externalId"`
+	By            string            `json:"by"`
+}
+
+// newPutRequest creates a PutRequest containing the current values of a schedule, so that a PATCH request
+// only needs to contain the fields which are changing.
+func newPutRequest(sc data.ScheduleCrontabs) PutRequest {
+	crontabs := make([]string, len(sc.Crontabs))
+	for i, ct := range sc.Crontabs {
+		crontabs[i] = ct.Crontab
+	}
+	return PutRequest{
+		ScheduleID:    sc.Schedule.ScheduleID,
+		ARN:           sc.Schedule.ARN,
+		Payload:       sc.Schedule.Payload,
+		HTTPRequest:   copyHTTPRequest(sc.Schedule.HTTPRequest),
+		RetryPolicy:   copyRetryPolicy(sc.Schedule.RetryPolicy),
+		Crontabs:      crontabs,
+		Timezone:      sc.Schedule.Timezone,
+		Until:         sc.Schedule.Until,
+		MaxRuns:       sc.Schedule.MaxRuns,
+		MisfirePolicy: sc.Schedule.MisfirePolicy,
+		ExternalID:    sc.Schedule.ExternalID,
+		By:            sc.Schedule.By,
+	}
+}
+
+// copyHTTPRequest returns a deep copy of r, so that unmarshalling a PATCH request into it doesn't
+// modify the schedule which it was copied from.
+func copyHTTPRequest(r *data.HTTPRequest) *data.HTTPRequest {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	if r.Headers != nil {
+		c.Headers = make(map[string]string, len(r.Headers))
+		for k, v := range r.Headers {
+			c.Headers[k] = v
+		}
+	}
+	return &c
+}
+
+// copyRetryPolicy returns a copy of p, for the same reason as copyHTTPRequest.
+func copyRetryPolicy(p *data.RetryPolicy) *data.RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// PatchRequest is the request that can be passed to update some of a schedule's fields. The fields are kept as raw
+// JSON, so that a field which is missing from the request can be told apart from one which is null.
+type PatchRequest struct {
+	ARN           json.RawMessage `json:"arn"`
+	Payload       json.RawMessage `json:"payload"`
+	HTTPRequest   json.RawMessage `json:"httpRequest"`
+	RetryPolicy   json.RawMessage `json:"retryPolicy"`
+	Crontabs      json.RawMessage `json:"crontabs"`
+	Timezone      json.RawMessage `json:"timezone"`
+	Until         json.RawMessage `json:"until"`
+	MaxRuns       json.RawMessage `json:"maxRuns"`
+	MisfirePolicy json.RawMessage `json:"misfirePolicy"`
+	ExternalID    json.RawMessage `json:"externalId"`
+	By            json.RawMessage `json:"by"`
+}
+
+// httpRequestPatch is the httpRequest field of a PatchRequest.
+type httpRequestPatch struct {
+	Method      json.RawMessage `json:"method"`
+	Headers     json.RawMessage `json:"headers"`
+	ContentType json.RawMessage `json:"contentType"`
+	SigningKey  json.RawMessage `json:"signingKey"`
+}
+
+// Apply changes the fields of pr which are present in the patch, a null field is cleared. The fields of httpRequest
+// and retryPolicy are changed in the same way, except for headers, which like crontabs are replaced.
+func (p PatchRequest) Apply(pr *PutRequest) error {
+	fields := []struct {
+		value json.RawMessage
+		field interface{}
+	}{
+		{p.ARN, &pr.ARN},
+		{p.Payload, &pr.Payload},
+		{p.Crontabs, &pr.Crontabs},
+		{p.Timezone, &pr.Timezone},
+		{p.Until, &pr.Until},
+		{p.MaxRuns, &pr.MaxRuns},
+		{p.MisfirePolicy, &pr.MisfirePolicy},
+		{p.ExternalID, &pr.ExternalID},
+		{p.By, &pr.By},
+	}
+	for _, f := range fields {
+		if err := replaceField(f.value, f.field); err != nil {
+			return err
+		}
+	}
+	if err := p.applyHTTPRequest(pr); err != nil {
+		return err
+	}
+	// Unmarshalling into the existing retryPolicy only changes the fields which are present, and null clears it.
+	if p.RetryPolicy != nil {
+		return json.Unmarshal(p.RetryPolicy, &pr.RetryPolicy)
+	}
+	return nil
+}
+
+func (p PatchRequest) applyHTTPRequest(pr *PutRequest) error {
+	if p.HTTPRequest == nil {
+		return nil
+	}
+	if isNull(p.HTTPRequest) {
+		pr.HTTPRequest = nil
+		return nil
+	}
+	var hp httpRequestPatch
+	if err := json.Unmarshal(p.HTTPRequest, &hp); err != nil {
+		return err
+	}
+	if pr.HTTPRequest == nil {
+		pr.HTTPRequest = &data.HTTPRequest{}
+	}
+	fields := []struct {
+		value json.RawMessage
+		field interface{}
+	}{
+		{hp.Method, &pr.HTTPRequest.Method},
+		{hp.Headers, &pr.HTTPRequest.Headers},
+		{hp.ContentType, &pr.HTTPRequest.ContentType},
+		{hp.SigningKey, &pr.HTTPRequest.SigningKey},
+	}
+	for _, f := range fields {
+		if err := replaceField(f.value, f.field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceField replaces the value of field with the JSON value, if it was present in the request. The field is
+// cleared first, so that a null value clears it, and a map isn't merged with the new value.
+func replaceField(value json.RawMessage, field interface{}) error {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(field).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(value, field)
+}
+
+func isNull(value json.RawMessage) bool {
+	return string(bytes.TrimSpace(value)) == "null"
+}
+
+// Validate that the PutRequest is valid, using validateARN and validateRequest in the same way as a PostRequest. The
+// same rules apply as when the schedule is created.
+func (pr PutRequest) Validate(validateARN executor.Validator, validateRequest web.RequestValidator) error {
+	return PostRequest{
+		ARN:           pr.ARN,
+		Payload:       pr.Payload,
+		HTTPRequest:   pr.HTTPRequest,
+		RetryPolicy:   pr.RetryPolicy,
+		Crontabs:      pr.Crontabs,
+		Timezone:      pr.Timezone,
+		Until:         pr.Until,
+		MaxRuns:       pr.MaxRuns,
+		MisfirePolicy: pr.MisfirePolicy,
+		ExternalID:    pr.ExternalID,
+		By:            pr.By,
+	}.Validate(validateARN, validateRequest)
+}
+
+// PauseRequest is the optional body of a request to pause a schedule.
+type PauseRequest struct {
+	By string `json:"by"`
+}
+
+// ResumeRequest is the optional body of a request to resume a schedule.
+type ResumeRequest struct {
+	By string `json:"by"`
+	// CatchUp starts a single job when the schedule is resumed if any runs were missed while it was paused.
+	CatchUp bool `json:"catchUp"`
+}
+
 // New creates a new handler.
-func New(creator data.ScheduleCreator, getter data.ScheduleByIDGetter, deactivator data.ScheduleDeactivator) *Handler {
+func New(creator data.ScheduleCreator, idempotentCreator data.IdempotentScheduleCreator, updater data.ScheduleUpdater, getter data.ScheduleByIDGetter, lister data.SchedulesLister,
+	deactivator data.ScheduleDeactivator, pauser data.SchedulePauser, resumer data.ScheduleResumer, arnValidator executor.Validator, requestValidator web.RequestValidator) *Handler {
 	return &Handler{
-		ScheduleCreator:     creator,
-		ScheduleByIDGetter:  getter,
-		ScheduleDeactivator: deactivator,
+		ScheduleCreator:           creator,
+		IdempotentScheduleCreator: idempotentCreator,
+		ScheduleUpdater:           updater,
+		ScheduleByIDGetter:        getter,
+		SchedulesLister:           lister,
+		ScheduleDeactivator:       deactivator,
+		SchedulePauser:            pauser,
+		ScheduleResumer:           resumer,
+		ARNValidator:              arnValidator,
+		RequestValidator:          requestValidator,
 	}
 }
 
@@ -103,21 +362,151 @@ func (h *Handler) Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Validate the schedule.
-	if err = s.Validate(); err != nil {
+	if err = s.Validate(h.ARNValidator, h.RequestValidator); err != nil {
 		logger.For(pkg, "Post").WithError(err).Error("failed to validate schedule request")
 		response.Error(err, w, http.StatusUnprocessableEntity)
 		return
 	}
+	key, err := idempotency.Key(r, body)
+	if err != nil {
+		logger.For(pkg, "Post").WithError(err).Error("failed to read idempotency key")
+		response.Error(err, w, http.StatusBadRequest)
+		return
+	}
+	if key != "" {
+		h.postIdempotently(key, s, w)
+		return
+	}
 	// Create it.
-	s.ScheduleID, err = h.ScheduleCreator(s.From, s.ARN, s.Payload, s.Crontabs, s.ExternalID, s.By)
+	s.ScheduleID, err = h.ScheduleCreator(s.From, s.ARN, s.Payload, s.HTTPRequest, s.RetryPolicy, s.Crontabs, s.Timezone, s.Until, s.MaxRuns, s.MisfirePolicy, s.ExternalID, s.By)
+	if err != nil {
+		logger.For(pkg, "Post").WithError(err).Error("failed to create schedule")
+		response.ErrorString("failed to create schedule", w, http.StatusInternalServerError)
+		return
+	}
+	response.JSON(s, w, http.StatusCreated)
+}
+
+// postIdempotently creates the schedule unless a schedule was already created with the idempotency key, in which
+// case the ID of that schedule is returned with a 200 status instead of 201. Keys are scoped to the by field.
+func (h *Handler) postIdempotently(key string, s PostRequest, w http.ResponseWriter) {
+	requestHash, err := idempotency.Hash(s)
+	if err != nil {
+		logger.For(pkg, "Post").WithError(err).Error("failed to hash request")
+		response.ErrorString("failed to create schedule", w, http.StatusInternalServerError)
+		return
+	}
+	ik := data.IdempotencyKey{Key: key, Caller: s.By, RequestHash: requestHash}
+	var existing bool
+	s.ScheduleID, existing, err = h.IdempotentScheduleCreator(ik, s.From, s.ARN, s.Payload, s.HTTPRequest, s.RetryPolicy, s.Crontabs, s.Timezone, s.Until, s.MaxRuns, s.MisfirePolicy, s.ExternalID, s.By)
+	if err == data.ErrIdempotencyKeyConflict {
+		logger.For(pkg, "Post").WithField("idempotencyKey", key).Warn("idempotency key was used for a different request")
+		response.Error(err, w, http.StatusConflict)
+		return
+	}
 	if err != nil {
 		logger.For(pkg, "Post").WithError(err).Error("failed to create schedule")
 		response.ErrorString("failed to create schedule", w, http.StatusInternalServerError)
 		return
 	}
+	if existing {
+		logger.For(pkg, "Post").WithField("scheduleID", s.ScheduleID).WithField("idempotencyKey", key).Info("schedule was already created with the idempotency key")
+		response.JSON(s, w, http.StatusOK)
+		return
+	}
 	response.JSON(s, w, http.StatusCreated)
 }
 
+// Put replaces the fields and crontabs of an existing schedule.
+func (h *Handler) Put(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Put").WithField("url", r.URL).Info("start")
+	scheduleID, ok := parseScheduleID("Put", w, r)
+	if !ok {
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.For(pkg, "Put").WithError(err).Error("failed to read body")
+		response.ErrorString("failed to read body", w, http.StatusBadRequest)
+		return
+	}
+	var pr PutRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		logger.For(pkg, "Put").WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return
+	}
+	pr.ScheduleID = scheduleID
+	h.update("Put", pr, w, r)
+}
+
+// Patch updates the fields of an existing schedule which are present in the request, and clears those which are
+// null. If crontabs or headers are present, they replace all of the schedule's crontabs or headers.
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Patch").WithField("url", r.URL).Info("start")
+	scheduleID, ok := parseScheduleID("Patch", w, r)
+	if !ok {
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.For(pkg, "Patch").WithError(err).Error("failed to read body")
+		response.ErrorString("failed to read body", w, http.StatusBadRequest)
+		return
+	}
+	sc, ok, err := h.ScheduleByIDGetter(scheduleID)
+	if err != nil {
+		logger.For(pkg, "Patch").WithError(err).WithField("scheduleID", scheduleID).Error("failed to retrieve schedule")
+		response.ErrorString("failed to retrieve schedule", w, http.StatusInternalServerError)
+		return
+	}
+	if !ok || !sc.Schedule.Active {
+		logger.For(pkg, "Patch").WithField("scheduleID", scheduleID).Warn("schedule not found")
+		http.NotFound(w, r)
+		return
+	}
+	var patch PatchRequest
+	if err := json.Unmarshal(body, &patch); err != nil {
+		logger.For(pkg, "Patch").WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return
+	}
+	// Fields which aren't in the request keep their current values.
+	pr := newPutRequest(sc)
+	if err := patch.Apply(&pr); err != nil {
+		logger.For(pkg, "Patch").WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return
+	}
+	pr.ScheduleID = scheduleID
+	h.update("Patch", pr, w, r)
+}
+
+func (h *Handler) update(fn string, pr PutRequest, w http.ResponseWriter, r *http.Request) {
+	if err := pr.Validate(h.ARNValidator, h.RequestValidator); err != nil {
+		logger.For(pkg, fn).WithError(err).Error("failed to validate schedule request")
+		response.Error(err, w, http.StatusUnprocessableEntity)
+		return
+	}
+	ok, err := h.ScheduleUpdater(pr.ScheduleID, pr.ARN, pr.Payload, pr.HTTPRequest, pr.RetryPolicy, pr.Crontabs, pr.Timezone, pr.Until, pr.MaxRuns, pr.MisfirePolicy, pr.ExternalID, pr.By)
+	if err == data.ErrCrontabLeased {
+		logger.For(pkg, fn).WithField("scheduleID", pr.ScheduleID).Warn("could not update schedule, a crontab is leased by a worker")
+		response.ErrorString("the schedule is being processed by a worker, try again later", w, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		logger.For(pkg, fn).WithError(err).WithField("scheduleID", pr.ScheduleID).Error("failed to update schedule")
+		response.ErrorString("failed to update schedule", w, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		logger.For(pkg, fn).WithField("scheduleID", pr.ScheduleID).Warn("could not update schedule, it could not be found or isn't active")
+		http.NotFound(w, r)
+		return
+	}
+	response.JSON(pr, w, http.StatusOK)
+}
+
 // Get responds to the GET /schedule/{id} route.
 func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	logger.For(pkg, "Get").WithField("url", r.URL).Info("start")
@@ -148,6 +537,77 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 	response.JSON(sc, w, http.StatusOK)
 }
 
+// ListResponse is the response to the List operation.
+type ListResponse struct {
+	Schedules []data.ScheduleSummary `json:"schedules"`
+	// Next is the value of the after parameter used to get the next page, it's null when there are no more results.
+	Next *int64 `json:"next"`
+}
+
+// List lists schedules, using the externalId, by, active, arn, createdFrom, createdTo, after and limit query
+// parameters. The createdFrom and createdTo parameters are RFC 3339 times, and match schedules which were created at
+// or after createdFrom, and before createdTo.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "List").WithField("url", r.URL).Info("start")
+	filter, after, limit, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		logger.For(pkg, "List").WithError(err).Error("failed to parse query")
+		response.Error(err, w, http.StatusBadRequest)
+		return
+	}
+	scs, err := h.SchedulesLister(filter, after, limit)
+	if err != nil {
+		logger.For(pkg, "List").WithError(err).Error("failed to retrieve schedules")
+		response.ErrorString("failed to retrieve schedules", w, http.StatusInternalServerError)
+		return
+	}
+	lr := ListResponse{
+		Schedules: scs,
+	}
+	if len(scs) == limit {
+		next := scs[len(scs)-1].Schedule.ScheduleID
+		lr.Next = &next
+	}
+	response.JSON(lr, w, http.StatusOK)
+}
+
+func parseListQuery(q url.Values) (filter data.ScheduleFilter, after int64, limit int, err error) {
+	filter.ExternalID = q.Get("externalId")
+	filter.By = q.Get("by")
+	if s := q.Get("active"); s != "" {
+		active, err := strconv.ParseBool(s)
+		if err != nil {
+			return filter, after, limit, errors.New("failed to parse active")
+		}
+		filter.Active = &active
+	}
+	filter.ARN = q.Get("arn")
+	if s := q.Get("createdFrom"); s != "" {
+		if filter.CreatedFrom, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, after, limit, errors.New("failed to parse createdFrom")
+		}
+	}
+	if s := q.Get("createdTo"); s != "" {
+		if filter.CreatedTo, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, after, limit, errors.New("failed to parse createdTo")
+		}
+	}
+	if s := q.Get("after"); s != "" {
+		if after, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return filter, after, limit, errors.New("failed to parse after")
+		}
+	}
+	limit = DefaultLimit
+	if s := q.Get("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l < 1 || l > MaxLimit {
+			return filter, after, limit, errors.New("limit must be between 1 and 1000")
+		}
+		limit = l
+	}
+	return filter, after, limit, nil
+}
+
 // Deactivate deactivates a schedule by its id.
 func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
 	logger.For(pkg, "Deactivate").WithField("url", r.URL).Info("start")
@@ -177,3 +637,97 @@ func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
 	}
 	response.OK(true, w, http.StatusOK)
 }
+
+// Pause pauses a schedule by its id.
+func (h *Handler) Pause(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Pause").WithField("url", r.URL).Info("start")
+	scheduleID, ok := parseScheduleID("Pause", w, r)
+	if !ok {
+		return
+	}
+	var pr PauseRequest
+	if !readOptionalBody("Pause", &pr, w, r) {
+		return
+	}
+	if len(pr.By) > 256 {
+		response.ErrorString("maximum length of the By field is 256 characters", w, http.StatusUnprocessableEntity)
+		return
+	}
+	ok, err := h.SchedulePauser(scheduleID, pr.By)
+	if err != nil {
+		logger.For(pkg, "Pause").WithError(err).WithField("scheduleID", scheduleID).Error("failed to pause schedule")
+		response.ErrorString("failed to pause schedule", w, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		logger.For(pkg, "Pause").WithField("scheduleID", scheduleID).Warn("could not pause schedule, it could not be found, isn't active or is already paused")
+		response.OK(false, w, http.StatusNotModified)
+		return
+	}
+	response.OK(true, w, http.StatusOK)
+}
+
+// Resume resumes a paused schedule by its id.
+func (h *Handler) Resume(w http.ResponseWriter, r *http.Request) {
+	logger.For(pkg, "Resume").WithField("url", r.URL).Info("start")
+	scheduleID, ok := parseScheduleID("Resume", w, r)
+	if !ok {
+		return
+	}
+	var rr ResumeRequest
+	if !readOptionalBody("Resume", &rr, w, r) {
+		return
+	}
+	if len(rr.By) > 256 {
+		response.ErrorString("maximum length of the By field is 256 characters", w, http.StatusUnprocessableEntity)
+		return
+	}
+	ok, err := h.ScheduleResumer(scheduleID, rr.By, rr.CatchUp)
+	if err != nil {
+		logger.For(pkg, "Resume").WithError(err).WithField("scheduleID", scheduleID).Error("failed to resume schedule")
+		response.ErrorString("failed to resume schedule", w, http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		logger.For(pkg, "Resume").WithField("scheduleID", scheduleID).Warn("could not resume schedule, it could not be found or isn't paused")
+		response.OK(false, w, http.StatusNotModified)
+		return
+	}
+	response.OK(true, w, http.StatusOK)
+}
+
+func parseScheduleID(fn string, w http.ResponseWriter, r *http.Request) (scheduleID int64, ok bool) {
+	vars := mux.Vars(r)
+	id, hasID := vars["id"]
+	if !hasID {
+		logger.For(pkg, fn).WithField("url", r.URL).Warn("id not found")
+		http.NotFound(w, r)
+		return
+	}
+	scheduleID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		logger.For(pkg, fn).WithError(err).WithField("scheduleID", id).Error("failed to parse scheduleID")
+		response.ErrorString("failed to parse scheduleID", w, http.StatusBadRequest)
+		return
+	}
+	return scheduleID, true
+}
+
+// readOptionalBody reads the JSON body of the request into v, if the request has a body.
+func readOptionalBody(fn string, v interface{}, w http.ResponseWriter, r *http.Request) (ok bool) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.For(pkg, fn).WithError(err).Error("failed to read body")
+		response.ErrorString("failed to read body", w, http.StatusBadRequest)
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		logger.For(pkg, fn).WithError(err).Error("failed to parse request")
+		response.ErrorString("failed to parse request", w, http.StatusUnprocessableEntity)
+		return false
+	}
+	return true
+}
@@ -5,12 +5,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/executor"
+	"github.com/welldigital/callme/web"
 )
 
 const notFoundMessage = `404 page not found` + "\n"
@@ -43,7 +46,7 @@ func TestGetByID(t *testing.T) {
 			},
 			r:              httptest.NewRequest("GET", "/schedule/1", nil),
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"schedule":{"scheduleId":1,"externalId":"testexternalid","by":"testby","arn":"testarn","payload":"testpayload","created":"2010-01-01T01:00:00Z","active":true,"deactivatedDate":"2000-01-01T01:00:00Z"},"crontabs":null}`,
+			expectedBody:   `{"schedule":{"scheduleId":1,"externalId":"testexternalid","by":"testby","arn":"testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"runs":0,"misfirePolicy":"","created":"2010-01-01T01:00:00Z","active":true,"deactivatedDate":"2000-01-01T01:00:00Z","paused":false},"crontabs":null,"pauses":null}`,
 		},
 		{
 			name:           "missing id",
@@ -82,7 +85,7 @@ func TestGetByID(t *testing.T) {
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		sh := New(nil, test.g, nil)
+		sh := New(nil, nil, nil, test.g, nil, nil, nil, nil, nil, nil)
 		router.Path("/schedule/{id}").Methods(http.MethodGet).HandlerFunc(sh.Get)
 
 		w := httptest.NewRecorder()
@@ -101,6 +104,160 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestList(t *testing.T) {
+	summary := data.ScheduleSummary{
+		Schedule: data.Schedule{
+			ScheduleID: 3,
+			ExternalID: "testexternalid",
+			By:         "testby",
+			ARN:        "testarn",
+			Payload:    "testpayload",
+			Created:    time.Date(2010, time.January, 1, 1, 0, 0, 0, time.UTC),
+			Active:     true,
+		},
+		Crontabs: []data.Crontab{
+			{
+				CrontabID:   4,
+				ScheduleID:  3,
+				Crontab:     "* * * * *",
+				Previous:    time.Date(2010, time.January, 1, 1, 0, 0, 0, time.UTC),
+				Next:        time.Date(2010, time.January, 1, 1, 1, 0, 0, time.UTC),
+				LastUpdated: time.Date(2010, time.January, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	const summaryJSON = `{"schedule":{"scheduleId":3,"externalId":"testexternalid","by":"testby","arn":"testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"runs":0,"misfirePolicy":"","created":"2010-01-01T01:00:00Z","active":true,"deactivatedDate":"0001-01-01T00:00:00Z","paused":false},` +
+		`"crontabs":[{"crontabId":4,"scheduleId":3,"crontab":"* * * * *","previous":"2010-01-01T01:00:00Z","next":"2010-01-01T01:01:00Z","lastUpdated":"2010-01-01T01:00:00Z"}]}`
+	active := false
+
+	tests := []struct {
+		name           string
+		l              data.SchedulesLister
+		r              *http.Request
+		expectedFilter data.ScheduleFilter
+		expectedAfter  int64
+		expectedLimit  int
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "defaults",
+			l: func(filter data.ScheduleFilter, afterID int64, limit int) ([]data.ScheduleSummary, error) {
+				return []data.ScheduleSummary{summary}, nil
+			},
+			r:              httptest.NewRequest("GET", "/schedule", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"schedules":[` + summaryJSON + `],"next":null}`,
+		},
+		{
+			name: "filters and full page returns next",
+			l: func(filter data.ScheduleFilter, afterID int64, limit int) ([]data.ScheduleSummary, error) {
+				return []data.ScheduleSummary{summary}, nil
+			},
+			r: httptest.NewRequest("GET", "/schedule?externalId=testexternalid&by=testby&active=false&arn=testarn"+
+				"&createdFrom=2010-01-01T00:00:00Z&createdTo=2010-01-02T00:00:00Z&after=2&limit=1", nil),
+			expectedFilter: data.ScheduleFilter{
+				ExternalID:  "testexternalid",
+				By:          "testby",
+				Active:      &active,
+				ARN:         "testarn",
+				CreatedFrom: time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC),
+				CreatedTo:   time.Date(2010, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			expectedAfter:  2,
+			expectedLimit:  1,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"schedules":[` + summaryJSON + `],"next":3}`,
+		},
+		{
+			name: "no schedules",
+			l: func(filter data.ScheduleFilter, afterID int64, limit int) ([]data.ScheduleSummary, error) {
+				return []data.ScheduleSummary{}, nil
+			},
+			r:              httptest.NewRequest("GET", "/schedule?externalId=missing", nil),
+			expectedFilter: data.ScheduleFilter{ExternalID: "missing"},
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"schedules":[],"next":null}`,
+		},
+		{
+			name:           "invalid active",
+			r:              httptest.NewRequest("GET", "/schedule?active=sometimes", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse active"}`,
+		},
+		{
+			name:           "invalid createdFrom",
+			r:              httptest.NewRequest("GET", "/schedule?createdFrom=yesterday", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse createdFrom"}`,
+		},
+		{
+			name:           "invalid createdTo",
+			r:              httptest.NewRequest("GET", "/schedule?createdTo=2010-01-01", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse createdTo"}`,
+		},
+		{
+			name:           "invalid after",
+			r:              httptest.NewRequest("GET", "/schedule?after=first", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse after"}`,
+		},
+		{
+			name:           "limit too small",
+			r:              httptest.NewRequest("GET", "/schedule?limit=0", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"limit must be between 1 and 1000"}`,
+		},
+		{
+			name: "failed to list schedules",
+			l: func(filter data.ScheduleFilter, afterID int64, limit int) ([]data.ScheduleSummary, error) {
+				return nil, errors.New("database error")
+			},
+			r:              httptest.NewRequest("GET", "/schedule", nil),
+			expectedLimit:  DefaultLimit,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve schedules"}`,
+		},
+	}
+
+	for _, test := range tests {
+		var actualFilter data.ScheduleFilter
+		var actualAfter int64
+		var actualLimit int
+		var l data.SchedulesLister
+		if test.l != nil {
+			l = func(filter data.ScheduleFilter, afterID int64, limit int) ([]data.ScheduleSummary, error) {
+				actualFilter, actualAfter, actualLimit = filter, afterID, limit
+				return test.l(filter, afterID, limit)
+			}
+		}
+		router := mux.NewRouter()
+		sh := New(nil, nil, nil, nil, l, nil, nil, nil, nil, nil)
+		router.Path("/schedule").Methods(http.MethodGet).HandlerFunc(sh.List)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+		if !reflect.DeepEqual(actualFilter, test.expectedFilter) || actualAfter != test.expectedAfter || actualLimit != test.expectedLimit {
+			t.Errorf("%s: expected filter=%+v, after=%v, limit=%v, got filter=%+v, after=%v, limit=%v", test.name,
+				test.expectedFilter, test.expectedAfter, test.expectedLimit, actualFilter, actualAfter, actualLimit)
+		}
+	}
+}
+
 func TestDeactivate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -148,7 +305,7 @@ func TestDeactivate(t *testing.T) {
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		sh := New(nil, nil, test.d)
+		sh := New(nil, nil, nil, nil, nil, test.d, nil, nil, nil, nil)
 		router.Path("/schedule/{id}/deactivate").Methods(http.MethodPost).HandlerFunc(sh.Deactivate)
 
 		w := httptest.NewRecorder()
@@ -167,6 +324,450 @@ func TestDeactivate(t *testing.T) {
 	}
 }
 
+func TestPause(t *testing.T) {
+	tests := []struct {
+		name           string
+		p              data.SchedulePauser
+		r              *http.Request
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			p: func(scheduleID int64, by string) (ok bool, err error) {
+				return scheduleID == 1 && by == "testby", nil
+			},
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", strings.NewReader(`{"by":"testby"}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:           "empty body",
+			p:              func(scheduleID int64, by string) (ok bool, err error) { return true, nil },
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", nil),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:           "invalid schedule id",
+			p:              nil,
+			r:              httptest.NewRequest("POST", "/schedule/_/pause", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse scheduleID"}`,
+		},
+		{
+			name:           "invalid JSON body",
+			p:              nil,
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", strings.NewReader("_not_json_")),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"failed to parse request"}`,
+		},
+		{
+			name:           "By is too big",
+			p:              nil,
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", strings.NewReader(`{"by":"`+longString(257)+`"}`)),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"maximum length of the By field is 256 characters"}`,
+		},
+		{
+			name: "failure to access database",
+			p: func(scheduleID int64, by string) (ok bool, err error) {
+				return false, errors.New("failed to access database")
+			},
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to pause schedule"}`,
+		},
+		{
+			name:           "schedule not paused",
+			p:              func(scheduleID int64, by string) (ok bool, err error) { return false, nil },
+			r:              httptest.NewRequest("POST", "/schedule/1/pause", nil),
+			expectedStatus: http.StatusNotModified,
+			expectedBody:   `{"ok":false}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		sh := New(nil, nil, nil, nil, nil, nil, test.p, nil, nil, nil)
+		router.Path("/schedule/{id}/pause").Methods(http.MethodPost).HandlerFunc(sh.Pause)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	tests := []struct {
+		name           string
+		rs             data.ScheduleResumer
+		r              *http.Request
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			rs: func(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+				return scheduleID == 1 && by == "testby" && !catchUp, nil
+			},
+			r:              httptest.NewRequest("POST", "/schedule/1/resume", strings.NewReader(`{"by":"testby"}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name: "catch up",
+			rs: func(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+				return catchUp, nil
+			},
+			r:              httptest.NewRequest("POST", "/schedule/1/resume", strings.NewReader(`{"catchUp":true}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name:           "invalid schedule id",
+			rs:             nil,
+			r:              httptest.NewRequest("POST", "/schedule/_/resume", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse scheduleID"}`,
+		},
+		{
+			name: "failure to access database",
+			rs: func(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+				return false, errors.New("failed to access database")
+			},
+			r:              httptest.NewRequest("POST", "/schedule/1/resume", nil),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to resume schedule"}`,
+		},
+		{
+			name:           "schedule not paused",
+			rs:             func(scheduleID int64, by string, catchUp bool) (ok bool, err error) { return false, nil },
+			r:              httptest.NewRequest("POST", "/schedule/1/resume", nil),
+			expectedStatus: http.StatusNotModified,
+			expectedBody:   `{"ok":false}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		sh := New(nil, nil, nil, nil, nil, nil, nil, test.rs, nil, nil)
+		router.Path("/schedule/{id}/resume").Methods(http.MethodPost).HandlerFunc(sh.Resume)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
+func TestPut(t *testing.T) {
+	tests := []struct {
+		name           string
+		u              data.ScheduleUpdater
+		r              *http.Request
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				if scheduleID != 1 || arn != "arn:aws:sns:eu-west-2:123456789012:newarn" || len(crontabs) != 1 || crontabs[0] != "0 9 * * *" {
+					return false, errors.New("unexpected update")
+				}
+				return true, nil
+			},
+			r: httptest.NewRequest("PUT", "/schedule/1",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","payload":"newpayload","crontabs":["0 9 * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","payload":"newpayload","httpRequest":null,"retryPolicy":null,"crontabs":["0 9 * * *"],"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name:           "invalid schedule id",
+			u:              nil,
+			r:              httptest.NewRequest("PUT", "/schedule/_", nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"failed to parse scheduleID"}`,
+		},
+		{
+			name:           "invalid JSON body",
+			u:              nil,
+			r:              httptest.NewRequest("PUT", "/schedule/1", strings.NewReader("_not_json_")),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"failed to parse request"}`,
+		},
+		{
+			name:           "invalid schedule",
+			u:              nil,
+			r:              httptest.NewRequest("PUT", "/schedule/1", strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","crontabs":[]}`)),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"at least one crontab must be provided"}`,
+		},
+		{
+			name: "crontab is leased",
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return false, data.ErrCrontabLeased
+			},
+			r:              httptest.NewRequest("PUT", "/schedule/1", strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","crontabs":["0 9 * * *"]}`)),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"err":"the schedule is being processed by a worker, try again later"}`,
+		},
+		{
+			name: "failure to access database",
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return false, errors.New("failed to access database")
+			},
+			r:              httptest.NewRequest("PUT", "/schedule/1", strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","crontabs":["0 9 * * *"]}`)),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to update schedule"}`,
+		},
+		{
+			name: "schedule not found",
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return false, nil
+			},
+			r:              httptest.NewRequest("PUT", "/schedule/1", strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:newarn","crontabs":["0 9 * * *"]}`)),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		sh := New(nil, nil, test.u, nil, nil, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest)
+		router.Path("/schedule/{id}").Methods(http.MethodPut).HandlerFunc(sh.Put)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
+func TestPatch(t *testing.T) {
+	existing := data.ScheduleCrontabs{
+		Schedule: data.Schedule{
+			ScheduleID: 1,
+			ExternalID: "testexternalid",
+			By:         "testby",
+			ARN:        "arn:aws:sns:eu-west-2:123456789012:testarn",
+			Payload:    "testpayload",
+			Timezone:   "Europe/London",
+			MaxRuns:    5,
+			Active:     true,
+		},
+		Crontabs: []data.Crontab{
+			{CrontabID: 1, ScheduleID: 1, Crontab: "0 9 * * *"},
+			{CrontabID: 2, ScheduleID: 1, Crontab: "0 17 * * *"},
+		},
+	}
+	getter := func(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+		return existing, scheduleID == 1, nil
+	}
+	deactivated := existing
+	deactivated.Schedule.Active = false
+	withHTTPRequest := existing
+	withHTTPRequest.Schedule.ARN = "https://example.com"
+	withHTTPRequest.Schedule.HTTPRequest = &data.HTTPRequest{Method: "PUT", Headers: map[string]string{"X-A": "1", "X-B": "2"}}
+	withHTTPRequest.Schedule.Until = time.Now().UTC().Add(time.Hour)
+	withHTTPRequestGetter := func(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+		return withHTTPRequest, true, nil
+	}
+
+	tests := []struct {
+		name           string
+		g              data.ScheduleByIDGetter
+		u              data.ScheduleUpdater
+		r              *http.Request
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "only the fields in the request are changed",
+			g:    getter,
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				if payload != "newpayload" || arn != existing.Schedule.ARN || timezone != "Europe/London" || maxRuns != 5 || len(crontabs) != 2 {
+					return false, errors.New("unexpected update")
+				}
+				return true, nil
+			},
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"payload":"newpayload"}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"newpayload","httpRequest":null,"retryPolicy":null,"crontabs":["0 9 * * *","0 17 * * *"],"timezone":"Europe/London","until":"0001-01-01T00:00:00Z","maxRuns":5,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "crontabs are replaced",
+			g:    getter,
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return len(crontabs) == 1 && crontabs[0] == "30 12 * * *", nil
+			},
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"crontabs":["30 12 * * *"]}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["30 12 * * *"],"timezone":"Europe/London","until":"0001-01-01T00:00:00Z","maxRuns":5,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "headers are replaced and null fields are cleared",
+			g:    withHTTPRequestGetter,
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				if httpRequest == nil || httpRequest.Method != "PUT" || len(httpRequest.Headers) != 1 || httpRequest.Headers["X-A"] != "1" || !until.IsZero() || maxRuns != 0 {
+					return false, errors.New("unexpected update")
+				}
+				return true, nil
+			},
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"httpRequest":{"headers":{"X-A":"1"}},"until":null,"maxRuns":null}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"https://example.com","payload":"testpayload","httpRequest":{"method":"PUT","headers":{"X-A":"1"},"contentType":"","signingKey":""},"retryPolicy":null,"crontabs":["0 9 * * *","0 17 * * *"],"timezone":"Europe/London","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "a null httpRequest is cleared",
+			g:    withHTTPRequestGetter,
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return httpRequest == nil, nil
+			},
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"httpRequest":null}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"https://example.com","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["0 9 * * *","0 17 * * *"],"timezone":"Europe/London","until":"` + withHTTPRequest.Schedule.Until.Format(time.RFC3339Nano) + `","maxRuns":5,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "null headers are cleared",
+			g:    withHTTPRequestGetter,
+			u: func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+				return httpRequest != nil && httpRequest.Method == "PUT" && httpRequest.Headers == nil, nil
+			},
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"httpRequest":{"headers":null}}`)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"scheduleId":1,"arn":"https://example.com","payload":"testpayload","httpRequest":{"method":"PUT","headers":null,"contentType":"","signingKey":""},"retryPolicy":null,"crontabs":["0 9 * * *","0 17 * * *"],"timezone":"Europe/London","until":"` + withHTTPRequest.Schedule.Until.Format(time.RFC3339Nano) + `","maxRuns":5,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name:           "the result is validated",
+			g:              getter,
+			u:              nil,
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"timezone":"Europe/Nowhere"}`)),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"unknown timezone 'Europe/Nowhere'"}`,
+		},
+		{
+			name:           "schedule not found",
+			g:              getter,
+			u:              nil,
+			r:              httptest.NewRequest("PATCH", "/schedule/2", strings.NewReader(`{"payload":"newpayload"}`)),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			name: "schedule not active",
+			g: func(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+				return deactivated, true, nil
+			},
+			u:              nil,
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"payload":"newpayload"}`)),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			name: "failure to retrieve schedule",
+			g: func(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+				return sc, false, errors.New("failed to access database")
+			},
+			u:              nil,
+			r:              httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"payload":"newpayload"}`)),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to retrieve schedule"}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		sh := New(nil, nil, test.u, test.g, nil, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest)
+		router.Path("/schedule/{id}").Methods(http.MethodPatch).HandlerFunc(sh.Patch)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, test.r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
+func TestPatchDoesNotModifyTheExistingSchedule(t *testing.T) {
+	existing := data.ScheduleCrontabs{
+		Schedule: data.Schedule{
+			ScheduleID:  1,
+			ARN:         "https://example.com",
+			Payload:     "testpayload",
+			HTTPRequest: &data.HTTPRequest{Method: "PUT", Headers: map[string]string{"X-Original": "1"}},
+			RetryPolicy: &data.RetryPolicy{MaxAttempts: 3},
+			Active:      true,
+		},
+		Crontabs: []data.Crontab{{CrontabID: 1, ScheduleID: 1, Crontab: "0 9 * * *"}},
+	}
+	getter := func(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+		return existing, true, nil
+	}
+	var updated *data.HTTPRequest
+	updater := func(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error) {
+		updated = httpRequest
+		return true, nil
+	}
+
+	router := mux.NewRouter()
+	sh := New(nil, nil, updater, getter, nil, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest)
+	router.Path("/schedule/{id}").Methods(http.MethodPatch).HandlerFunc(sh.Patch)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PATCH", "/schedule/1", strings.NewReader(`{"httpRequest":{"method":"POST","headers":{"X-New":"2"}},"retryPolicy":{"maxAttempts":5}}`))
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %v, got %v", http.StatusOK, w.Code)
+	}
+	if updated == nil || updated.Method != "POST" || updated.Headers["X-New"] != "2" {
+		t.Errorf("expected the patched httpRequest to be used, got %+v", updated)
+	}
+	if existing.Schedule.HTTPRequest.Method != "PUT" || len(existing.Schedule.HTTPRequest.Headers) != 1 || existing.Schedule.HTTPRequest.Headers["X-Original"] != "1" {
+		t.Errorf("expected the existing httpRequest to be unmodified, got %+v", existing.Schedule.HTTPRequest)
+	}
+	if existing.Schedule.RetryPolicy.MaxAttempts != 3 {
+		t.Errorf("expected the existing retryPolicy to be unmodified, got %+v", existing.Schedule.RetryPolicy)
+	}
+}
+
 func TestPost(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -178,12 +779,12 @@ func TestPost(t *testing.T) {
 		{
 			name: "successful post",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
-			s: func(from time.Time, arn string, payload string, crontabs []string, externalID, by string) (scheduleID int64, err error) {
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			s: func(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error) {
 				return 1, nil
 			},
 			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"scheduleId":1,"from":"0001-01-01T00:00:00Z","arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`,
+			expectedBody:   `{"scheduleId":1,"from":"0001-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["* * * * *"],"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
 		},
 		{
 			name:           "malformed body",
@@ -202,8 +803,8 @@ func TestPost(t *testing.T) {
 		{
 			name: "failure to create schedule",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
-			s: func(from time.Time, arn string, payload string, crontabs []string, externalID, by string) (scheduleID int64, err error) {
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			s: func(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error) {
 				return 0, errors.New("failed to create schedule")
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -212,7 +813,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "try and update an existing schedule fails",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"scheduleId":1,"arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+				strings.NewReader(`{"scheduleId":1,"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"cannot post to an existing schedule"}`,
@@ -236,7 +837,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "ExternalID is too big",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"`+longString(257)+`","by":"testby"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"`+longString(257)+`","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"maximum length of the ExternalID is 256 characters"}`,
@@ -244,7 +845,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "By is too big",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"`+longString(257)+`"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"`+longString(257)+`"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"maximum length of the By field is 256 characters"}`,
@@ -252,7 +853,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "Payload is too big",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"`+longString(1024*1024*32)+`","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"`+longString(1024*1024*32)+`","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"exceeded maximum length of payload"}`,
@@ -260,7 +861,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "Missing crontabs field",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","externalId":"testexternalid","by":"testby"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","externalId":"testexternalid","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"crontabs field must be present"}`,
@@ -268,7 +869,7 @@ func TestPost(t *testing.T) {
 		{
 			name: "No crontabs in the array",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":[],"externalId":"testexternalid","by":"testby"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":[],"externalId":"testexternalid","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"at least one crontab must be provided"}`,
@@ -276,16 +877,127 @@ func TestPost(t *testing.T) {
 		{
 			name: "Junk crontabs in the array",
 			r: httptest.NewRequest("POST", "/schedule",
-				strings.NewReader(`{"arn":"testarn","payload":"testpayload","crontabs":["* * * * *", "nonsense"],"externalId":"testexternalid","by":"testby"}`)),
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *", "nonsense"],"externalId":"testexternalid","by":"testby"}`)),
 			s:              nil,
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedBody:   `{"err":"failed to parse crontab with error 'Expected 5 or 6 fields, found 1: nonsense'"}`,
 		},
+		{
+			name: "unsupported ARN scheme fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sqs:eu-west-2:123456789012:testqueue","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"unsupported ARN scheme 'sqs'"}`,
+		},
+		{
+			name: "HTTP request with an SNS ARN fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":{"method":"PUT"},"crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"httpRequest can only be used with http and https ARNs"}`,
+		},
+		{
+			name: "invalid retry policy fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","retryPolicy":{"multiplier":0.5},"crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"retryPolicy.multiplier must be 0 (default) or between 1 and 10"}`,
+		},
+		{
+			name: "timezone is passed to the creator",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["0 9 * * MON-FRI"],"timezone":"Europe/London","externalId":"testexternalid","by":"testby"}`)),
+			s: func(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error) {
+				if timezone != "Europe/London" {
+					return 0, errors.New("unexpected timezone")
+				}
+				return 1, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"scheduleId":1,"from":"0001-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["0 9 * * MON-FRI"],"timezone":"Europe/London","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "unknown timezone fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"timezone":"Europe/Nowhere","externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"unknown timezone 'Europe/Nowhere'"}`,
+		},
+		{
+			name: "crontab timezone prefix with a schedule timezone fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["TZ=UTC * * * * *"],"timezone":"Europe/London","externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"failed to parse crontab with error 'a crontab can't include TZ= when the schedule has a timezone'"}`,
+		},
+		{
+			name: "until and maxRuns are passed to the creator",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"from":"2000-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"until":"2100-01-01T00:00:00Z","maxRuns":5,"externalId":"testexternalid","by":"testby"}`)),
+			s: func(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error) {
+				if !until.Equal(time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)) || maxRuns != 5 {
+					return 0, errors.New("unexpected until or maxRuns")
+				}
+				return 1, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"scheduleId":1,"from":"2000-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["* * * * *"],"timezone":"","until":"2100-01-01T00:00:00Z","maxRuns":5,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "until before from fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"from":"2100-01-02T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"until":"2100-01-01T00:00:00Z","externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"until must be after from"}`,
+		},
+		{
+			name: "until in the past fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"until":"2000-01-01T00:00:00Z","externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"until must be in the future"}`,
+		},
+		{
+			name: "negative maxRuns fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"maxRuns":-1,"externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"maxRuns must not be negative"}`,
+		},
+		{
+			name: "misfirePolicy is passed to the creator",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"misfirePolicy":"fireOnce","externalId":"testexternalid","by":"testby"}`)),
+			s: func(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error) {
+				if misfirePolicy != data.MisfirePolicyFireOnce {
+					return 0, errors.New("unexpected misfirePolicy")
+				}
+				return 1, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"scheduleId":1,"from":"0001-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["* * * * *"],"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"fireOnce","externalId":"testexternalid","by":"testby"}`,
+		},
+		{
+			name: "unknown misfirePolicy fails",
+			r: httptest.NewRequest("POST", "/schedule",
+				strings.NewReader(`{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"misfirePolicy":"sometimes","externalId":"testexternalid","by":"testby"}`)),
+			s:              nil,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"err":"misfirePolicy must be one of fireAll, fireOnce or skip"}`,
+		},
 	}
 
 	for _, test := range tests {
 		router := mux.NewRouter()
-		sh := New(test.s, nil, nil)
+		sh := New(test.s, nil, nil, nil, nil, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest)
 		router.Path("/schedule").Methods(http.MethodPost).HandlerFunc(sh.Post)
 
 		w := httptest.NewRecorder()
@@ -304,6 +1016,106 @@ func TestPost(t *testing.T) {
 	}
 }
 
+func TestPostIdempotently(t *testing.T) {
+	body := `{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby"}`
+	expectedBody := `{"scheduleId":1,"from":"0001-01-01T00:00:00Z","arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","httpRequest":null,"retryPolicy":null,"crontabs":["* * * * *"],"timezone":"","until":"0001-01-01T00:00:00Z","maxRuns":0,"misfirePolicy":"","externalId":"testexternalid","by":"testby"}`
+	tests := []struct {
+		name           string
+		key            string
+		body           string
+		s              data.IdempotentScheduleCreator
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "new schedule",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error) {
+				if key.Key != "abc" || key.Caller != "testby" || len(key.RequestHash) != 64 {
+					return 0, false, errors.New("unexpected key")
+				}
+				return 1, false, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   expectedBody,
+		},
+		{
+			name: "key in the body",
+			body: `{"arn":"arn:aws:sns:eu-west-2:123456789012:testarn","payload":"testpayload","crontabs":["* * * * *"],"externalId":"testexternalid","by":"testby","idempotencyKey":"abc"}`,
+			s: func(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error) {
+				if key.Key != "abc" {
+					return 0, false, errors.New("unexpected key")
+				}
+				return 1, false, nil
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   expectedBody,
+		},
+		{
+			name: "existing schedule",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error) {
+				return 1, true, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   expectedBody,
+		},
+		{
+			name: "key used for a different request",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error) {
+				return 0, false, data.ErrIdempotencyKeyConflict
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"err":"the idempotency key was used for a different request"}`,
+		},
+		{
+			name: "failure to create schedule",
+			key:  "abc",
+			body: body,
+			s: func(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error) {
+				return 0, false, errors.New("failed to access database")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"err":"failed to create schedule"}`,
+		},
+		{
+			name:           "key is too long",
+			key:            strings.Repeat("a", 257),
+			body:           body,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"err":"maximum length of the idempotency key is 256 characters"}`,
+		},
+	}
+
+	for _, test := range tests {
+		router := mux.NewRouter()
+		sh := New(nil, test.s, nil, nil, nil, nil, nil, nil, executor.SchemeValidator(executor.DefaultSchemes...), web.ValidateRequest)
+		router.Path("/schedule").Methods(http.MethodPost).HandlerFunc(sh.Post)
+
+		r := httptest.NewRequest("POST", "/schedule", strings.NewReader(test.body))
+		if test.key != "" {
+			r.Header.Set("Idempotency-Key", test.key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if test.expectedStatus != w.Code {
+			t.Errorf("%s: expected status %v, got %v", test.name, test.expectedStatus, w.Code)
+		}
+		actualBody, err := ioutil.ReadAll(w.Body)
+		if err != nil {
+			t.Errorf("%s: unexpected error reading body: '%v'", test.name, err)
+		}
+		if test.expectedBody != string(actualBody) {
+			t.Errorf("%s: expected body '%v', got '%v'", test.name, test.expectedBody, string(actualBody))
+		}
+	}
+}
+
 type ReaderFunc struct {
 	F func(p []byte) (n int, err error)
 }
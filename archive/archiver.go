@@ -0,0 +1,192 @@
+// Package archive exports completed jobs to compressed, newline delimited JSON files before they're purged, so that
+// a record of every job is kept outside of the database. Each archive file has an entry in a manifest, which can be
+// searched to find the archives which contain a job, and the jobs read back out of them.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/metrics"
+)
+
+const pkg = "github.com/welldigital/callme/archive"
+
+const (
+	jobsPrefix     = "jobs/"
+	manifestPrefix = "manifest/"
+)
+
+// A ManifestEntry describes an archive file.
+type ManifestEntry struct {
+	// Name is the name of the archive file in the Store.
+	Name string `json:"name"`
+	// Count is the number of jobs in the file.
+	Count int `json:"count"`
+	// JobIDs are the IDs of the jobs in the file, in the order they were completed.
+	JobIDs []int64 `json:"jobIds"`
+	// FirstCompleted and LastCompleted are the times that the first and last jobs in the file were completed.
+	FirstCompleted time.Time `json:"firstCompleted"`
+	LastCompleted  time.Time `json:"lastCompleted"`
+	// Created is when the file was written.
+	Created time.Time `json:"created"`
+	// SHA256 is the hex encoded checksum of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// Contains returns whether the archive file contains the job.
+func (e ManifestEntry) Contains(jobID int64) bool {
+	for _, id := range e.JobIDs {
+		if id == jobID {
+			return true
+		}
+	}
+	return false
+}
+
+// Archiver exports completed jobs to a Store before deleting them from the database.
+type Archiver struct {
+	Store                Store
+	CompletedJobsGetter  data.CompletedJobsGetter
+	CompletedJobsDeleter data.CompletedJobsDeleter
+	now                  func() time.Time
+}
+
+// NewArchiver creates an Archiver which writes to the store.
+func NewArchiver(store Store, completedJobsGetter data.CompletedJobsGetter, completedJobsDeleter data.CompletedJobsDeleter) Archiver {
+	return Archiver{
+		Store:                store,
+		CompletedJobsGetter:  completedJobsGetter,
+		CompletedJobsDeleter: completedJobsDeleter,
+		now:                  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Purge archives up to limit jobs which were completed before the cutoff, then deletes them. It can be used as the
+// data.JobsPurger of the retention worker. Jobs are only deleted once their archive file and its manifest entry have
+// been written, so a failure leaves them in the database to be archived again.
+func (a Archiver) Purge(completedBefore time.Time, limit int) (purged int, err error) {
+	jrs, err := a.CompletedJobsGetter(completedBefore, limit)
+	if err != nil || len(jrs) == 0 {
+		return
+	}
+	entry, err := a.write(jrs)
+	if err != nil {
+		metrics.ArchiveWrittenCounts.WithLabelValues("error").Inc()
+		return
+	}
+	metrics.ArchiveWrittenCounts.WithLabelValues("success").Inc()
+	metrics.ArchivedJobCounts.Add(float64(entry.Count))
+	logger.For(pkg, "Purge").WithField("name", entry.Name).Infof("archived %v jobs", entry.Count)
+	return a.CompletedJobsDeleter(entry.JobIDs)
+}
+
+// write writes the jobs to an archive file, then adds it to the manifest.
+func (a Archiver) write(jrs []data.JobAndResponse) (entry ManifestEntry, err error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	entry.JobIDs = make([]int64, len(jrs))
+	for i, jr := range jrs {
+		if err = enc.Encode(jr); err != nil {
+			return
+		}
+		entry.JobIDs[i] = jr.Job.JobID
+	}
+	if err = zw.Close(); err != nil {
+		return
+	}
+
+	// Name the file after its first job, so that archiving the same jobs again replaces the file.
+	first := jrs[0]
+	entry.FirstCompleted = first.JobResponse.Time.UTC()
+	entry.LastCompleted = jrs[len(jrs)-1].JobResponse.Time.UTC()
+	entry.Name = fmt.Sprintf("%s%s/%s-%d.ndjson.gz", jobsPrefix, entry.FirstCompleted.Format("2006/01/02"),
+		entry.FirstCompleted.Format("20060102T150405.000000Z"), first.Job.JobID)
+	entry.Count = len(jrs)
+	entry.Created = a.now()
+	checksum := sha256.Sum256(buf.Bytes())
+	entry.SHA256 = hex.EncodeToString(checksum[:])
+
+	if err = a.Store.Write(entry.Name, buf.Bytes()); err != nil {
+		return
+	}
+	manifest, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	err = a.Store.Write(manifestName(entry.Name), manifest)
+	return
+}
+
+// manifestName returns the name of the manifest entry for an archive file.
+func manifestName(name string) string {
+	return manifestPrefix + strings.TrimSuffix(strings.TrimPrefix(name, jobsPrefix), ".ndjson.gz") + ".json"
+}
+
+// Search returns the manifest entries of the archive files which contain jobs completed between from and to, in the
+// order they were completed. A zero from or to leaves that end of the range open.
+func Search(store Store, from, to time.Time) (entries []ManifestEntry, err error) {
+	names, err := store.List(manifestPrefix)
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		if path.Ext(name) != ".json" {
+			continue
+		}
+		var b []byte
+		if b, err = store.Read(name); err != nil {
+			return
+		}
+		var e ManifestEntry
+		if err = json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("archive: invalid manifest entry '%v': %v", name, err)
+		}
+		if (!from.IsZero() && e.LastCompleted.Before(from)) || (!to.IsZero() && e.FirstCompleted.After(to)) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return
+}
+
+// Read returns the jobs in an archive file, so that they can be inspected or imported again. The file is checked
+// against the checksum in its manifest entry.
+func Read(store Store, entry ManifestEntry) (jrs []data.JobAndResponse, err error) {
+	b, err := store.Read(entry.Name)
+	if err != nil {
+		return
+	}
+	checksum := sha256.Sum256(b)
+	if hex.EncodeToString(checksum[:]) != entry.SHA256 {
+		return nil, fmt.Errorf("archive: checksum of '%v' doesn't match the manifest", entry.Name)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+	defer zr.Close()
+
+	s := bufio.NewScanner(zr)
+	// Responses can be large, so allow lines of up to 64MB.
+	s.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for s.Scan() {
+		var jr data.JobAndResponse
+		if err = json.Unmarshal(s.Bytes(), &jr); err != nil {
+			return nil, err
+		}
+		jrs = append(jrs, jr)
+	}
+	return jrs, s.Err()
+}
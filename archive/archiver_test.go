@@ -0,0 +1,159 @@
+package archive
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/memory"
+)
+
+func newTestDirectory(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "callme_archive_test")
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	return dir
+}
+
+// completeJobs starts and completes jobs in the memory backend, returning their IDs in the order they completed.
+func completeJobs(t *testing.T, jm memory.JobManager, n int) (jobIDs []int64) {
+	for i := 0; i < n; i++ {
+		j, _, err := jm.StartJob(time.Now().UTC().Add(-time.Minute), "testarn", "testpayload", nil, nil, nil, "", 0)
+		if err != nil {
+			t.Fatalf("failed to start job: %v", err)
+		}
+		lj, ok, err := jm.GetJob("archive_test", 5)
+		if err != nil || !ok {
+			t.Fatalf("failed to get job, ok=%v, err=%v", ok, err)
+		}
+		if _, err = jm.CompleteJob(j.JobID, lj.JobLeaseID, "response", nil); err != nil {
+			t.Fatalf("failed to complete job: %v", err)
+		}
+		jobIDs = append(jobIDs, j.JobID)
+		time.Sleep(time.Millisecond)
+	}
+	return
+}
+
+func TestThatJobsAreArchivedBeforeTheyArePurged(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer os.RemoveAll(dir)
+
+	jm := memory.NewJobManager(memory.NewDatabase())
+	jobIDs := completeJobs(t, jm, 3)
+	a := NewArchiver(NewDirectoryStore(dir), jm.GetCompletedJobs, jm.DeleteCompletedJobs)
+
+	before := time.Now().UTC().Add(time.Minute)
+	purged, err := a.Purge(before, 2)
+	if err != nil || purged != 2 {
+		t.Fatalf("expected to purge 2 jobs, but purged %v, err=%v", purged, err)
+	}
+	purged, err = a.Purge(before, 2)
+	if err != nil || purged != 1 {
+		t.Fatalf("expected to purge the last job, but purged %v, err=%v", purged, err)
+	}
+	purged, err = a.Purge(before, 2)
+	if err != nil || purged != 0 {
+		t.Fatalf("expected nothing left to purge, but purged %v, err=%v", purged, err)
+	}
+	for _, id := range jobIDs {
+		if _, _, jobOK, _, _ := jm.GetJobResponse(id); jobOK {
+			t.Errorf("expected job %v to have been purged", id)
+		}
+	}
+
+	entries, err := Search(a.Store, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("failed to search the manifest: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Count != 2 || entries[1].Count != 1 {
+		t.Fatalf("expected 2 archive files, with 2 and 1 jobs, got %+v", entries)
+	}
+	if !entries[0].Contains(jobIDs[0]) || !entries[0].Contains(jobIDs[1]) || !entries[1].Contains(jobIDs[2]) {
+		t.Errorf("expected the archive files to contain the jobs in the order they completed, got %+v", entries)
+	}
+
+	jrs, err := Read(a.Store, entries[0])
+	if err != nil {
+		t.Fatalf("failed to read archive file: %v", err)
+	}
+	if len(jrs) != 2 || jrs[0].Job.JobID != jobIDs[0] || jrs[1].Job.JobID != jobIDs[1] {
+		t.Fatalf("expected the first 2 jobs to be read back, got %+v", jrs)
+	}
+	if jrs[0].Job.Payload != "testpayload" || !jrs[0].HasJobResponse || jrs[0].JobResponse.Response != "response" || len(jrs[0].Attempts) != 1 {
+		t.Errorf("expected the job, its response and its attempts to be archived, got %+v", jrs[0])
+	}
+}
+
+type failingStore struct {
+	Store
+}
+
+func (s failingStore) Write(name string, data []byte) error {
+	return errors.New("disk full")
+}
+
+func TestThatJobsAreKeptIfTheyCannotBeArchived(t *testing.T) {
+	jm := memory.NewJobManager(memory.NewDatabase())
+	jobIDs := completeJobs(t, jm, 1)
+	a := NewArchiver(failingStore{}, jm.GetCompletedJobs, jm.DeleteCompletedJobs)
+
+	purged, err := a.Purge(time.Now().UTC().Add(time.Minute), 10)
+	if err == nil || purged != 0 {
+		t.Errorf("expected the purge to fail, but purged %v, err=%v", purged, err)
+	}
+	if _, _, jobOK, _, _ := jm.GetJobResponse(jobIDs[0]); !jobOK {
+		t.Error("expected the job to be kept, because it wasn't archived")
+	}
+}
+
+func TestThatTheManifestCanBeSearchedByCompletionTime(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer os.RemoveAll(dir)
+
+	a := NewArchiver(NewDirectoryStore(dir), nil, nil)
+	day := time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		completed := day.Add(time.Hour * 24 * time.Duration(i))
+		jr := data.JobAndResponse{
+			Job:         data.Job{JobID: int64(i + 1)},
+			JobResponse: data.JobResponse{JobID: int64(i + 1), Time: completed},
+		}
+		if _, err := a.write([]data.JobAndResponse{jr}); err != nil {
+			t.Fatalf("failed to write archive file: %v", err)
+		}
+	}
+
+	entries, err := Search(a.Store, day.Add(time.Hour), day.Add(time.Hour*24))
+	if err != nil {
+		t.Fatalf("failed to search the manifest: %v", err)
+	}
+	if len(entries) != 1 || !entries[0].Contains(2) {
+		t.Errorf("expected only the second day's archive file to be found, got %+v", entries)
+	}
+	entries, err = Search(a.Store, day.Add(time.Hour*24), time.Time{})
+	if err != nil || len(entries) != 2 {
+		t.Errorf("expected the last 2 days' archive files to be found, got %+v, err=%v", entries, err)
+	}
+}
+
+func TestThatAChangedArchiveFileIsNotRead(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer os.RemoveAll(dir)
+
+	a := NewArchiver(NewDirectoryStore(dir), nil, nil)
+	entry, err := a.write([]data.JobAndResponse{{Job: data.Job{JobID: 1}}})
+	if err != nil {
+		t.Fatalf("failed to write archive file: %v", err)
+	}
+	if err = a.Store.Write(entry.Name, []byte("changed")); err != nil {
+		t.Fatalf("failed to change archive file: %v", err)
+	}
+	if _, err = Read(a.Store, entry); err == nil {
+		t.Error("expected an error, because the file doesn't match its checksum")
+	}
+}
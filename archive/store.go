@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// A Store saves archive files by name, e.g. "jobs/2018/03/01/20180301T120000Z-42.ndjson.gz".
+type Store interface {
+	// Write saves the file, replacing it if it already exists.
+	Write(name string, data []byte) error
+	// Read returns the contents of the file.
+	Read(name string) ([]byte, error)
+	// List returns the names of the files which start with the prefix, in order.
+	List(prefix string) ([]string, error)
+}
+
+// Open returns the Store for the URL. s3://bucket/prefix URLs use an S3 bucket, where the endpoint can be set to
+// use an S3 compatible service instead of AWS, while file:// URLs and paths use a local directory.
+func Open(url string, endpoint string) Store {
+	if strings.HasPrefix(url, "s3://") {
+		bucketAndPrefix := strings.SplitN(strings.TrimPrefix(url, "s3://"), "/", 2)
+		var prefix string
+		if len(bucketAndPrefix) == 2 && bucketAndPrefix[1] != "" {
+			prefix = strings.TrimSuffix(bucketAndPrefix[1], "/") + "/"
+		}
+		config := &aws.Config{}
+		if endpoint != "" {
+			// S3 compatible services don't usually support bucket subdomains.
+			config.Endpoint = aws.String(endpoint)
+			config.S3ForcePathStyle = aws.Bool(true)
+		}
+		return NewS3Store(s3.New(session.New(config)), bucketAndPrefix[0], prefix)
+	}
+	return NewDirectoryStore(strings.TrimPrefix(url, "file://"))
+}
+
+// DirectoryStore saves archive files in a local directory.
+type DirectoryStore struct {
+	Directory string
+}
+
+// NewDirectoryStore creates a Store which saves files in the directory, which is created if it doesn't exist.
+func NewDirectoryStore(directory string) DirectoryStore {
+	return DirectoryStore{
+		Directory: directory,
+	}
+}
+
+// Write saves the file. It's written to a temporary file first, so that readers never see part of a file.
+func (s DirectoryStore) Write(name string, data []byte) error {
+	path := filepath.Join(s.Directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Read returns the contents of the file.
+func (s DirectoryStore) Read(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.Directory, filepath.FromSlash(name)))
+}
+
+// List returns the names of the files which start with the prefix, in order.
+func (s DirectoryStore) List(prefix string) (names []string, err error) {
+	err = filepath.Walk(s.Directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == s.Directory {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(s.Directory, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if strings.HasPrefix(name, prefix) && !strings.Contains(filepath.Base(name), ".tmp") {
+			names = append(names, name)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return
+}
+
+// s3API is the part of the S3 client used by the S3Store.
+type s3API interface {
+	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error
+}
+
+// S3Store saves archive files in an S3 bucket, under a prefix.
+type S3Store struct {
+	client s3API
+	Bucket string
+	// Prefix is added to the name of each file, e.g. "callme/".
+	Prefix string
+}
+
+// NewS3Store creates a Store which saves files in the bucket, under the prefix.
+func NewS3Store(client s3API, bucket, prefix string) S3Store {
+	return S3Store{
+		client: client,
+		Bucket: bucket,
+		Prefix: prefix,
+	}
+}
+
+// Write saves the file as an object.
+func (s S3Store) Write(name string, data []byte) error {
+	_, err := s.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.Prefix + name),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+// Read returns the contents of the object.
+func (s S3Store) Read(name string) ([]byte, error) {
+	o, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.Prefix + name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer o.Body.Close()
+	return ioutil.ReadAll(o.Body)
+}
+
+// List returns the names of the objects which start with the prefix, in order.
+func (s S3Store) List(prefix string) (names []string, err error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Bucket),
+		Prefix: aws.String(s.Prefix + prefix),
+	}
+	err = s.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(o.Key), s.Prefix))
+		}
+		return true
+	})
+	return
+}
@@ -0,0 +1,101 @@
+package archive
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestThatTheStoreIsChosenFromTheURL(t *testing.T) {
+	if s, ok := Open("s3://bucket/callme/archive", "").(S3Store); !ok || s.Bucket != "bucket" || s.Prefix != "callme/archive/" {
+		t.Errorf("expected an S3 store for the bucket and prefix, got %+v", s)
+	}
+	if s, ok := Open("s3://bucket", "http://localhost:9000").(S3Store); !ok || s.Bucket != "bucket" || s.Prefix != "" {
+		t.Errorf("expected an S3 store for the bucket, got %+v", s)
+	}
+	if s, ok := Open("file:///var/lib/callme", "").(DirectoryStore); !ok || s.Directory != "/var/lib/callme" {
+		t.Errorf("expected a directory store, got %+v", s)
+	}
+	if s, ok := Open("archive", "").(DirectoryStore); !ok || s.Directory != "archive" {
+		t.Errorf("expected a directory store, got %+v", s)
+	}
+}
+
+func TestDirectoryStore(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer os.RemoveAll(dir)
+	s := NewDirectoryStore(dir + "/archive")
+
+	names, err := s.List("")
+	if err != nil || len(names) != 0 {
+		t.Errorf("expected a missing directory to be empty, got %v, err=%v", names, err)
+	}
+	for _, name := range []string{"manifest/b.json", "jobs/b.ndjson.gz", "manifest/a.json"} {
+		if err = s.Write(name, []byte(name)); err != nil {
+			t.Fatalf("failed to write '%v': %v", name, err)
+		}
+	}
+	if err = s.Write("manifest/a.json", []byte("replaced")); err != nil {
+		t.Fatalf("failed to replace file: %v", err)
+	}
+	names, err = s.List("manifest/")
+	if err != nil || !reflect.DeepEqual(names, []string{"manifest/a.json", "manifest/b.json"}) {
+		t.Errorf("expected the manifest files in order, got %v, err=%v", names, err)
+	}
+	b, err := s.Read("manifest/a.json")
+	if err != nil || string(b) != "replaced" {
+		t.Errorf("expected the replaced contents, got '%s', err=%v", b, err)
+	}
+}
+
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	b, err := ioutil.ReadAll(input.Body)
+	f.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)] = b
+	return &s3.PutObjectOutput{}, err
+}
+
+func (f *fakeS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	b := f.objects[aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)]
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(b))}, nil
+}
+
+func (f *fakeS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	// Return a page for each object, to check that every page is read.
+	for _, key := range []string{"callme/manifest/a.json", "callme/manifest/b.json"} {
+		if _, ok := f.objects[aws.StringValue(input.Bucket)+"/"+key]; ok {
+			fn(&s3.ListObjectsV2Output{Contents: []*s3.Object{{Key: aws.String(key)}}}, false)
+		}
+	}
+	return nil
+}
+
+func TestS3Store(t *testing.T) {
+	client := &fakeS3{objects: make(map[string][]byte)}
+	s := NewS3Store(client, "bucket", "callme/")
+
+	for _, name := range []string{"manifest/a.json", "manifest/b.json"} {
+		if err := s.Write(name, []byte(name)); err != nil {
+			t.Fatalf("failed to write '%v': %v", name, err)
+		}
+	}
+	if _, ok := client.objects["bucket/callme/manifest/a.json"]; !ok {
+		t.Errorf("expected the object to be written under the prefix, got %v", client.objects)
+	}
+	names, err := s.List("manifest/")
+	if err != nil || !reflect.DeepEqual(names, []string{"manifest/a.json", "manifest/b.json"}) {
+		t.Errorf("expected the names without the prefix, got %v, err=%v", names, err)
+	}
+	b, err := s.Read("manifest/b.json")
+	if err != nil || string(b) != "manifest/b.json" {
+		t.Errorf("expected the contents of the object, got '%s', err=%v", b, err)
+	}
+}
@@ -0,0 +1,122 @@
+// Command callme manages a callme installation. It reads the CALLME_CONNECTION_STRING environment variable in the
+// same way as the worker and API.
+//
+// Usage:
+//
+//	callme migrate status     shows the version of the database schema
+//	callme migrate up         runs all of the migrations which haven't been run yet
+//	callme migrate down N     reverts the last N migrations
+//	callme migrate goto V     migrates up or down to version V
+//	callme migrate force V    sets the version to V without running any migrations, clearing the dirty flag
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/welldigital/callme/storage"
+)
+
+const usage = `usage: callme migrate <command>
+
+commands:
+  status     shows the version of the database schema
+  up         runs all of the migrations which haven't been run yet
+  down N     reverts the last N migrations
+  goto V     migrates up or down to version V
+  force V    sets the version to V without running any migrations, clearing the dirty flag
+`
+
+// errUsage is returned when the command line arguments are invalid.
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	if len(os.Args) < 3 || os.Args[1] != "migrate" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	connectionString := os.Getenv("CALLME_CONNECTION_STRING")
+	if connectionString == "" {
+		fmt.Fprintln(os.Stderr, "missing connection string environment variable (CALLME_CONNECTION_STRING)")
+		os.Exit(1)
+	}
+	store, err := storage.Open(connectionString, storage.PoolOptions{MaxOpenConnections: 1, MaxIdleConnections: 1})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open the database: %v\n", err)
+		os.Exit(1)
+	}
+	if store.Migrator == nil {
+		fmt.Fprintf(os.Stderr, "the %v backend doesn't have a schema to migrate\n", store.Backend)
+		os.Exit(1)
+	}
+
+	code := 0
+	err = migrate(store.Migrator, os.Args[2:], os.Stdout)
+	if err == errUsage {
+		fmt.Fprint(os.Stderr, usage)
+		code = 2
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to %v: %v\n", os.Args[2], err)
+		code = 1
+	}
+	store.Close()
+	os.Exit(code)
+}
+
+func migrate(m storage.Migrator, args []string, w io.Writer) (err error) {
+	switch {
+	case len(args) == 1 && args[0] == "status":
+	case len(args) == 1 && args[0] == "up":
+		err = m.UpdateSchema()
+	case len(args) == 2 && args[0] == "down":
+		n, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil || n < 1 {
+			return errUsage
+		}
+		err = m.Down(n)
+	case len(args) == 2 && args[0] == "goto":
+		v, parseErr := strconv.ParseUint(args[1], 10, 64)
+		if parseErr != nil {
+			return errUsage
+		}
+		err = m.Goto(uint(v))
+	case len(args) == 2 && args[0] == "force":
+		v, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil || v < -1 {
+			return errUsage
+		}
+		err = m.Force(v)
+	default:
+		return errUsage
+	}
+	if err != nil {
+		return
+	}
+	return status(m, w)
+}
+
+func status(m storage.Migrator, w io.Writer) error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	latest, err := m.LatestVersion()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "version: %v\nlatest: %v\ndirty: %v\n", version, latest, dirty)
+	switch {
+	case dirty:
+		fmt.Fprintln(w, "a migration failed part way through, fix the database and then force the version")
+	case version < latest:
+		fmt.Fprintln(w, "behind, run `callme migrate up` to update it")
+	case version > latest:
+		fmt.Fprintln(w, "the database is ahead of this program")
+	default:
+		fmt.Fprintln(w, "up to date")
+	}
+	return nil
+}
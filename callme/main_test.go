@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeMigrator struct {
+	version, latest uint
+	dirty           bool
+	calls           []string
+	err             error
+}
+
+func (m *fakeMigrator) UpdateSchema() error {
+	m.calls = append(m.calls, "up")
+	m.version = m.latest
+	return m.err
+}
+
+func (m *fakeMigrator) Version() (uint, bool, error) { return m.version, m.dirty, nil }
+
+func (m *fakeMigrator) LatestVersion() (uint, error) { return m.latest, nil }
+
+func (m *fakeMigrator) Down(n int) error {
+	m.calls = append(m.calls, "down")
+	m.version -= uint(n)
+	return m.err
+}
+
+func (m *fakeMigrator) Goto(version uint) error {
+	m.calls = append(m.calls, "goto")
+	m.version = version
+	return m.err
+}
+
+func (m *fakeMigrator) Force(version int) error {
+	m.calls = append(m.calls, "force")
+	m.version, m.dirty = uint(version), false
+	return m.err
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		version         uint
+		dirty           bool
+		expectedCall    string
+		expectedVersion uint
+		expectedOutput  string
+	}{
+		{name: "status", args: []string{"status"}, version: 19, expectedVersion: 19, expectedOutput: "up to date"},
+		{name: "status behind", args: []string{"status"}, version: 17, expectedVersion: 17, expectedOutput: "behind"},
+		{name: "status dirty", args: []string{"status"}, version: 18, dirty: true, expectedVersion: 18, expectedOutput: "force the version"},
+		{name: "up", args: []string{"up"}, version: 17, expectedCall: "up", expectedVersion: 19, expectedOutput: "up to date"},
+		{name: "down", args: []string{"down", "2"}, version: 19, expectedCall: "down", expectedVersion: 17, expectedOutput: "version: 17"},
+		{name: "goto", args: []string{"goto", "18"}, version: 12, expectedCall: "goto", expectedVersion: 18, expectedOutput: "version: 18"},
+		{name: "force", args: []string{"force", "18"}, version: 18, dirty: true, expectedCall: "force", expectedVersion: 18, expectedOutput: "dirty: false"},
+	}
+
+	for _, test := range tests {
+		m := &fakeMigrator{version: test.version, latest: 19, dirty: test.dirty}
+		w := new(bytes.Buffer)
+		if err := migrate(m, test.args, w); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if test.expectedCall != "" && (len(m.calls) != 1 || m.calls[0] != test.expectedCall) {
+			t.Errorf("%s: expected a call to %v, got %v", test.name, test.expectedCall, m.calls)
+		}
+		if m.version != test.expectedVersion {
+			t.Errorf("%s: expected version %v, got %v", test.name, test.expectedVersion, m.version)
+		}
+		if !strings.Contains(w.String(), test.expectedOutput) {
+			t.Errorf("%s: expected the output to contain '%v', got '%v'", test.name, test.expectedOutput, w.String())
+		}
+	}
+}
+
+func TestMigrateRejectsInvalidArguments(t *testing.T) {
+	for _, args := range [][]string{{}, {"sideways"}, {"down"}, {"down", "0"}, {"down", "x"}, {"goto", "-1"}, {"force"}, {"up", "1"}} {
+		m := &fakeMigrator{version: 19, latest: 19}
+		if err := migrate(m, args, new(bytes.Buffer)); err != errUsage {
+			t.Errorf("%v: expected a usage error, got %v", args, err)
+		}
+		if len(m.calls) > 0 {
+			t.Errorf("%v: expected no changes, got %v", args, m.calls)
+		}
+	}
+}
+
+func TestMigrateReturnsErrors(t *testing.T) {
+	expected := errors.New("migration failed")
+	m := &fakeMigrator{version: 17, latest: 19, err: expected}
+	w := new(bytes.Buffer)
+	if err := migrate(m, []string{"up"}, w); err != expected {
+		t.Errorf("expected the migration error, got %v", err)
+	}
+	if w.Len() > 0 {
+		t.Errorf("expected no status to be written after an error, got '%v'", w.String())
+	}
+}
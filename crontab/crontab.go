@@ -0,0 +1,193 @@
+// Package crontab evaluates crontabs against the wall clock of a schedule's timezone.
+//
+// When a timezone is set, crontab fields match local time, so "0 9 * * MON-FRI" in
+// Europe/London fires at 9am whether the UK is on GMT or BST. Daylight saving
+// transitions are handled as follows:
+//   - Gaps: a local time which doesn't exist because the clocks went forward fires
+//     after the gap, moved forward by the length of the gap, e.g. 01:30 on the day
+//     the UK moves to BST fires at 02:30 BST.
+//   - Overlaps: a local time which occurs twice because the clocks went back fires
+//     once, on its first occurrence, e.g. 01:30 on the day the UK moves to GMT fires
+//     at 01:30 BST and not again at 01:30 GMT.
+//
+// The next time is always after the time it's calculated from. Fixed intervals such as
+// "@every 1h" are not affected by the timezone.
+package crontab
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	cron "gopkg.in/robfig/cron.v2"
+)
+
+// Schedule is a parsed crontab and the timezone it's evaluated in.
+type Schedule struct {
+	schedule cron.Schedule
+	location *time.Location
+}
+
+// Parse parses a crontab which is evaluated in the IANA timezone, e.g. "Europe/London".
+// If the timezone is empty, the crontab is evaluated in UTC, or in the timezone of its TZ= prefix.
+func Parse(spec string, timezone string) (Schedule, error) {
+	loc, err := Location(timezone)
+	if err != nil {
+		return Schedule{}, err
+	}
+	if loc != nil && strings.HasPrefix(spec, "TZ=") {
+		return Schedule{}, errors.New("a crontab can't include TZ= when the schedule has a timezone")
+	}
+	c, err := cron.Parse(spec)
+	if err != nil {
+		return Schedule{}, err
+	}
+	// The cron package defaults to the local timezone of the process.
+	if s, ok := c.(*cron.SpecSchedule); ok && loc == nil && !strings.HasPrefix(spec, "TZ=") {
+		s.Location = time.UTC
+	}
+	return Schedule{
+		schedule: c,
+		location: loc,
+	}, nil
+}
+
+// Location loads the IANA timezone, returning nil if the timezone is empty.
+func Location(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return nil, nil
+	}
+	if len(timezone) > 64 {
+		return nil, errors.New("maximum length of the timezone is 64 characters")
+	}
+	// "Local" would depend on the configuration of each worker.
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("unknown timezone '%v'", timezone)
+	}
+	return loc, nil
+}
+
+// Next returns the first time after from at which the crontab fires, in UTC. A zero time
+// is returned if the crontab never fires.
+func (s Schedule) Next(from time.Time) time.Time {
+	spec, ok := s.schedule.(*cron.SpecSchedule)
+	if !ok || s.location == nil {
+		return s.schedule.Next(from).UTC()
+	}
+	// Walk the local wall clock, which has no gaps or overlaps, by evaluating the crontab
+	// against wall clock times written in UTC.
+	wallSpec := *spec
+	wallSpec.Location = time.UTC
+	wall := wallClock(from.In(s.location))
+	for {
+		wall = wallSpec.Next(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		next := inLocation(wall, s.location)
+		if next.After(from) {
+			return next.UTC()
+		}
+	}
+}
+
+// wallClock returns the local date and time of t, written as a UTC time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// inLocation returns the earliest time in loc which has the wall clock time, or if the wall clock
+// time falls in a gap, the time after the gap. time.Date doesn't define which of these it returns.
+func inLocation(wall time.Time, loc *time.Location) time.Time {
+	_, offsetBefore := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, offsetAfter := wall.Add(24 * time.Hour).In(loc).Zone()
+	var earliest time.Time
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClock(t).Equal(wall) && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	if !earliest.IsZero() {
+		return earliest
+	}
+	// The wall clock time is in a gap, so use the offset from before the gap, which moves it
+	// forward by the length of the gap.
+	return wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+}
+
+// Resume calculates the Next value of each crontab when a paused schedule is resumed at the given time. The
+// crontabs are moved on to their first run after the resume time, skipping any runs missed while the schedule
+// was paused. If catchUp is set and any runs were missed, the crontab with the earliest missed run is due at the
+// resume time instead, so that a single job is started to catch up. Crontabs which can't be parsed, or never
+// run again, keep their Next value.
+func Resume(crontabs []data.Crontab, timezone string, at time.Time, catchUp bool) (next []time.Time, caughtUp bool) {
+	next = make([]time.Time, len(crontabs))
+	earliestMissed := -1
+	for i, ct := range crontabs {
+		next[i] = ct.Next
+		s, err := Parse(ct.Crontab, timezone)
+		if err != nil {
+			continue
+		}
+		if n := s.Next(at); !n.IsZero() {
+			next[i] = n
+		}
+		if !ct.Next.After(at) && (earliestMissed < 0 || ct.Next.Before(crontabs[earliestMissed].Next)) {
+			earliestMissed = i
+		}
+	}
+	if catchUp && earliestMissed >= 0 {
+		next[earliestMissed] = at
+		caughtUp = true
+	}
+	return
+}
+
+// Replace calculates the changes needed to replace a schedule's crontabs with the given crontab specs at the given time.
+// Existing crontabs which match a spec are kept, and are only returned in updated, with a recalculated Next value, if the
+// timezone has changed. Specs which don't match an existing crontab are returned in added, and existing crontabs which
+// don't match a spec are returned in removed. New and recalculated crontabs are next due at their first run after at.
+func Replace(existing []data.Crontab, specs []string, timezoneChanged bool, timezone string, at time.Time) (added, updated, removed []data.Crontab, err error) {
+	unmatched := make(map[string]int)
+	for _, spec := range specs {
+		unmatched[spec]++
+	}
+	for _, ct := range existing {
+		if unmatched[ct.Crontab] == 0 {
+			removed = append(removed, ct)
+			continue
+		}
+		unmatched[ct.Crontab]--
+		if !timezoneChanged {
+			continue
+		}
+		if ct.Next, err = next(ct.Crontab, timezone, at); err != nil {
+			return
+		}
+		updated = append(updated, ct)
+	}
+	for _, spec := range specs {
+		if unmatched[spec] == 0 {
+			continue
+		}
+		unmatched[spec]--
+		ct := data.Crontab{Crontab: spec}
+		if ct.Next, err = next(spec, timezone, at); err != nil {
+			return
+		}
+		added = append(added, ct)
+	}
+	return
+}
+
+func next(spec, timezone string, at time.Time) (time.Time, error) {
+	s, err := Parse(spec, timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.Next(at), nil
+}
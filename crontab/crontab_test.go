@@ -0,0 +1,315 @@
+package crontab
+
+import (
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		crontab  string
+		timezone string
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "no timezone",
+			crontab:  "TZ=UTC 0 9 * * *",
+			timezone: "",
+			from:     time.Date(2018, time.March, 24, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 25, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.March, 26, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "UTC",
+			crontab:  "0 9 * * *",
+			timezone: "UTC",
+			from:     time.Date(2018, time.March, 24, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 25, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.March, 26, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "9am London, GMT to BST",
+			crontab:  "0 9 * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.March, 24, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 25, 8, 0, 0, 0, time.UTC),
+				time.Date(2018, time.March, 26, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "9am London, BST to GMT",
+			crontab:  "0 9 * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.October, 27, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.October, 28, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.October, 29, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "9am New York, EST to EDT",
+			crontab:  "0 9 * * MON-FRI",
+			timezone: "America/New_York",
+			from:     time.Date(2018, time.March, 9, 15, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 12, 13, 0, 0, 0, time.UTC),
+				time.Date(2018, time.March, 13, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "gap: 01:30 London is moved forward to 02:30 BST",
+			crontab:  "30 1 * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.March, 24, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 25, 1, 30, 0, 0, time.UTC),
+				time.Date(2018, time.March, 26, 0, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "gap: every 20 minutes doesn't go backwards",
+			crontab:  "*/20 * * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.March, 25, 0, 30, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.March, 25, 0, 40, 0, 0, time.UTC), // 00:40 GMT
+				time.Date(2018, time.March, 25, 1, 0, 0, 0, time.UTC),  // 01:00 GMT doesn't exist, so 02:00 BST
+				time.Date(2018, time.March, 25, 1, 20, 0, 0, time.UTC), // 02:20 BST
+				time.Date(2018, time.March, 25, 1, 40, 0, 0, time.UTC), // 02:40 BST
+				time.Date(2018, time.March, 25, 2, 0, 0, 0, time.UTC),  // 03:00 BST
+			},
+		},
+		{
+			name:     "overlap: 01:30 London fires once, at 01:30 BST",
+			crontab:  "30 1 * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.October, 27, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.October, 28, 0, 30, 0, 0, time.UTC),
+				time.Date(2018, time.October, 29, 1, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "overlap: hourly doesn't repeat the hour",
+			crontab:  "0 * * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.October, 27, 23, 30, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.October, 28, 0, 0, 0, 0, time.UTC), // 01:00 BST
+				time.Date(2018, time.October, 28, 2, 0, 0, 0, time.UTC), // 02:00 GMT
+				time.Date(2018, time.October, 28, 3, 0, 0, 0, time.UTC), // 03:00 GMT
+			},
+		},
+		{
+			name:     "overlap: starting in the repeated hour",
+			crontab:  "30 1 * * *",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.October, 28, 1, 10, 0, 0, time.UTC), // 01:10 GMT
+			expected: []time.Time{
+				time.Date(2018, time.October, 29, 1, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "intervals ignore the timezone",
+			crontab:  "@every 1h",
+			timezone: "Europe/London",
+			from:     time.Date(2018, time.October, 28, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2018, time.October, 28, 1, 0, 0, 0, time.UTC),
+				time.Date(2018, time.October, 28, 2, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s, err := Parse(test.crontab, test.timezone)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		from := test.from
+		for i, expected := range test.expected {
+			actual := s.Next(from)
+			if !actual.Equal(expected) {
+				t.Errorf("%s: run %d: expected %v, got %v", test.name, i, expected, actual)
+			}
+			if actual.Location() != time.UTC {
+				t.Errorf("%s: run %d: expected a UTC time, got %v", test.name, i, actual.Location())
+			}
+			from = actual
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		crontab     string
+		timezone    string
+		expectedErr string
+	}{
+		{crontab: "0 9 * * *", timezone: ""},
+		{crontab: "0 9 * * *", timezone: "Europe/London"},
+		{crontab: "TZ=Europe/London 0 9 * * *", timezone: ""},
+		{crontab: "0 9 * * *", timezone: "Europe/Nowhere", expectedErr: "unknown timezone 'Europe/Nowhere'"},
+		{crontab: "0 9 * * *", timezone: "Local", expectedErr: "unknown timezone 'Local'"},
+		{crontab: "TZ=Europe/London 0 9 * * *", timezone: "Europe/London", expectedErr: "a crontab can't include TZ= when the schedule has a timezone"},
+		{crontab: "nonsense", timezone: "Europe/London", expectedErr: "Expected 5 or 6 fields, found 1: nonsense"},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.crontab, test.timezone)
+		var actualErr string
+		if err != nil {
+			actualErr = err.Error()
+		}
+		if actualErr != test.expectedErr {
+			t.Errorf("%s in '%s': expected error '%v', got '%v'", test.crontab, test.timezone, test.expectedErr, actualErr)
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	at := time.Date(2018, time.January, 10, 12, 30, 0, 0, time.UTC)
+	crontabs := []data.Crontab{
+		// Missed runs every hour while paused.
+		{Crontab: "0 * * * *", Next: time.Date(2018, time.January, 2, 10, 0, 0, 0, time.UTC)},
+		// Missed runs every day, starting earlier.
+		{Crontab: "0 9 * * *", Next: time.Date(2018, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		// Not missed.
+		{Crontab: "0 9 1 * *", Next: time.Date(2018, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		// Can't be parsed.
+		{Crontab: "nonsense", Next: time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name             string
+		catchUp          bool
+		expected         []time.Time
+		expectedCaughtUp bool
+	}{
+		{
+			name:    "skip missed runs",
+			catchUp: false,
+			expected: []time.Time{
+				time.Date(2018, time.January, 10, 13, 0, 0, 0, time.UTC),
+				time.Date(2018, time.January, 11, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.February, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedCaughtUp: false,
+		},
+		{
+			name:    "catch up once",
+			catchUp: true,
+			expected: []time.Time{
+				time.Date(2018, time.January, 10, 13, 0, 0, 0, time.UTC),
+				at,
+				time.Date(2018, time.February, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedCaughtUp: true,
+		},
+	}
+
+	for _, test := range tests {
+		actual, actualCaughtUp := Resume(crontabs, "", at, test.catchUp)
+		for i := range test.expected {
+			if !actual[i].Equal(test.expected[i]) {
+				t.Errorf("%s: crontab %d: expected next %v, got %v", test.name, i, test.expected[i], actual[i])
+			}
+		}
+		if actualCaughtUp != test.expectedCaughtUp {
+			t.Errorf("%s: expected caught up %v, got %v", test.name, test.expectedCaughtUp, actualCaughtUp)
+		}
+	}
+
+	// Nothing to catch up on.
+	_, caughtUp := Resume(crontabs[2:3], "", at, true)
+	if caughtUp {
+		t.Errorf("expected no catch up when no runs were missed")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	at := time.Date(2018, time.January, 10, 12, 30, 0, 0, time.UTC)
+	existing := []data.Crontab{
+		{CrontabID: 1, Crontab: "0 * * * *", Next: time.Date(2018, time.January, 10, 13, 0, 0, 0, time.UTC)},
+		{CrontabID: 2, Crontab: "0 9 * * *", Next: time.Date(2018, time.January, 11, 9, 0, 0, 0, time.UTC)},
+		{CrontabID: 3, Crontab: "0 9 * * *", Next: time.Date(2018, time.January, 11, 9, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name            string
+		specs           []string
+		timezoneChanged bool
+		timezone        string
+		expectedAdded   []data.Crontab
+		expectedUpdated []data.Crontab
+		expectedRemoved []int64
+	}{
+		{
+			name:  "unchanged",
+			specs: []string{"0 9 * * *", "0 * * * *", "0 9 * * *"},
+		},
+		{
+			name:  "add and remove",
+			specs: []string{"0 * * * *", "30 9 * * *"},
+			expectedAdded: []data.Crontab{
+				{Crontab: "30 9 * * *", Next: time.Date(2018, time.January, 11, 9, 30, 0, 0, time.UTC)},
+			},
+			expectedRemoved: []int64{2, 3},
+		},
+		{
+			name:            "timezone changed",
+			specs:           []string{"0 9 * * *"},
+			timezoneChanged: true,
+			timezone:        "America/New_York",
+			expectedUpdated: []data.Crontab{
+				{CrontabID: 2, Crontab: "0 9 * * *", Next: time.Date(2018, time.January, 10, 14, 0, 0, 0, time.UTC)},
+			},
+			expectedRemoved: []int64{1, 3},
+		},
+	}
+
+	for _, test := range tests {
+		added, updated, removed, err := Replace(existing, test.specs, test.timezoneChanged, test.timezone, at)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		assertCrontabs(t, test.name+": added", test.expectedAdded, added)
+		assertCrontabs(t, test.name+": updated", test.expectedUpdated, updated)
+		if len(removed) != len(test.expectedRemoved) {
+			t.Errorf("%s: expected %d removed, got %d", test.name, len(test.expectedRemoved), len(removed))
+			continue
+		}
+		for i := range removed {
+			if removed[i].CrontabID != test.expectedRemoved[i] {
+				t.Errorf("%s: removed %d: expected crontab %v, got %v", test.name, i, test.expectedRemoved[i], removed[i].CrontabID)
+			}
+		}
+	}
+
+	if _, _, _, err := Replace(existing, []string{"nonsense"}, false, "", at); err == nil {
+		t.Errorf("expected an error for a crontab which can't be parsed")
+	}
+}
+
+func assertCrontabs(t *testing.T, name string, expected, actual []data.Crontab) {
+	if len(expected) != len(actual) {
+		t.Errorf("%s: expected %d crontabs, got %d", name, len(expected), len(actual))
+		return
+	}
+	for i := range expected {
+		if expected[i].CrontabID != actual[i].CrontabID || expected[i].Crontab != actual[i].Crontab || !expected[i].Next.Equal(actual[i].Next) {
+			t.Errorf("%s: crontab %d: expected %+v, got %+v", name, i, expected[i], actual[i])
+		}
+	}
+}
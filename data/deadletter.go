@@ -0,0 +1,30 @@
+package data
+
+import "time"
+
+// A DeadLetter records a job which failed permanently, because all of its attempts failed.
+type DeadLetter struct {
+	DeadLetterID int64     `json:"deadLetterId"`
+	Job          Job       `json:"job"`
+	Time         time.Time `json:"time"`
+	Error        string    `json:"error"`
+	// ForwardedTo is the ARN that the dead letter was forwarded to, it's empty if it wasn't forwarded.
+	ForwardedTo string `json:"forwardedTo"`
+	// ForwardError is the error returned when the dead letter was forwarded, if any.
+	ForwardError string `json:"forwardError"`
+	// RequeuedJobID is the ID of the new job created when the dead letter was requeued.
+	RequeuedJobID *int64 `json:"requeuedJobId"`
+	// RequeuedDate is when the dead letter was requeued, it's zero if it hasn't been.
+	RequeuedDate time.Time `json:"requeuedDate"`
+}
+
+// DeadLettersGetter lists dead letters in ID order, starting after the afterID, returning at most limit items.
+// The requeued parameter selects whether to list dead letters which have been requeued, or those which haven't.
+type DeadLettersGetter func(requeued bool, afterID int64, limit int) (dls []DeadLetter, err error)
+
+// DeadLetterRequeuer creates a new job for each dead letter, so that it can be tried again. It returns a map of
+// dead letter ID to the new job ID, dead letters which don't exist or have already been requeued are not included.
+type DeadLetterRequeuer func(deadLetterIDs []int64) (jobIDs map[int64]int64, err error)
+
+// DeadLetterForwardRecorder records the result of forwarding a dead letter to the dead letter ARN.
+type DeadLetterForwardRecorder func(deadLetterID int64, forwardedTo string, forwardErr error) error
@@ -0,0 +1,35 @@
+package data
+
+import (
+	"errors"
+	"time"
+)
+
+// An IdempotencyKey identifies a request which creates a job or schedule, so that if the request is repeated, e.g.
+// because the client timed out waiting for the response, the resource created by the first request is returned
+// instead of creating another.
+type IdempotencyKey struct {
+	// Key is chosen by the caller, max length 256.
+	Key string
+	// Caller scopes the key, so that different callers can use the same keys, max length 256.
+	Caller string
+	// RequestHash is a hash of the request, used to detect a key which is reused for a different request.
+	RequestHash string
+}
+
+// ErrIdempotencyKeyConflict is returned when an idempotency key is reused for a different request.
+var ErrIdempotencyKeyConflict = errors.New("the idempotency key was used for a different request")
+
+// IdempotentJobStarter starts a job like a JobStarter, storing the idempotency key in the same transaction. If a job
+// was already started with the key, that job is returned with existing set to true, unless the key was used for a
+// different request, in which case ErrIdempotencyKeyConflict is returned.
+type IdempotentJobStarter func(key IdempotencyKey, when time.Time, arn string, payload string, httpRequest *HTTPRequest, retryPolicy *RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j Job, existing, duplicate bool, err error)
+
+// IdempotentScheduleCreator creates a schedule like a ScheduleCreator, storing the idempotency key in the same
+// transaction. If a schedule was already created with the key, its ID is returned with existing set to true, unless
+// the key was used for a different request, in which case ErrIdempotencyKeyConflict is returned.
+type IdempotentScheduleCreator func(key IdempotencyKey, from time.Time, arn string, payload string, httpRequest *HTTPRequest, retryPolicy *RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, existing bool, err error)
+
+// IdempotencyKeysPurger deletes up to limit idempotency keys which were created before the cutoff, returning the
+// number deleted. Once a key is deleted, repeating its request creates a new resource.
+type IdempotencyKeysPurger func(createdBefore time.Time, limit int) (purged int, err error)
@@ -9,11 +9,78 @@ import (
 // A job can be delayed by setting the When field to be in the future.
 // A worker must acquire a lease before it should grab a job.
 type Job struct {
-	JobID      int64     `json:"jobId"`
-	ScheduleID *int64    `json:"scheduleId"`
-	When       time.Time `json:"when"`
-	ARN        string    `json:"arn"`
-	Payload    string    `json:"payload"`
+	JobID       int64        `json:"jobId"`
+	ScheduleID  *int64       `json:"scheduleId"`
+	When        time.Time    `json:"when"`
+	ARN         string       `json:"arn"`
+	Payload     string       `json:"payload"`
+	HTTPRequest *HTTPRequest `json:"httpRequest"`
+	RetryPolicy *RetryPolicy `json:"retryPolicy"`
+	// AttemptCount is the number of times that the job has already been executed.
+	AttemptCount int `json:"attemptCount"`
+	// DedupeKey prevents the job from being started twice while it's pending, e.g. when an upstream system emits the
+	// same event twice, max length 256. It's empty once the job has completed, or the key's dedupe window has ended and
+	// the key has been purged.
+	DedupeKey string `json:"dedupeKey"`
+}
+
+// LeasedJob is a Job with the lease that a worker holds on it. It's used by the JobGetter to lease jobs, and isn't
+// designed to be marshalled across an API.
+type LeasedJob struct {
+	Job        Job
+	JobLeaseID int64
+}
+
+// HTTPRequest customises the request made to web (http and https) ARNs. All fields are optional,
+// by default the payload is sent using a POST with a Content-Type of application/json.
+type HTTPRequest struct {
+	// Method is the HTTP method to use, e.g. PUT.
+	Method string `json:"method"`
+	// Headers are additional headers to send, e.g. Authorization.
+	Headers map[string]string `json:"headers"`
+	// ContentType is the Content-Type of the payload, e.g. text/plain.
+	ContentType string `json:"contentType"`
+	// SigningKey is the name of the worker's key used to sign the request. If empty, the key
+	// for the destination host is used, falling back to the default key.
+	SigningKey string `json:"signingKey"`
+}
+
+// RetryPolicy controls how a failed job is retried. Each failed attempt releases the job and
+// reschedules it for a later time, until MaxAttempts is reached. Zero values use the defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times the job will be executed, including the first attempt.
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialIntervalSeconds is the delay before the first retry.
+	InitialIntervalSeconds int `json:"initialIntervalSeconds"`
+	// MaxIntervalSeconds caps the delay between retries.
+	MaxIntervalSeconds int `json:"maxIntervalSeconds"`
+	// Multiplier increases the delay after each retry, e.g. 2 doubles it.
+	Multiplier float64 `json:"multiplier"`
+}
+
+// A JobAttempt records a single execution of a Job.
+type JobAttempt struct {
+	JobAttemptID int64     `json:"jobAttemptId"`
+	JobID        int64     `json:"jobId"`
+	Attempt      int       `json:"attempt"`
+	Time         time.Time `json:"time"`
+	Response     string    `json:"response"`
+	IsError      bool      `json:"isError"`
+	Error        string    `json:"error"`
+	// RetryAt is when the job was rescheduled to run again, it's zero if the job was not retried.
+	RetryAt time.Time `json:"retryAt"`
+}
+
+// A JobDuplicate records an execution of a Job which was completed by a worker whose lease had expired and been taken
+// over, or whose job had already been completed by another worker.
+type JobDuplicate struct {
+	JobDuplicateID int64     `json:"jobDuplicateId"`
+	JobID          int64     `json:"jobId"`
+	JobLeaseID     int64     `json:"jobLeaseId"`
+	Time           time.Time `json:"time"`
+	Response       string    `json:"response"`
+	IsError        bool      `json:"isError"`
+	Error          string    `json:"error"`
 }
 
 // A JobResponse records an execution of the Job.
@@ -28,7 +95,43 @@ type JobResponse struct {
 
 // JobAndResponse contains a job, and an optional response.
 type JobAndResponse struct {
-	Job            Job         `json:"job"`
-	JobResponse    JobResponse `json:"response"`
-	HasJobResponse bool        `json:"hasJobResponse"`
+	Job            Job          `json:"job"`
+	JobResponse    JobResponse  `json:"response"`
+	HasJobResponse bool         `json:"hasJobResponse"`
+	Attempts       []JobAttempt `json:"attempts"`
+	// Duplicates are executions which were completed using a lease that was no longer current.
+	Duplicates []JobDuplicate `json:"duplicates"`
+}
+
+// JobStatePending is a job which hasn't been completed, and isn't leased by a worker.
+const JobStatePending = "pending"
+
+// JobStateLeased is a job which is leased by a worker, so it's being executed.
+const JobStateLeased = "leased"
+
+// JobStateSucceeded is a job which was completed without an error.
+const JobStateSucceeded = "succeeded"
+
+// JobStateFailed is a job which was completed with an error, because it ran out of attempts.
+const JobStateFailed = "failed"
+
+// JobFilter selects the jobs listed by a JobsLister. Zero values match every job.
+type JobFilter struct {
+	// ScheduleID matches the jobs started by a schedule.
+	ScheduleID *int64
+	// State is one of JobStatePending, JobStateLeased, JobStateSucceeded or JobStateFailed.
+	State string
+	// WhenFrom and WhenTo match jobs which are due at or after WhenFrom, and before WhenTo.
+	WhenFrom time.Time
+	WhenTo   time.Time
+	// ARNPrefix matches jobs whose ARN starts with the prefix.
+	ARNPrefix string
+	// IsError matches jobs which have (true) or haven't (false) had a failed attempt.
+	IsError *bool
+}
+
+// A JobSummary is a job listed by a JobsLister, with its state.
+type JobSummary struct {
+	Job   Job    `json:"job"`
+	State string `json:"state"`
 }
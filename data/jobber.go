@@ -4,17 +4,93 @@ import (
 	"time"
 )
 
-// JobStarter schedules a job to start in the future.
-type JobStarter func(when time.Time, arn string, payload string, scheduleID *int64) (Job, error)
+// JobStarter schedules a job to start in the future. If dedupeKey isn't empty, and a pending job was started with the
+// same key less than its dedupe window ago, that job is returned with duplicate set to true instead of starting
+// another. The key is held for dedupeWindow, or until the job completes.
+type JobStarter func(when time.Time, arn string, payload string, httpRequest *HTTPRequest, retryPolicy *RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j Job, duplicate bool, err error)
 
-// JobGetter retrieves a job that's ready to run from the queue.
-type JobGetter func(lockedBy string, lockExpiryMinutes int) (j Job, ok bool, err error)
+// A StartedJob is a job started by a JobsStarter. Duplicate is true if the job had already been started with the same
+// dedupe key, in which case Job is the existing job.
+type StartedJob struct {
+	Job       Job
+	Duplicate bool
+}
 
-// JobCompleter marks a job as complete.
-type JobCompleter func(jobID int64, resp string, err error) error
+// JobsStarter starts a batch of jobs like a JobStarter, using the When, ARN, Payload, HTTPRequest, RetryPolicy,
+// ScheduleID and DedupeKey of each job. The jobs are started in a single transaction, so if err is returned, none of
+// them have been started. Otherwise, the started jobs are returned in the same order.
+type JobsStarter func(jobs []Job, dedupeWindow time.Duration) (started []StartedJob, err error)
+
+// JobGetter leases a job that's ready to run from the queue.
+type JobGetter func(lockedBy string, lockExpiryMinutes int) (lj LeasedJob, ok bool, err error)
+
+// JobsGetter leases up to limit jobs that are ready to run from the queue, in the order they're due. It returns an
+// empty slice if no jobs are ready.
+type JobsGetter func(lockedBy string, lockExpiryMinutes int, limit int) (jobs []LeasedJob, err error)
+
+// JobLeaseRenewer extends a lease on a job, so that it expires lockExpiryMinutes from now. It returns false if the
+// lease has expired, been released, or been taken over by another worker.
+type JobLeaseRenewer func(jobID, jobLeaseID int64, lockExpiryMinutes int) (ok bool, err error)
+
+// The JobCompleter, JobsCompleter, JobRetrier and JobDeadLetterer only complete a job using its current lease, which
+// is the job's latest lease, while the job hasn't been completed. If the lease has expired and the job has been leased
+// again, or the job has already been completed by another worker, the outcome is recorded as a duplicate instead.
+
+// JobCompleter marks a job as complete, or records a duplicate if the lease isn't current.
+type JobCompleter func(jobID, jobLeaseID int64, resp string, jobErr error) (duplicate bool, err error)
+
+// A JobCompletion is the outcome of executing a job, used to complete jobs in batches.
+type JobCompletion struct {
+	JobID      int64
+	JobLeaseID int64
+	Response   string
+	// Err is the error returned by the execution, if any.
+	Err error
+}
+
+// JobsCompleter marks a batch of jobs as complete in a single transaction, returning the IDs of the jobs which were
+// recorded as duplicates because their leases weren't current.
+type JobsCompleter func(completions []JobCompletion) (duplicateJobIDs []int64, err error)
+
+// JobRetrier records a failed attempt at a job, releases its lease and reschedules it to run again at the retryAt time.
+// If the lease isn't current, the attempt is recorded as a duplicate and the job is left alone.
+type JobRetrier func(jobID, jobLeaseID int64, resp string, jobErr error, retryAt time.Time) (duplicate bool, err error)
+
+// JobDeadLetterer records the final failed attempt at a job, completes it with an error and marks it as a dead letter.
+// If the lease isn't current, the attempt is recorded as a duplicate instead, and the dead letter ID is 0.
+type JobDeadLetterer func(j Job, jobLeaseID int64, resp string, jobErr error) (deadLetterID int64, duplicate bool, err error)
+
+// JobDuplicatesGetter gets the duplicate executions of a job, in order.
+type JobDuplicatesGetter func(jobID int64) ([]JobDuplicate, error)
+
+// JobAttemptsGetter gets the attempts made to execute a job, in order.
+type JobAttemptsGetter func(jobID int64) ([]JobAttempt, error)
 
 // JobAndResponseByIDGetter gets a job and its response by its Job ID.
 type JobAndResponseByIDGetter func(jobID int64) (j Job, r JobResponse, jobOK, responseOK bool, err error)
 
+// JobsLister lists the jobs which match the filter in ID order, starting after the afterID, returning at most limit
+// items. New jobs always have a higher ID, so paging through the jobs with the ID of the last one isn't affected by
+// jobs being started at the same time.
+type JobsLister func(filter JobFilter, afterID int64, limit int) (jobs []JobSummary, err error)
+
 // JobDeleter deletes a job that hasn't yet been completed or locked for processing.
 type JobDeleter func(jobID int64) (ok bool, err error)
+
+// JobsPurger deletes up to limit jobs which were completed before the cutoff, along with their responses, attempts
+// and leases. Jobs which have been dead lettered are kept. It returns the number of jobs deleted.
+type JobsPurger func(completedBefore time.Time, limit int) (purged int, err error)
+
+// CompletedJobsGetter gets up to limit jobs which were completed before the cutoff, oldest first, with their responses
+// and attempts. Jobs which have been dead lettered aren't included, so it returns the jobs that a JobsPurger would delete.
+type CompletedJobsGetter func(completedBefore time.Time, limit int) ([]JobAndResponse, error)
+
+// CompletedJobsDeleter deletes completed jobs, along with their responses, attempts and leases. Jobs which haven't
+// been completed, or have been dead lettered, are kept. It returns the number of jobs deleted.
+type CompletedJobsDeleter func(jobIDs []int64) (deleted int, err error)
+
+// JobLeasesPurger deletes up to limit job leases which expired before the cutoff, returning the number deleted.
+type JobLeasesPurger func(expiredBefore time.Time, limit int) (purged int, err error)
+
+// DedupeKeysPurger deletes up to limit dedupe keys whose windows ended before the cutoff, returning the number deleted.
+type DedupeKeysPurger func(expiredBefore time.Time, limit int) (purged int, err error)
@@ -16,12 +16,56 @@ type Schedule struct {
 	ARN string `json:"arn"`
 	// Payload is the payload sent to the ARN (resource).
 	Payload string `json:"payload"`
+	// HTTPRequest customises the request made when the ARN is a web address.
+	HTTPRequest *HTTPRequest `json:"httpRequest"`
+	// RetryPolicy controls how the jobs started by the schedule are retried.
+	RetryPolicy *RetryPolicy `json:"retryPolicy"`
+	// Timezone is the IANA timezone, e.g. "Europe/London", used to evaluate the crontabs.
+	// If empty, crontabs are evaluated in UTC.
+	Timezone string `json:"timezone"`
+	// Until is the time after which the schedule is deactivated. If zero, the schedule doesn't end.
+	Until time.Time `json:"until"`
+	// MaxRuns is the number of jobs the schedule starts before it's deactivated. If zero, there's no limit.
+	MaxRuns int `json:"maxRuns"`
+	// Runs is the number of jobs the schedule has started.
+	Runs int `json:"runs"`
+	// MisfirePolicy controls what happens to runs which were missed because the schedule fell behind,
+	// e.g. because workers were down. One of MisfirePolicyFireAll (the default), MisfirePolicyFireOnce
+	// or MisfirePolicySkip.
+	MisfirePolicy string `json:"misfirePolicy"`
 	// Created is the date that the record was created.
 	Created time.Time `json:"created"`
 	// Active stores whether the schedule is active or not.
 	Active bool `json:"active"`
 	// DeactivatedDate returns the date that the schedule was disabled.
 	DeactivatedDate time.Time `json:"deactivatedDate"`
+	// Paused stores whether the schedule is paused. A paused schedule doesn't start jobs until it's resumed.
+	Paused bool `json:"paused"`
+}
+
+// MisfirePolicyFireAll starts a job for every missed run, one after another. An empty MisfirePolicy is the same.
+const MisfirePolicyFireAll = "fireAll"
+
+// MisfirePolicyFireOnce starts a single job for all of the missed runs, then skips to the next run in the future.
+const MisfirePolicyFireOnce = "fireOnce"
+
+// MisfirePolicySkip doesn't start a job for missed runs, and skips to the next run in the future.
+const MisfirePolicySkip = "skip"
+
+// SchedulePause records a schedule being paused, and when it was resumed.
+type SchedulePause struct {
+	SchedulePauseID int64 `json:"schedulePauseId"`
+	ScheduleID      int64 `json:"scheduleId"`
+	// PausedDate is the date that the schedule was paused.
+	PausedDate time.Time `json:"pausedDate"`
+	// PausedBy tracks which system paused the schedule, max length 256.
+	PausedBy string `json:"pausedBy"`
+	// ResumedDate is the date that the schedule was resumed, or zero if it's still paused.
+	ResumedDate time.Time `json:"resumedDate"`
+	// ResumedBy tracks which system resumed the schedule, max length 256.
+	ResumedBy string `json:"resumedBy"`
+	// CatchUp is set if a job was started on resume to catch up on runs missed while paused.
+	CatchUp bool `json:"catchUp"`
 }
 
 // Crontab contains the schedule data and when it was last executed.
@@ -31,10 +75,10 @@ type Schedule struct {
 //
 // Periodically, a process grabs records which need to be scheduled, i.e. where
 // the Next is in the past. The process then:
-//   * Calculates the "new Next value"
-//   * Schedules a Job to start immediately.
-//   * Places the "old Next value" into the "LastUpdated" field.
-//   * Places the "new Next value" into the "Next" field to schedule the next refresh of the cron schedule.
+//   - Calculates the "new Next value"
+//   - Schedules a Job to start immediately.
+//   - Places the "old Next value" into the "LastUpdated" field.
+//   - Places the "new Next value" into the "Next" field to schedule the next refresh of the cron schedule.
 type Crontab struct {
 	CrontabID   int64     `json:"crontabId"`
 	ScheduleID  int64     `json:"scheduleId"`
@@ -53,8 +97,31 @@ type ScheduleCrontab struct {
 	CrontabLeaseID int64
 }
 
-// ScheduleCrontabs is the schedule data with its matching crontabs attached.
+// ScheduleCrontabs is the schedule data with its matching crontabs and pause history attached.
 type ScheduleCrontabs struct {
+	Schedule Schedule        `json:"schedule"`
+	Crontabs []Crontab       `json:"crontabs"`
+	Pauses   []SchedulePause `json:"pauses"`
+}
+
+// ScheduleFilter selects the schedules listed by a SchedulesLister. Zero values match every schedule.
+type ScheduleFilter struct {
+	// ExternalID matches schedules with the external ID.
+	ExternalID string
+	// By matches schedules which were made by the system.
+	By string
+	// Active matches schedules which are (true) or aren't (false) active.
+	Active *bool
+	// ARN matches schedules with the ARN.
+	ARN string
+	// CreatedFrom and CreatedTo match schedules which were created at or after CreatedFrom, and before CreatedTo.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// A ScheduleSummary is a schedule listed by a SchedulesLister, with its crontabs. The Next value of each crontab
+// is when it will next start a job.
+type ScheduleSummary struct {
 	Schedule Schedule  `json:"schedule"`
 	Crontabs []Crontab `json:"crontabs"`
 }
@@ -1,20 +1,53 @@
 package data
 
 import (
+	"errors"
 	"time"
 )
 
-// ScheduleCreator schedules a job to repeat.
-type ScheduleCreator func(from time.Time, arn string, payload string, crontabs []string, externalID, by string) (scheduleID int64, err error)
+// ScheduleCreator schedules a job to repeat. The crontabs are evaluated in the IANA timezone, or UTC if it's empty.
+// The schedule is deactivated after the until time, or after maxRuns jobs have been started. A zero until or maxRuns
+// means that there's no limit. The misfirePolicy controls what happens to missed runs.
+type ScheduleCreator func(from time.Time, arn string, payload string, httpRequest *HTTPRequest, retryPolicy *RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (scheduleID int64, err error)
+
+// ScheduleUpdater replaces the fields and crontabs of an active schedule in a transaction. Crontabs which are unchanged keep
+// their Next value, while new crontabs, and all crontabs if the timezone has changed, are next due at their first run after the
+// update. If the schedule doesn't exist or isn't active, ok is false. If a worker holds a lease on a crontab which would be
+// removed or recalculated, the schedule isn't changed and ErrCrontabLeased is returned.
+type ScheduleUpdater func(scheduleID int64, arn string, payload string, httpRequest *HTTPRequest, retryPolicy *RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID, by string) (ok bool, err error)
+
+// ErrCrontabLeased is returned by a ScheduleUpdater when a worker is processing a crontab which the update would change.
+var ErrCrontabLeased = errors.New("a crontab of the schedule is leased by a worker")
 
 // ScheduleDeactivator stops a schedule from functioning and deletes scheduled tasks belonging to it.
 type ScheduleDeactivator func(scheduleID int64) (ok bool, err error)
 
+// SchedulePauser pauses an active schedule, so that it doesn't start jobs until it's resumed. If the schedule
+// doesn't exist, isn't active or is already paused, ok is false.
+type SchedulePauser func(scheduleID int64, by string) (ok bool, err error)
+
+// ScheduleResumer resumes a paused schedule. The Next value of each crontab is recalculated from the time
+// the schedule is resumed, so runs missed while it was paused are skipped. If catchUp is set and any runs were
+// missed, one crontab is made due immediately, so that a single job is started to catch up. If the schedule
+// isn't paused, ok is false.
+type ScheduleResumer func(scheduleID int64, by string, catchUp bool) (ok bool, err error)
+
 // ScheduleByIDGetter gets the schedule specified in the ID.
 type ScheduleByIDGetter func(scheduleID int64) (sc ScheduleCrontabs, ok bool, err error)
 
+// SchedulesLister lists the schedules which match the filter in ID order, starting after the afterID, returning at
+// most limit items.
+type SchedulesLister func(filter ScheduleFilter, afterID int64, limit int) (scs []ScheduleSummary, err error)
+
 // ScheduleGetter gets a schedule and the next crontab which is due to start, in order to schedule jobs.
 type ScheduleGetter func(lockedBy string, lockExpiryMinutes int) (sc ScheduleCrontab, ok bool, err error)
 
 // ScheduledJobStarter starts a new job and updates a Crontab record in a transaction so that it's not included in future updates.
+// It also increments the schedule's run count, and doesn't start a job if the schedule has reached its maximum number of runs.
 type ScheduledJobStarter func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error)
+
+// CrontabSkipper updates a Crontab record to its newNext value without starting a job, and releases its lease.
+type CrontabSkipper func(crontabID, crontabLeaseID int64, newNext time.Time) (err error)
+
+// CrontabLeasesPurger deletes up to limit crontab leases which expired before the cutoff, returning the number deleted.
+type CrontabLeasesPurger func(expiredBefore time.Time, limit int) (purged int, err error)
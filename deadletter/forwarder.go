@@ -0,0 +1,66 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/executor"
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/metrics"
+)
+
+const pkg = "github.com/welldigital/callme/deadletter"
+
+// Message is the payload sent to the dead letter ARN when a job is marked as dead.
+type Message struct {
+	DeadLetterID int64    `json:"deadLetterId"`
+	Job          data.Job `json:"job"`
+	Response     string   `json:"response"`
+	Error        string   `json:"error"`
+}
+
+// NewForwarder wraps a JobDeadLetterer so that each dead letter is also sent to the ARN (an SNS topic or webhook)
+// using the executor, and the outcome is recorded. Failing to forward a dead letter doesn't cause an error, since the
+// dead letter has already been stored and can be listed through the API. Duplicates aren't forwarded, since they
+// weren't stored as dead letters.
+func NewForwarder(deadLetterer data.JobDeadLetterer, arn string, e executor.Executor, recorder data.DeadLetterForwardRecorder) data.JobDeadLetterer {
+	return func(j data.Job, jobLeaseID int64, resp string, jobErr error) (deadLetterID int64, duplicate bool, err error) {
+		deadLetterID, duplicate, err = deadLetterer(j, jobLeaseID, resp, jobErr)
+		if err != nil || duplicate {
+			return
+		}
+		forwardErr := forward(deadLetterID, j, resp, jobErr, arn, e)
+		if forwardErr != nil {
+			logger.WithJob(pkg, "NewForwarder", j).WithField("deadLetterID", deadLetterID).WithField("arn", arn).WithError(forwardErr).Error("failed to forward dead letter")
+			metrics.DeadLetterForwardedCounts.WithLabelValues("error").Inc()
+		} else {
+			logger.WithJob(pkg, "NewForwarder", j).WithField("deadLetterID", deadLetterID).WithField("arn", arn).Info("forwarded dead letter")
+			metrics.DeadLetterForwardedCounts.WithLabelValues("success").Inc()
+		}
+		if re := recorder(deadLetterID, arn, forwardErr); re != nil {
+			logger.WithJob(pkg, "NewForwarder", j).WithField("deadLetterID", deadLetterID).WithError(re).Warn("failed to record dead letter forward")
+		}
+		return
+	}
+}
+
+func forward(deadLetterID int64, j data.Job, resp string, jobErr error, arn string, e executor.Executor) error {
+	m := Message{
+		DeadLetterID: deadLetterID,
+		Job:          j,
+		Response:     resp,
+	}
+	if jobErr != nil {
+		m.Error = jobErr.Error()
+	}
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = e(context.Background(), data.Job{
+		ARN:     arn,
+		Payload: string(payload),
+	})
+	return err
+}
@@ -0,0 +1,123 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/welldigital/callme/data"
+)
+
+func TestThatDeadLettersAreForwarded(t *testing.T) {
+	deadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		return 5, false, nil
+	}
+	var forwarded data.Job
+	e := func(ctx context.Context, j data.Job) (string, error) {
+		forwarded = j
+		return "", nil
+	}
+	var recordedID int64
+	var recordedARN string
+	var recordedErr error
+	recorder := func(deadLetterID int64, forwardedTo string, forwardErr error) error {
+		recordedID, recordedARN, recordedErr = deadLetterID, forwardedTo, forwardErr
+		return nil
+	}
+
+	f := NewForwarder(deadLetterer, "https://example.com/dead", e, recorder)
+	id, _, err := f(data.Job{JobID: 1, ARN: "https://example.com", Payload: "payload"}, 1, "response", errors.New("failed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 5 {
+		t.Errorf("expected dead letter ID 5, got %v", id)
+	}
+	if forwarded.ARN != "https://example.com/dead" {
+		t.Errorf("expected dead letter to be forwarded to the dead letter ARN, got '%v'", forwarded.ARN)
+	}
+	var m Message
+	if err := json.Unmarshal([]byte(forwarded.Payload), &m); err != nil {
+		t.Fatalf("failed to unmarshal forwarded payload: %v", err)
+	}
+	if m.DeadLetterID != 5 || m.Job.JobID != 1 || m.Job.Payload != "payload" || m.Response != "response" || m.Error != "failed" {
+		t.Errorf("unexpected forwarded message: %+v", m)
+	}
+	if recordedID != 5 || recordedARN != "https://example.com/dead" || recordedErr != nil {
+		t.Errorf("expected the forward to be recorded, got id %v, arn '%v', err '%v'", recordedID, recordedARN, recordedErr)
+	}
+}
+
+func TestThatForwardingErrorsAreRecordedButNotReturned(t *testing.T) {
+	deadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		return 5, false, nil
+	}
+	e := func(ctx context.Context, j data.Job) (string, error) {
+		return "", errors.New("forward failed")
+	}
+	var recordedErr error
+	recorder := func(deadLetterID int64, forwardedTo string, forwardErr error) error {
+		recordedErr = forwardErr
+		return errors.New("record failed")
+	}
+
+	f := NewForwarder(deadLetterer, "https://example.com/dead", e, recorder)
+	_, _, err := f(data.Job{JobID: 1}, 1, "", errors.New("failed"))
+	if err != nil {
+		t.Errorf("expected forwarding errors not to be returned, got: %v", err)
+	}
+	if recordedErr == nil || recordedErr.Error() != "forward failed" {
+		t.Errorf("expected the forwarding error to be recorded, got '%v'", recordedErr)
+	}
+}
+
+func TestThatDeadLetterErrorsAreNotForwarded(t *testing.T) {
+	deadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		return 0, false, errors.New("database error")
+	}
+	forwarded := false
+	e := func(ctx context.Context, j data.Job) (string, error) {
+		forwarded = true
+		return "", nil
+	}
+	recorder := func(deadLetterID int64, forwardedTo string, forwardErr error) error {
+		return nil
+	}
+
+	f := NewForwarder(deadLetterer, "https://example.com/dead", e, recorder)
+	_, _, err := f(data.Job{JobID: 1}, 1, "", errors.New("failed"))
+	if err == nil || err.Error() != "database error" {
+		t.Errorf("expected the dead letter error to be returned, got '%v'", err)
+	}
+	if forwarded {
+		t.Errorf("expected the dead letter not to be forwarded, because it wasn't stored")
+	}
+}
+
+func TestThatDuplicatesAreNotForwarded(t *testing.T) {
+	deadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		return 0, true, nil
+	}
+	forwarded := false
+	e := func(ctx context.Context, j data.Job) (string, error) {
+		forwarded = true
+		return "", nil
+	}
+	recorder := func(deadLetterID int64, forwardedTo string, forwardErr error) error {
+		t.Errorf("expected no forward to be recorded for a duplicate")
+		return nil
+	}
+
+	f := NewForwarder(deadLetterer, "https://example.com/dead", e, recorder)
+	_, duplicate, err := f(data.Job{JobID: 1}, 1, "", errors.New("failed"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !duplicate {
+		t.Errorf("expected the duplicate to be returned")
+	}
+	if forwarded {
+		t.Errorf("expected the duplicate not to be forwarded, because it wasn't stored as a dead letter")
+	}
+}
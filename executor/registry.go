@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/welldigital/callme/data"
+)
+
+// SchemeSNS is the scheme of AWS SNS topic ARNs, e.g. arn:aws:sns:eu-west-2:123456789012:topic
+const SchemeSNS = "sns"
+
+// SchemeHTTP is the scheme of HTTP webhook addresses, e.g. http://example.com/hook
+const SchemeHTTP = "http"
+
+// SchemeHTTPS is the scheme of HTTPS webhook addresses, e.g. https://example.com/hook
+const SchemeHTTPS = "https"
+
+// DefaultSchemes are the schemes supported by the built-in sns and web executors.
+var DefaultSchemes = []string{SchemeSNS, SchemeHTTP, SchemeHTTPS}
+
+// An Executor executes work, stopping if the context is cancelled.
+type Executor func(ctx context.Context, j data.Job) (resp string, err error)
+
+// A Validator checks that an ARN can be executed, returning an error if it can't.
+type Validator func(arn string) error
+
+// Registry dispatches work to an Executor based on the scheme of the ARN.
+type Registry struct {
+	executors map[string]Executor
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		executors: make(map[string]Executor),
+	}
+}
+
+// Register adds an Executor to handle ARNs of the given scheme, replacing any existing Executor.
+func (r *Registry) Register(scheme string, e Executor) {
+	r.executors[strings.ToLower(scheme)] = e
+}
+
+// Schemes returns the schemes which have an Executor registered.
+func (r *Registry) Schemes() []string {
+	schemes := make([]string, 0, len(r.executors))
+	for s := range r.executors {
+		schemes = append(schemes, s)
+	}
+	return schemes
+}
+
+// Validate returns an error if no Executor is registered for the scheme of the ARN.
+func (r *Registry) Validate(arn string) error {
+	_, err := r.find(arn)
+	return err
+}
+
+// Execute executes the job using the Executor registered for the scheme of its ARN.
+func (r *Registry) Execute(ctx context.Context, j data.Job) (resp string, err error) {
+	e, err := r.find(j.ARN)
+	if err != nil {
+		return "", err
+	}
+	return e(ctx, j)
+}
+
+func (r *Registry) find(arn string) (Executor, error) {
+	scheme, err := Scheme(arn)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := r.executors[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ARN scheme '%v'", scheme)
+	}
+	return e, nil
+}
+
+// SchemeValidator creates a Validator which accepts ARNs using any of the given schemes.
+func SchemeValidator(schemes ...string) Validator {
+	r := NewRegistry()
+	for _, s := range schemes {
+		r.Register(s, nil)
+	}
+	return r.Validate
+}
+
+// Scheme returns the scheme of an ARN. AWS ARNs (arn:partition:service:...) use the
+// service as the scheme, e.g. "sns", while URLs use the URL scheme, e.g. "https".
+func Scheme(arn string) (string, error) {
+	if strings.HasPrefix(arn, "arn:") {
+		parts := strings.SplitN(arn, ":", 4)
+		if len(parts) < 4 || parts[2] == "" {
+			return "", errors.New("ARN must be in the format arn:partition:service:resource")
+		}
+		return strings.ToLower(parts[2]), nil
+	}
+	u, err := url.Parse(arn)
+	if err != nil || u.Scheme == "" {
+		return "", errors.New("ARN must be an AWS ARN or an absolute URL")
+	}
+	return strings.ToLower(u.Scheme), nil
+}
@@ -0,0 +1,96 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/welldigital/callme/data"
+)
+
+func TestScheme(t *testing.T) {
+	tests := []struct {
+		arn            string
+		expectedScheme string
+		expectedErr    bool
+	}{
+		{arn: "arn:aws:sns:eu-west-2:123456789012:topic", expectedScheme: "sns"},
+		{arn: "arn:aws:lambda:eu-west-2:123456789012:function:name", expectedScheme: "lambda"},
+		{arn: "http://example.com/hook", expectedScheme: "http"},
+		{arn: "HTTPS://example.com/hook", expectedScheme: "https"},
+		{arn: "arn:aws", expectedErr: true},
+		{arn: "arn:aws::resource", expectedErr: true},
+		{arn: "example.com", expectedErr: true},
+		{arn: "", expectedErr: true},
+	}
+
+	for _, test := range tests {
+		actual, err := Scheme(test.arn)
+		if test.expectedErr && err == nil {
+			t.Errorf("%s: expected error, but got scheme '%v'", test.arn, actual)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.arn, err)
+		}
+		if actual != test.expectedScheme {
+			t.Errorf("%s: expected scheme '%v', got '%v'", test.arn, test.expectedScheme, actual)
+		}
+	}
+}
+
+func TestThatRegistryDispatchesByScheme(t *testing.T) {
+	var executedBy string
+	r := NewRegistry()
+	r.Register(SchemeSNS, func(ctx context.Context, j data.Job) (string, error) {
+		executedBy = "sns"
+		return "sns_ok", nil
+	})
+	r.Register(SchemeHTTPS, func(ctx context.Context, j data.Job) (string, error) {
+		executedBy = "https"
+		return "https_ok", nil
+	})
+
+	resp, err := r.Execute(context.Background(), data.Job{ARN: "arn:aws:sns:eu-west-2:123456789012:topic", Payload: "payload"})
+	if err != nil || resp != "sns_ok" || executedBy != "sns" {
+		t.Errorf("sns: expected the sns executor to be used, got resp '%v', err '%v', executed by '%v'", resp, err, executedBy)
+	}
+
+	resp, err = r.Execute(context.Background(), data.Job{ARN: "https://example.com", Payload: "payload"})
+	if err != nil || resp != "https_ok" || executedBy != "https" {
+		t.Errorf("https: expected the https executor to be used, got resp '%v', err '%v', executed by '%v'", resp, err, executedBy)
+	}
+
+	executedBy = ""
+	_, err = r.Execute(context.Background(), data.Job{ARN: "http://example.com", Payload: "payload"})
+	if err == nil || err.Error() != "unsupported ARN scheme 'http'" {
+		t.Errorf("http: expected unsupported scheme error, got '%v'", err)
+	}
+	if executedBy != "" {
+		t.Errorf("http: expected no executor to be used, but '%v' was", executedBy)
+	}
+}
+
+func TestThatExecutorErrorsAreReturned(t *testing.T) {
+	r := NewRegistry()
+	r.Register(SchemeHTTP, func(ctx context.Context, j data.Job) (string, error) {
+		return "", errors.New("failed")
+	})
+	_, err := r.Execute(context.Background(), data.Job{ARN: "http://example.com", Payload: "payload"})
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("expected executor error to be returned, got '%v'", err)
+	}
+}
+
+func TestSchemeValidator(t *testing.T) {
+	v := SchemeValidator(DefaultSchemes...)
+	for _, arn := range []string{"arn:aws:sns:eu-west-2:123456789012:topic", "http://example.com", "https://example.com"} {
+		if err := v(arn); err != nil {
+			t.Errorf("%s: expected to be valid, got error: %v", arn, err)
+		}
+	}
+	for _, arn := range []string{"ftp://example.com", "arn:aws:sqs:eu-west-2:123456789012:queue", "example.com"} {
+		if err := v(arn); err == nil {
+			t.Errorf("%s: expected to be invalid, but was valid", arn)
+		}
+	}
+}
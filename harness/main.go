@@ -1,17 +1,21 @@
 package main
 
 import (
-	"fmt"
+	"flag"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/welldigital/callme/data"
 	"github.com/welldigital/callme/mysql"
+	"github.com/welldigital/callme/sqlite"
+	"github.com/welldigital/callme/storage"
 
 	"github.com/welldigital/callme/logger"
 
@@ -21,9 +25,44 @@ import (
 const pkg = "github.com/welldigital/callme/harness"
 
 func main() {
+	backend := flag.String("backend", "mysql", "The database to test against, mysql or sqlite.")
+	batchSizes := flag.String("batch-sizes", "1,20", "Comma separated job batch sizes (CALLME_JOB_BATCH_SIZE) to compare.")
+	flag.Parse()
+
 	sigs := make(chan os.Signal)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
+	var results []result
+	for _, bs := range strings.Split(*batchSizes, ",") {
+		batchSize, err := strconv.Atoi(strings.TrimSpace(bs))
+		if err != nil {
+			logger.For(pkg, "main").WithError(err).Errorf("invalid batch size '%v'", bs)
+			return
+		}
+		r, ok := run(*backend, batchSize, sigs)
+		if !ok {
+			return
+		}
+		results = append(results, r)
+	}
+
+	// Write out a summary
+	for _, r := range results {
+		logger.For(pkg, "main").Infof("batch size %v: created %v jobs in %f seconds, web server received %v messages in %f seconds (%.1f per second)\n",
+			r.batchSize, r.created, r.createDuration.Seconds(), r.received, r.runDuration.Seconds(), float64(r.received)/r.runDuration.Seconds())
+	}
+}
+
+type result struct {
+	batchSize      int
+	created        int
+	createDuration time.Duration
+	received       int
+	runDuration    time.Duration
+}
+
+// run drains a new test database of jobs with a worker, and returns false if it failed or was stopped.
+func run(backend string, batchSize int, sigs chan os.Signal) (r result, ok bool) {
 	// Start up a bunch of jobs in the test DB
 	jobsToCreate := 1000
 	// Create a schedule which creates a job each minute
@@ -34,36 +73,55 @@ func main() {
 	scheduleWorkerCount := 2
 	jobWorkerCount := 2
 
+	r = result{batchSize: batchSize, created: jobsToCreate}
+
 	// Create test database to work against
-	dsn, dbName, err := mysql.CreateTestDatabase()
+	var connectionString, dbName string
+	var err error
+	var dropTestDatabase func(string) error
+	switch backend {
+	case "sqlite":
+		connectionString, dbName, err = sqlite.CreateTestDatabase()
+		// The worker is given a file path rather than a DSN.
+		dropTestDatabase, connectionString = sqlite.DropTestDatabase, "sqlite://"+dbName
+	default:
+		connectionString, dbName, err = mysql.CreateTestDatabase()
+		dropTestDatabase = mysql.DropTestDatabase
+	}
 	if err != nil {
 		logger.For(pkg, "main").WithError(err).Error("failed to create test databse")
 		return
 	}
-	defer mysql.DropTestDatabase(dbName)
+	defer dropTestDatabase(dbName)
+	store, err := storage.Open(connectionString, storage.PoolOptions{})
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Error("failed to open test database")
+		return
+	}
+	defer store.Close()
 
 	arn := "http://localhost:8080"
 	payload := `{ "test": true }`
 
 	taskStart := time.Now().UTC()
 	logger.For(pkg, "main").Infof("creating %v jobs", jobsToCreate)
-	jm := mysql.NewJobManager(dsn)
-	for i := 0; i < jobsToCreate; i++ {
-		j, err := jm.StartJob(time.Now().UTC(), arn, payload, nil)
-		if err != nil {
-			logger.For(pkg, "main").WithError(err).Errorf("failed to create test job i=%v, with error: %v", i, err)
-			return
-		}
-		logger.WithJob(pkg, "main", j).Info("created")
+	jobs := make([]data.Job, jobsToCreate)
+	for i := range jobs {
+		jobs[i] = data.Job{When: time.Now().UTC(), ARN: arn, Payload: payload}
 	}
-	taskDuration := time.Now().UTC().Sub(taskStart)
+	started, err := store.JobsStarter(jobs, 0)
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Errorf("failed to create test jobs, with error: %v", err)
+		return
+	}
+	logger.For(pkg, "main").Infof("created %v jobs", len(started))
+	r.createDuration = time.Now().UTC().Sub(taskStart)
 
 	// Start a scheduled job.
 	logger.For(pkg, "main").Infof("creating %v schedules", schedulesToCreate)
-	sm := mysql.NewScheduleManager(dsn)
 	for i := 0; i < schedulesToCreate; i++ {
 		// Run every minute.
-		id, err := sm.Create(time.Now().UTC(), arn, payload, []string{"* * * * *"}, "externalid", "harness")
+		id, err := store.ScheduleCreator(time.Now().UTC(), arn, payload, nil, nil, []string{"* * * * *"}, "", time.Time{}, 0, "", "externalid", "harness")
 		logger.For(pkg, "main").Infof("created schedule %v", id)
 		if err != nil {
 			logger.For(pkg, "main").WithError(err).Error("failed to create schedule")
@@ -73,19 +131,19 @@ func main() {
 	// Start a web server to count the work.
 	handler := NewCountHandler(quitAfter)
 
-	r := http.NewServeMux()
-	r.Handle("/", handler)
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
 
 	// Register pprof handlers
-	r.HandleFunc("/debug/pprof/", pprof.Index)
-	r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-	r.HandleFunc("/debug/pprof/profile", pprof.Profile)
-	r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-	r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 
-	s = &http.Server{
+	s := &http.Server{
 		Addr:           ":8080",
-		Handler:        r,
+		Handler:        mux,
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
@@ -93,26 +151,26 @@ func main() {
 
 	// Start serving
 	go func() {
-		logger.For(pkg, "main").Fatal(s.ListenAndServe())
+		if err := s.ListenAndServe(); err != http.ErrServerClosed {
+			logger.For(pkg, "main").Fatal(err)
+		}
 	}()
 
 	// Start the work processing
 	// Start up the server
 	cmd := exec.Command("../worker/worker")
-	cmd.Env = append(cmd.Env, "CALLME_CONNECTION_STRING="+dsn)
-	cmd.Env = append(cmd.Env, "CALLME_MODE=web")
+	cmd.Env = append(cmd.Env, "CALLME_CONNECTION_STRING="+connectionString)
 	cmd.Env = append(cmd.Env, "CALLME_SCHEDULE_WORKER_COUNT="+strconv.Itoa(scheduleWorkerCount))
 	cmd.Env = append(cmd.Env, "CALLME_JOB_WORKER_COUNT="+strconv.Itoa(jobWorkerCount))
+	cmd.Env = append(cmd.Env, "CALLME_JOB_BATCH_SIZE="+strconv.Itoa(batchSize))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	runStart := time.Now().UTC()
-	go func() {
-		if err := cmd.Run(); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			return
-		}
-	}()
+	if err := cmd.Start(); err != nil {
+		logger.For(pkg, "main").WithError(err).Error("failed to start worker")
+		return
+	}
 
 	// Wait for completion
 	select {
@@ -121,9 +179,11 @@ func main() {
 		break
 	case <-handler.Completed:
 		logger.For(pkg, "main").Info("test complete")
+		ok = true
 		break
 	}
-	runDuration := time.Now().UTC().Sub(runStart)
+	r.runDuration = time.Now().UTC().Sub(runStart)
+	r.received = handler.Received
 
 	logger.For(pkg, "main").Info("shutting down web server")
 	if err := s.Close(); err != nil {
@@ -133,14 +193,10 @@ func main() {
 	if err := cmd.Process.Kill(); err != nil {
 		logger.For(pkg, "main").WithError(err).Error("failed to kill process")
 	}
-
-	// Write out a summary
-	logger.For(pkg, "main").Infof("created %v jobs in %f seconds\n", jobsToCreate, taskDuration.Seconds())
-	logger.For(pkg, "main").Infof("web server received %v messages in %f seconds\n", handler.Received, runDuration.Seconds())
+	cmd.Wait()
+	return
 }
 
-var s *http.Server
-
 // NewCountHandler creates a HTTP handler which counts incoming requests.
 func NewCountHandler(expected int) *CountHandler {
 	ch := &CountHandler{
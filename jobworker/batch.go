@@ -0,0 +1,187 @@
+package jobworker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/metrics"
+	"github.com/welldigital/callme/repetitive"
+
+	"github.com/cenkalti/backoff"
+)
+
+// NewBatchJobWorker creates a worker for the repetitive.Work function which leases up to batchSize pending jobs at a
+// time and executes them concurrently. Successful jobs are completed together once the whole batch has executed,
+// while failed jobs are retried or marked as dead individually, as they are by the worker created by NewJobWorker. The
+// lease on each job is renewed until it has been completed, and the job is abandoned if its lease is lost.
+func NewBatchJobWorker(workerName string,
+	lockExpiryMinutes int,
+	batchSize int,
+	jobsGetter data.JobsGetter,
+	jobLeaseRenewer data.JobLeaseRenewer,
+	e Executor,
+	jobsCompleter data.JobsCompleter,
+	jobRetrier data.JobRetrier,
+	jobDeadLetterer data.JobDeadLetterer) repetitive.Worker {
+	return func() (workDone bool, err error) {
+		return findAndExecuteBatch(workerName, lockExpiryMinutes, batchSize, jobsGetter, jobLeaseRenewer, renewalInterval(lockExpiryMinutes), e, jobsCompleter, jobRetrier, jobDeadLetterer, defaultTimeout)
+	}
+}
+
+// batchResult is the outcome of executing a job in a batch.
+type batchResult struct {
+	lease           *leaseKeeper
+	resp            string
+	executionError  error
+	leaseError      error
+	completionError error
+}
+
+func findAndExecuteBatch(workerName string,
+	lockExpiryMinutes int,
+	batchSize int,
+	jobsGetter data.JobsGetter,
+	jobLeaseRenewer data.JobLeaseRenewer,
+	renewEvery time.Duration,
+	e Executor,
+	jobsCompleter data.JobsCompleter,
+	jobRetrier data.JobRetrier,
+	jobDeadLetterer data.JobDeadLetterer,
+	timeout time.Duration) (workDone bool, err error) {
+	// See if there's some work to do.
+	jobGetStart := time.Now()
+	jobs, err := jobsGetter(workerName, lockExpiryMinutes, batchSize)
+	jobGetDuration := time.Since(jobGetStart) / time.Millisecond
+	if err != nil {
+		logger.For(pkg, "findAndExecuteBatch").WithField("workerName", workerName).WithError(err).Error("failed to get jobs")
+		metrics.JobLeaseCounts.WithLabelValues("error").Inc()
+		metrics.JobLeaseDurations.WithLabelValues("error").Observe(float64(jobGetDuration))
+		return
+	}
+	if len(jobs) == 0 {
+		logger.For(pkg, "findAndExecuteBatch").WithField("workerName", workerName).Info("no job available")
+		metrics.JobLeaseCounts.WithLabelValues("none_available").Inc()
+		metrics.JobLeaseDurations.WithLabelValues("none_available").Observe(float64(jobGetDuration))
+		return
+	}
+	metrics.JobLeaseCounts.WithLabelValues("success").Inc()
+	metrics.JobLeaseDurations.WithLabelValues("success").Observe(float64(jobGetDuration))
+	metrics.JobLeaseBatchSizes.Observe(float64(len(jobs)))
+
+	// Execute the jobs concurrently. Failures are retried or marked as dead straight away, since they're
+	// expected to be rare, and can't be completed in the same way as the successful jobs.
+	results := make([]batchResult, len(jobs))
+	for i := range jobs {
+		results[i].lease = keepLease(workerName, jobs[i], lockExpiryMinutes, jobLeaseRenewer, renewEvery)
+	}
+	defer func() {
+		for _, r := range results {
+			r.lease.Stop()
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := range jobs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := &results[i]
+			var willRetry bool
+			r.resp, r.executionError, willRetry, r.leaseError = executeWithLease(workerName, jobs[i].Job, e, r.lease)
+			if r.leaseError == nil && r.executionError != nil {
+				complete := completionFor(workerName, jobs[i], r.resp, r.executionError, willRetry, nil, jobRetrier, jobDeadLetterer)
+				r.completionError = retryCompletion(workerName, jobs[i].Job, complete, r.lease.Lost(), timeout)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var completions []data.JobCompletion
+	var leases []*leaseKeeper
+	for i, r := range results {
+		if r.leaseError == nil && r.executionError == nil {
+			completions = append(completions, data.JobCompletion{JobID: jobs[i].Job.JobID, JobLeaseID: jobs[i].JobLeaseID, Response: r.resp})
+			leases = append(leases, r.lease)
+		}
+	}
+	var completed map[int64]bool
+	var completionError error
+	if len(completions) > 0 {
+		workDone = true
+		completed, completionError = retryBatchCompletion(workerName, completions, leases, jobsCompleter, timeout)
+	}
+
+	// Report the first error, along with how many of the jobs failed.
+	var failed int
+	for i, r := range results {
+		if r.leaseError == nil && r.executionError == nil {
+			r.completionError = completionError
+			// Jobs which were left out of the batch completion lost their leases.
+			if completionError == nil && !completed[jobs[i].Job.JobID] {
+				r.leaseError = errLeaseLost
+			}
+		}
+		jobErr := r.leaseError
+		if jobErr == nil {
+			jobErr = mergeErrors(workerName, r.executionError, r.completionError)
+		}
+		if jobErr != nil {
+			if err == nil {
+				err = jobErr
+			}
+			failed++
+		}
+	}
+	if failed > 1 {
+		err = fmt.Errorf("%v of %v jobs failed, including %v", failed, len(jobs), err)
+	}
+	return
+}
+
+// retryBatchCompletion completes the batch of successful jobs, until it succeeds or the timeout is reached. Jobs whose
+// leases are lost are left out of the batch, since they'll be executed again by the next worker to lease them. It
+// returns the IDs of the jobs which were completed, including any which the store recorded as duplicates because
+// their leases were no longer current.
+func retryBatchCompletion(workerName string, completions []data.JobCompletion, leases []*leaseKeeper, jobsCompleter data.JobsCompleter, timeout time.Duration) (completed map[int64]bool, err error) {
+	complete := func() error {
+		var held []data.JobCompletion
+		for i, c := range completions {
+			if !leases[i].IsLost() {
+				held = append(held, c)
+			}
+		}
+		if len(held) == 0 {
+			return nil
+		}
+		jobCompleteStart := time.Now()
+		duplicateJobIDs, jce := jobsCompleter(held)
+		jobCompleteDuration := time.Since(jobCompleteStart) / time.Millisecond
+		if jce == nil {
+			if len(duplicateJobIDs) > 0 {
+				logger.For(pkg, "retryBatchCompletion").WithField("workerName", workerName).WithField("jobIDs", duplicateJobIDs).Warn("leases were no longer current, recorded as duplicates")
+				metrics.JobCompletedCounts.WithLabelValues("duplicate").Add(float64(len(duplicateJobIDs)))
+			}
+			logger.For(pkg, "retryBatchCompletion").WithField("workerName", workerName).WithField("count", len(held)-len(duplicateJobIDs)).Info("marked as complete successfully")
+			metrics.JobCompletedCounts.WithLabelValues("success").Add(float64(len(held) - len(duplicateJobIDs)))
+			completed = make(map[int64]bool, len(held))
+			for _, c := range held {
+				completed[c.JobID] = true
+			}
+			metrics.JobCompletedDurations.WithLabelValues("success").Observe(float64(jobCompleteDuration))
+		} else {
+			logger.For(pkg, "retryBatchCompletion").WithField("workerName", workerName).WithField("count", len(held)).WithError(jce).Warn("marked as complete failed, but may retry")
+			metrics.JobCompletedCounts.WithLabelValues("error").Inc()
+			metrics.JobCompletedDurations.WithLabelValues("error").Observe(float64(jobCompleteDuration))
+		}
+		return jce
+	}
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = timeout
+	err = backoff.Retry(complete, bo)
+	if err != nil {
+		logger.For(pkg, "retryBatchCompletion").WithField("workerName", workerName).WithField("count", len(completions)).WithError(err).Error("job complete retries exceeded")
+	}
+	return
+}
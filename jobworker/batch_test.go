@@ -0,0 +1,206 @@
+package jobworker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+func batchOf(n int) []data.LeasedJob {
+	jobs := make([]data.LeasedJob, n)
+	for i := range jobs {
+		jobs[i] = data.LeasedJob{
+			Job: data.Job{
+				JobID:   int64(i + 1),
+				ARN:     "arn",
+				Payload: "payload",
+				When:    time.Now().UTC(),
+			},
+			JobLeaseID: int64(i + 101),
+		}
+	}
+	return jobs
+}
+
+func TestThatNoWorkIsDoneIfABatchIsEmpty(t *testing.T) {
+	actual := Values{}
+
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		actual.JobRetrieved = true
+		return nil, nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		actual.JobExecuted = true
+		return "", nil
+	}
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		actual.JobCompleted = true
+		return nil, nil
+	}
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
+	}
+
+	w := NewBatchJobWorker(nodeName, lockExpiryMins, 10, jobsGetter, nil, executor, jobsCompleter, jobRetrier, jobDeadLetterer)
+
+	var err error
+	actual.WorkDone, err = w()
+	actual.ErrorOccurred = err != nil
+
+	expected := Values{
+		JobRetrieved: true,
+	}
+	expected.Assert(t, actual)
+}
+
+func TestThatABatchIsExecutedConcurrentlyAndCompletedTogether(t *testing.T) {
+	const batchSize = 5
+	var requestedLimit int
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		requestedLimit = limit
+		return batchOf(batchSize), nil
+	}
+
+	// Each execution waits for all of the others to start, so the batch can only finish if it runs concurrently.
+	var started sync.WaitGroup
+	started.Add(batchSize)
+	allStarted := make(chan bool)
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		started.Done()
+		select {
+		case <-allStarted:
+			return "ok", nil
+		case <-time.After(time.Second * 5):
+			return "", errors.New("the jobs weren't executed concurrently")
+		}
+	}
+
+	var completed [][]data.JobCompletion
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		completed = append(completed, completions)
+		return nil, nil
+	}
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		t.Errorf("expected no jobs to be retried, but job %v was", jobID)
+		return false, nil
+	}
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		t.Errorf("expected no jobs to be dead lettered, but job %v was", j.JobID)
+		return 1, false, nil
+	}
+
+	w := NewBatchJobWorker(nodeName, lockExpiryMins, batchSize, jobsGetter, nil, executor, jobsCompleter, jobRetrier, jobDeadLetterer)
+	workDone, err := w()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !workDone {
+		t.Error("expected work to be done")
+	}
+	if requestedLimit != batchSize {
+		t.Errorf("expected to lease up to %v jobs, but leased up to %v", batchSize, requestedLimit)
+	}
+	if len(completed) != 1 || len(completed[0]) != batchSize {
+		t.Fatalf("expected a single batch of %v completions, got %v", batchSize, completed)
+	}
+	for i, c := range completed[0] {
+		if c.JobID != int64(i+1) || c.JobLeaseID != int64(i+101) || c.Response != "ok" || c.Err != nil {
+			t.Errorf("unexpected completion %+v", c)
+		}
+	}
+}
+
+func TestThatFailedJobsInABatchAreRetriedIndividually(t *testing.T) {
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		jobs := batchOf(3)
+		jobs[1].Job.RetryPolicy = &data.RetryPolicy{MaxAttempts: 2}
+		return jobs, nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		if j.JobID == 2 {
+			return "", errors.New("failed for no reason whatsoever")
+		}
+		return "ok", nil
+	}
+
+	var m sync.Mutex
+	var completed []data.JobCompletion
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		m.Lock()
+		defer m.Unlock()
+		completed = append(completed, completions...)
+		return nil, nil
+	}
+	var retried []int64
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		m.Lock()
+		defer m.Unlock()
+		retried = append(retried, jobID)
+		return false, nil
+	}
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		t.Errorf("expected no jobs to be dead lettered, but job %v was", j.JobID)
+		return 1, false, nil
+	}
+
+	workDone, err := findAndExecuteBatch(nodeName, lockExpiryMins, 3, jobsGetter, nil, 0, executor, jobsCompleter, jobRetrier, jobDeadLetterer, time.Second)
+	if err == nil {
+		t.Error("expected the failed execution to be returned as an error")
+	}
+	if !workDone {
+		t.Error("expected work to be done, because some of the jobs succeeded")
+	}
+	if len(completed) != 2 || completed[0].JobID != 1 || completed[1].JobID != 3 {
+		t.Errorf("expected jobs 1 and 3 to be completed, got %+v", completed)
+	}
+	if len(retried) != 1 || retried[0] != 2 {
+		t.Errorf("expected job 2 to be retried, got %v", retried)
+	}
+}
+
+func TestThatABatchCompletionIsRetried(t *testing.T) {
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		return batchOf(2), nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		return "ok", nil
+	}
+	calls := 0
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("database unavailable")
+		}
+		return nil, nil
+	}
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		return false, nil
+	}
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		return 1, false, nil
+	}
+
+	workDone, err := findAndExecuteBatch(nodeName, lockExpiryMins, 2, jobsGetter, nil, 0, executor, jobsCompleter, jobRetrier, jobDeadLetterer, time.Second*5)
+	if err != nil {
+		t.Errorf("expected the completion to succeed when it was retried, got %v", err)
+	}
+	if !workDone {
+		t.Error("expected work to be done")
+	}
+	if calls != 2 {
+		t.Errorf("expected the batch to be completed on the second call, but it was called %v times", calls)
+	}
+}
@@ -0,0 +1,141 @@
+package jobworker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/metrics"
+
+	"github.com/cenkalti/backoff"
+)
+
+// errLeaseLost is returned when a job's lease couldn't be renewed, so its execution was abandoned to the worker which
+// leases it next.
+var errLeaseLost = errors.New("lease lost")
+
+// renewalInterval is how often a job's lease is renewed while it's executing, which leaves enough time for a couple of
+// failed renewals to be retried before the lease expires.
+func renewalInterval(lockExpiryMinutes int) time.Duration {
+	return time.Duration(lockExpiryMinutes) * time.Minute / 3
+}
+
+// A leaseKeeper renews the lease on a job until it's stopped, so that a job which takes longer than the lock expiry
+// isn't leased and executed by another worker at the same time.
+type leaseKeeper struct {
+	lost     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// keepLease starts renewing the lease on the job every interval. If jobLeaseRenewer is nil, the lease isn't renewed.
+func keepLease(workerName string, lj data.LeasedJob, lockExpiryMinutes int, jobLeaseRenewer data.JobLeaseRenewer, interval time.Duration) *leaseKeeper {
+	k := &leaseKeeper{
+		lost: make(chan struct{}),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if jobLeaseRenewer == nil || interval <= 0 {
+		close(k.done)
+		return k
+	}
+	go k.renew(workerName, lj, lockExpiryMinutes, jobLeaseRenewer, interval)
+	return k
+}
+
+// Lost returns a channel which is closed if the lease couldn't be renewed.
+func (k *leaseKeeper) Lost() <-chan struct{} {
+	return k.lost
+}
+
+// IsLost returns whether the lease couldn't be renewed.
+func (k *leaseKeeper) IsLost() bool {
+	select {
+	case <-k.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stop stops renewing the lease, and waits for any renewal in progress to finish.
+func (k *leaseKeeper) Stop() {
+	k.stopOnce.Do(func() { close(k.stop) })
+	<-k.done
+}
+
+func (k *leaseKeeper) renew(workerName string, lj data.LeasedJob, lockExpiryMinutes int, jobLeaseRenewer data.JobLeaseRenewer, interval time.Duration) {
+	defer close(k.done)
+	job := lj.Job
+	expiry := time.Duration(lockExpiryMinutes) * time.Minute
+	until := time.Now().Add(expiry)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-ticker.C:
+		}
+		renewStart := time.Now()
+		ok, err := jobLeaseRenewer(job.JobID, lj.JobLeaseID, lockExpiryMinutes)
+		switch {
+		case err != nil:
+			metrics.JobLeaseRenewalCounts.WithLabelValues("error").Inc()
+			// Errors are retried at the next interval, as long as the lease will still be held.
+			if time.Now().Add(interval).Before(until) {
+				logger.WithJob(pkg, "renew", job).WithField("workerName", workerName).WithError(err).Warn("failed to renew lease, but will retry")
+				continue
+			}
+			logger.WithJob(pkg, "renew", job).WithField("workerName", workerName).WithError(err).Error("failed to renew lease before it expired, aborting")
+		case !ok:
+			metrics.JobLeaseRenewalCounts.WithLabelValues("lost").Inc()
+			logger.WithJob(pkg, "renew", job).WithField("workerName", workerName).Error("lease has expired or been taken over, aborting")
+		default:
+			metrics.JobLeaseRenewalCounts.WithLabelValues("success").Inc()
+			until = renewStart.Add(expiry)
+			continue
+		}
+		close(k.lost)
+		return
+	}
+}
+
+// executeWithLease executes the job while renewing its lease. If the lease is lost, the execution is cancelled and
+// errLeaseLost is returned, since the job will be executed again by the next worker to lease it.
+func executeWithLease(workerName string, job data.Job, e Executor, k *leaseKeeper) (resp string, executionError error, willRetry bool, leaseError error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-k.Lost():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	resp, executionError, willRetry = execute(ctx, workerName, job, e)
+	if ctx.Err() != nil {
+		logger.WithJob(pkg, "executeWithLease", job).WithField("workerName", workerName).Warn("cancelled execution after losing the lease")
+		return "", nil, false, errLeaseLost
+	}
+	return resp, executionError, willRetry, nil
+}
+
+// leaseBackOff stops retrying once the lease has been lost.
+type leaseBackOff struct {
+	backoff.BackOff
+	lost <-chan struct{}
+}
+
+func (b leaseBackOff) NextBackOff() time.Duration {
+	select {
+	case <-b.lost:
+		return backoff.Stop
+	default:
+		return b.BackOff.NextBackOff()
+	}
+}
@@ -0,0 +1,258 @@
+package jobworker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+func TestThatLeasesAreRenewedWhileJobsExecute(t *testing.T) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+		return data.LeasedJob{Job: data.Job{JobID: 1, ARN: "arn", Payload: "payload", When: time.Now().UTC()}, JobLeaseID: 1}, true, nil
+	}
+	var m sync.Mutex
+	renewals := 0
+	jobLeaseRenewer := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		m.Lock()
+		defer m.Unlock()
+		if jobID != 1 || jobLeaseID != 1 || lockExpiryMinutes != lockExpiryMins {
+			t.Errorf("unexpected renewal of job %v with lease %v for %v minutes", jobID, jobLeaseID, lockExpiryMinutes)
+		}
+		renewals++
+		return true, nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		time.Sleep(time.Millisecond * 100)
+		return "ok", nil
+	}
+	completed := false
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
+		completed = true
+		return false, nil
+	}
+
+	workDone, err := findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, jobLeaseRenewer, time.Millisecond*10, executor, jobCompleter, nil, nil, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !workDone || !completed {
+		t.Errorf("expected the job to be completed, got workDone=%v, completed=%v", workDone, completed)
+	}
+	m.Lock()
+	defer m.Unlock()
+	if renewals < 2 {
+		t.Errorf("expected the lease to be renewed while the job executed, but it was renewed %v times", renewals)
+	}
+}
+
+func TestThatExecutionIsCancelledWhenTheLeaseIsLost(t *testing.T) {
+	actual := Values{}
+
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+		actual.JobRetrieved = true
+		return data.LeasedJob{Job: data.Job{JobID: 1, ARN: "arn", Payload: "payload", When: time.Now().UTC()}, JobLeaseID: 1}, true, nil
+	}
+	jobLeaseRenewer := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		return false, nil
+	}
+	// The execution doesn't finish until its context is cancelled, so it can only return if it's cancelled.
+	executed := make(chan bool, 1)
+	cancelled := false
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		executed <- true
+		select {
+		case <-ctx.Done():
+			cancelled = true
+			return "", ctx.Err()
+		case <-time.After(time.Second * 5):
+			return "ok", nil
+		}
+	}
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
+		actual.JobCompleted = true
+		return false, nil
+	}
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
+	}
+
+	var err error
+	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, jobLeaseRenewer, time.Millisecond*10, executor, jobCompleter, jobRetrier, jobDeadLetterer, time.Second)
+	actual.ErrorOccurred = err != nil
+	select {
+	case actual.JobExecuted = <-executed:
+	case <-time.After(time.Second):
+	}
+
+	expected := Values{
+		ErrorOccurred: true,
+		JobRetrieved:  true,
+		JobExecuted:   true,
+	}
+	expected.Assert(t, actual)
+	if err != errLeaseLost {
+		t.Errorf("expected the lease lost error, got %v", err)
+	}
+	if !cancelled {
+		t.Error("expected the execution to be cancelled")
+	}
+}
+
+func TestThatCompletionRetriesStopWhenTheLeaseIsLost(t *testing.T) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+		return data.LeasedJob{Job: data.Job{JobID: 1, ARN: "arn", Payload: "payload", When: time.Now().UTC()}, JobLeaseID: 1}, true, nil
+	}
+	lost := make(chan bool)
+	jobLeaseRenewer := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		select {
+		case <-lost:
+			return false, nil
+		default:
+			return true, nil
+		}
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		return "ok", nil
+	}
+	var once sync.Once
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
+		once.Do(func() { close(lost) })
+		return false, errors.New("failed for no reason whatsoever")
+	}
+
+	start := time.Now()
+	_, err := findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, jobLeaseRenewer, time.Millisecond*10, executor, jobCompleter, nil, nil, time.Minute)
+	if err == nil || err.Error() != "completion: lease lost" {
+		t.Errorf("expected the lease lost error, got %v", err)
+	}
+	if time.Since(start) > time.Second*10 {
+		t.Errorf("expected the completion retries to stop when the lease was lost, but they took %v", time.Since(start))
+	}
+}
+
+func TestThatRenewalErrorsAreRetriedWhileTheLeaseIsHeld(t *testing.T) {
+	calls := 0
+	failTwice := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		calls++
+		if calls <= 2 {
+			return false, errors.New("database unavailable")
+		}
+		return true, nil
+	}
+	k := keepLease(nodeName, data.LeasedJob{Job: data.Job{JobID: 1}, JobLeaseID: 1}, lockExpiryMins, failTwice, time.Millisecond*5)
+	time.Sleep(time.Millisecond * 50)
+	k.Stop()
+	if k.IsLost() {
+		t.Error("expected renewal errors to be retried while the lease is still held")
+	}
+	if calls < 3 {
+		t.Errorf("expected the renewal to be retried, but it was called %v times", calls)
+	}
+
+	// A lease which expires before the next renewal is lost as soon as a renewal fails.
+	alwaysFail := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		return false, errors.New("database unavailable")
+	}
+	k = keepLease(nodeName, data.LeasedJob{Job: data.Job{JobID: 1}, JobLeaseID: 1}, 0, alwaysFail, time.Millisecond*5)
+	defer k.Stop()
+	select {
+	case <-k.Lost():
+	case <-time.After(time.Second):
+		t.Error("expected the lease to be lost when it couldn't be renewed before it expired")
+	}
+}
+
+func TestThatABatchLeavesOutJobsWhoseLeasesAreLost(t *testing.T) {
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		return batchOf(3), nil
+	}
+	jobLeaseRenewer := func(jobID, jobLeaseID int64, lockExpiryMinutes int) (bool, error) {
+		return jobID != 2, nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		if j.JobID == 2 {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		time.Sleep(time.Millisecond * 50)
+		return "ok", nil
+	}
+	var completed []data.JobCompletion
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		completed = append(completed, completions...)
+		return nil, nil
+	}
+
+	workDone, err := findAndExecuteBatch(nodeName, lockExpiryMins, 3, jobsGetter, jobLeaseRenewer, time.Millisecond*10, executor, jobsCompleter, nil, nil, time.Second)
+	if err != errLeaseLost {
+		t.Errorf("expected the lease lost error, got %v", err)
+	}
+	if !workDone {
+		t.Error("expected work to be done")
+	}
+	if len(completed) != 2 || completed[0].JobID != 1 || completed[1].JobID != 3 {
+		t.Errorf("expected jobs 1 and 3 to be completed, got %+v", completed)
+	}
+}
+
+func TestThatDuplicateCompletionsAreNotRetried(t *testing.T) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+		return data.LeasedJob{Job: data.Job{JobID: 1, ARN: "arn", Payload: "payload", When: time.Now().UTC()}, JobLeaseID: 7}, true, nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		return "ok", nil
+	}
+	calls := 0
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
+		calls++
+		if jobLeaseID != 7 {
+			t.Errorf("expected the job to be completed with lease 7, got %v", jobLeaseID)
+		}
+		return true, nil
+	}
+
+	workDone, err := findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, nil, 0, executor, jobCompleter, nil, nil, time.Second)
+	if err != nil {
+		t.Errorf("expected a duplicate completion not to be an error, got %v", err)
+	}
+	if !workDone {
+		t.Error("expected work to be done")
+	}
+	if calls != 1 {
+		t.Errorf("expected a duplicate completion not to be retried, but it was called %v times", calls)
+	}
+}
+
+func TestThatDuplicatesInABatchAreNotErrors(t *testing.T) {
+	jobsGetter := func(lockedBy string, lockExpiryMinutes int, limit int) ([]data.LeasedJob, error) {
+		return batchOf(3), nil
+	}
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		return "ok", nil
+	}
+	calls := 0
+	jobsCompleter := func(completions []data.JobCompletion) ([]int64, error) {
+		calls++
+		return []int64{2}, nil
+	}
+
+	workDone, err := findAndExecuteBatch(nodeName, lockExpiryMins, 3, jobsGetter, nil, 0, executor, jobsCompleter, nil, nil, time.Second)
+	if err != nil {
+		t.Errorf("expected duplicate completions not to be errors, got %v", err)
+	}
+	if !workDone {
+		t.Error("expected work to be done")
+	}
+	if calls != 1 {
+		t.Errorf("expected the batch to be completed once, but it was called %v times", calls)
+	}
+}
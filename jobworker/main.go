@@ -1,12 +1,14 @@
 package jobworker
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/welldigital/callme/logger"
 	"github.com/welldigital/callme/metrics"
 	"github.com/welldigital/callme/repetitive"
+	"github.com/welldigital/callme/retry"
 
 	"github.com/welldigital/callme/data"
 
@@ -17,29 +19,37 @@ const leaseName = "job"
 const defaultTimeout = time.Minute * 5
 const pkg = "github.com/welldigital/callme/jobworker"
 
-// An Executor executes work.
-type Executor func(arn string, payload string) (resp string, err error)
+// An Executor executes work, stopping if the context is cancelled.
+type Executor func(ctx context.Context, j data.Job) (resp string, err error)
 
-// NewJobWorker creates a worker for the repetitive.Work function which processes pending jobs.
+// NewJobWorker creates a worker for the repetitive.Work function which processes pending jobs. The lease on each job
+// is renewed while it's being executed and completed, and the execution is cancelled if the lease is lost.
 func NewJobWorker(workerName string,
 	lockExpiryMinutes int,
 	jobGetter data.JobGetter,
+	jobLeaseRenewer data.JobLeaseRenewer,
 	e Executor,
-	jobCompleter data.JobCompleter) repetitive.Worker {
+	jobCompleter data.JobCompleter,
+	jobRetrier data.JobRetrier,
+	jobDeadLetterer data.JobDeadLetterer) repetitive.Worker {
 	return func() (workDone bool, err error) {
-		return findAndExecuteWork(workerName, lockExpiryMinutes, jobGetter, e, jobCompleter, defaultTimeout)
+		return findAndExecuteWork(workerName, lockExpiryMinutes, jobGetter, jobLeaseRenewer, renewalInterval(lockExpiryMinutes), e, jobCompleter, jobRetrier, jobDeadLetterer, defaultTimeout)
 	}
 }
 
 func findAndExecuteWork(workerName string,
 	lockExpiryMinutes int,
 	jobGetter data.JobGetter,
+	jobLeaseRenewer data.JobLeaseRenewer,
+	renewEvery time.Duration,
 	e Executor,
 	jobCompleter data.JobCompleter,
+	jobRetrier data.JobRetrier,
+	jobDeadLetterer data.JobDeadLetterer,
 	timeout time.Duration) (workDone bool, err error) {
 	// See if there's some work to do.
 	jobGetStart := time.Now()
-	job, ok, err := jobGetter(workerName, lockExpiryMinutes)
+	lj, ok, err := jobGetter(workerName, lockExpiryMinutes)
 	jobGetDuration := time.Since(jobGetStart) / time.Millisecond
 	if err != nil {
 		logger.For(pkg, "findAndExecuteWork").WithField("workerName", workerName).WithError(err).Error("failed to get job")
@@ -56,64 +66,151 @@ func findAndExecuteWork(workerName string,
 	metrics.JobLeaseCounts.WithLabelValues("success").Inc()
 	metrics.JobLeaseDurations.WithLabelValues("success").Observe(float64(jobGetDuration))
 
-	logger.For(pkg, "findAndExecuteWork").WithField("workerName", workerName).Info("executing")
-
-	// Attempt to execute the work.
-	var resp string
-	var ee error
-	execute := func() error {
-		jobDelay := time.Now().UTC().Sub(job.When)
-		jobExecuteStart := time.Now()
-		resp, ee = e(job.ARN, job.Payload)
-		jobExecuteDuration := time.Since(jobExecuteStart) / time.Millisecond
-		if ee == nil {
-			logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).Info("success")
-			metrics.JobExecutedCounts.WithLabelValues("success").Inc()
-			metrics.JobExecutedDurations.WithLabelValues("success").Observe(float64(jobExecuteDuration))
-			metrics.JobExecutedDelay.Observe(float64(jobDelay))
+	// Renew the lease until the job has been completed, in case it takes longer than the lock expiry.
+	job := lj.Job
+	lease := keepLease(workerName, lj, lockExpiryMinutes, jobLeaseRenewer, renewEvery)
+	defer lease.Stop()
+	resp, executionError, willRetry, leaseError := executeWithLease(workerName, job, e, lease)
+	if leaseError != nil {
+		err = leaseError
+		return
+	}
+	workDone = executionError == nil
+
+	// Attempt to complete the work, release it to be retried, or mark it as dead if there are no more attempts.
+	complete := completionFor(workerName, lj, resp, executionError, willRetry, jobCompleter, jobRetrier, jobDeadLetterer)
+	completionError := retryCompletion(workerName, job, complete, lease.Lost(), timeout)
+
+	err = mergeErrors(workerName, executionError, completionError)
+	return
+}
+
+// execute executes the job, and returns whether it should be retried if it failed.
+func execute(ctx context.Context, workerName string, job data.Job, e Executor) (resp string, executionError error, willRetry bool) {
+	logger.WithJob(pkg, "execute", job).WithField("workerName", workerName).Info("executing")
+
+	// Attempt to execute the work, failures are retried later according to the job's retry policy.
+	jobDelay := time.Now().UTC().Sub(job.When)
+	jobExecuteStart := time.Now()
+	resp, executionError = e(ctx, job)
+	jobExecuteDuration := time.Since(jobExecuteStart) / time.Millisecond
+	if ctx.Err() != nil {
+		// The execution was cancelled, so its outcome is left to the worker which leases the job next.
+		logger.WithJob(pkg, "execute", job).WithField("workerName", workerName).WithError(executionError).Warn("cancelled")
+		return
+	}
+	attempts := job.AttemptCount + 1
+	willRetry = executionError != nil && retry.ShouldRetry(job.RetryPolicy, attempts)
+	if executionError == nil {
+		logger.WithJob(pkg, "execute", job).WithField("workerName", workerName).Info("success")
+		metrics.JobExecutedCounts.WithLabelValues("success").Inc()
+		metrics.JobExecutedDurations.WithLabelValues("success").Observe(float64(jobExecuteDuration))
+		metrics.JobExecutedDelay.Observe(float64(jobDelay))
+	} else {
+		metrics.JobExecutedCounts.WithLabelValues("error").Inc()
+		metrics.JobExecutedDurations.WithLabelValues("error").Observe(float64(jobExecuteDuration))
+		if willRetry {
+			logger.WithJob(pkg, "execute", job).WithField("workerName", workerName).WithField("attempt", attempts).WithError(executionError).Warn("failure, will retry")
 		} else {
-			logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).WithError(ee).Warn("failure, but may retry")
-			metrics.JobExecutedCounts.WithLabelValues("error").Inc()
-			metrics.JobExecutedDurations.WithLabelValues("error").Observe(float64(jobExecuteDuration))
+			logger.WithJob(pkg, "execute", job).WithField("workerName", workerName).WithField("attempt", attempts).WithError(executionError).Error("retries exceeded")
 		}
-		return ee
 	}
+	return
+}
 
-	bo := backoff.NewExponentialBackOff()
-	bo.MaxElapsedTime = timeout
-	executionError := backoff.Retry(execute, bo)
+// completionFor returns a function which completes the work, releases it to be retried, or marks it as dead if there
+// are no more attempts. If the job's lease is no longer current, the outcome is recorded as a duplicate by the store,
+// which isn't an error, since the job has been, or will be, completed by the worker which holds the current lease.
+func completionFor(workerName string,
+	lj data.LeasedJob,
+	resp string,
+	executionError error,
+	willRetry bool,
+	jobCompleter data.JobCompleter,
+	jobRetrier data.JobRetrier,
+	jobDeadLetterer data.JobDeadLetterer) func() error {
+	job := lj.Job
+	if willRetry {
+		retryAt := time.Now().UTC().Add(retry.Interval(job.RetryPolicy, job.AttemptCount+1))
+		return func() error {
+			jobCompleteStart := time.Now()
+			duplicate, jre := jobRetrier(job.JobID, lj.JobLeaseID, resp, executionError, retryAt)
+			jobCompleteDuration := time.Since(jobCompleteStart) / time.Millisecond
+			if jre == nil && duplicate {
+				recordDuplicate(workerName, lj, jobCompleteDuration)
+			} else if jre == nil {
+				logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).WithField("retryAt", retryAt).Info("rescheduled successfully")
+				metrics.JobCompletedCounts.WithLabelValues("retry").Inc()
+				metrics.JobCompletedDurations.WithLabelValues("retry").Observe(float64(jobCompleteDuration))
+			} else {
+				logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).WithError(jre).Warn("reschedule failed, but may retry")
+				metrics.JobCompletedCounts.WithLabelValues("error").Inc()
+				metrics.JobCompletedDurations.WithLabelValues("error").Observe(float64(jobCompleteDuration))
+			}
+			return jre
+		}
+	}
 	if executionError != nil {
-		logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).WithError(executionError).Error("retries exceeded")
-	} else {
-		workDone = true
+		return func() error {
+			jobCompleteStart := time.Now()
+			deadLetterID, duplicate, jde := jobDeadLetterer(job, lj.JobLeaseID, resp, executionError)
+			jobCompleteDuration := time.Since(jobCompleteStart) / time.Millisecond
+			if jde == nil && duplicate {
+				recordDuplicate(workerName, lj, jobCompleteDuration)
+			} else if jde == nil {
+				logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).WithField("deadLetterID", deadLetterID).Info("marked as dead")
+				metrics.JobCompletedCounts.WithLabelValues("dead").Inc()
+				metrics.JobCompletedDurations.WithLabelValues("dead").Observe(float64(jobCompleteDuration))
+			} else {
+				logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).WithError(jde).Warn("marked as dead failed, but may retry")
+				metrics.JobCompletedCounts.WithLabelValues("error").Inc()
+				metrics.JobCompletedDurations.WithLabelValues("error").Observe(float64(jobCompleteDuration))
+			}
+			return jde
+		}
 	}
-
-	// Attempt to complete the work.
-	complete := func() error {
+	return func() error {
 		jobCompleteStart := time.Now()
-		jce := jobCompleter(job.JobID, resp, executionError)
+		duplicate, jce := jobCompleter(job.JobID, lj.JobLeaseID, resp, executionError)
 		jobCompleteDuration := time.Since(jobCompleteStart) / time.Millisecond
-		if jce == nil {
-			logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).Info("marked as complete successfully")
+		if jce == nil && duplicate {
+			recordDuplicate(workerName, lj, jobCompleteDuration)
+		} else if jce == nil {
+			logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).Info("marked as complete successfully")
 			metrics.JobCompletedCounts.WithLabelValues("success").Inc()
 			metrics.JobCompletedDurations.WithLabelValues("success").Observe(float64(jobCompleteDuration))
 		} else {
-			logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).WithError(jce).Warn("marked as complete failed, but may retry")
+			logger.WithJob(pkg, "completionFor", job).WithField("workerName", workerName).WithError(jce).Warn("marked as complete failed, but may retry")
 			metrics.JobCompletedCounts.WithLabelValues("error").Inc()
 			metrics.JobCompletedDurations.WithLabelValues("error").Observe(float64(jobCompleteDuration))
 		}
 		return jce
 	}
+}
 
-	bo = backoff.NewExponentialBackOff()
+// recordDuplicate logs and counts an outcome which was recorded as a duplicate, because the job's lease wasn't current.
+func recordDuplicate(workerName string, lj data.LeasedJob, jobCompleteDuration time.Duration) {
+	logger.WithJob(pkg, "completionFor", lj.Job).WithField("workerName", workerName).WithField("jobLeaseID", lj.JobLeaseID).Warn("lease was no longer current, recorded as a duplicate")
+	metrics.JobCompletedCounts.WithLabelValues("duplicate").Inc()
+	metrics.JobCompletedDurations.WithLabelValues("duplicate").Observe(float64(jobCompleteDuration))
+}
+
+// retryCompletion calls complete until it succeeds, the timeout is reached, or the lease is lost.
+func retryCompletion(workerName string, job data.Job, complete func() error, leaseLost <-chan struct{}, timeout time.Duration) error {
+	bo := backoff.NewExponentialBackOff()
 	bo.MaxElapsedTime = timeout
-	completionError := backoff.Retry(complete, bo)
-	if completionError != nil {
-		logger.WithJob(pkg, "findAndExecuteWork", job).WithField("workerName", workerName).WithError(completionError).Error("job complete retries exceeded")
+	completionError := backoff.Retry(complete, leaseBackOff{BackOff: bo, lost: leaseLost})
+	if completionError == nil {
+		return nil
+	}
+	select {
+	case <-leaseLost:
+		logger.WithJob(pkg, "retryCompletion", job).WithField("workerName", workerName).WithError(completionError).Error("job complete retries abandoned after losing the lease")
+		return errLeaseLost
+	default:
+		logger.WithJob(pkg, "retryCompletion", job).WithField("workerName", workerName).WithError(completionError).Error("job complete retries exceeded")
+		return completionError
 	}
-
-	err = mergeErrors(workerName, executionError, completionError)
-	return
 }
 
 func mergeErrors(workerName string, execution, completion error) error {
@@ -1,6 +1,7 @@
 package jobworker
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -14,23 +15,33 @@ const lockExpiryMins = 5
 func TestThatNoWorkIsDoneIfAJobIsNotRetrieved(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		ok = false
 		return
 	}
 
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		return `{ "response": "ok" }`, nil
 	}
 
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
-		return nil
+		return false, nil
 	}
 
-	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter)
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
+	}
+
+	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, nil, executor, jobCompleter, jobRetrier, jobDeadLetterer)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -50,11 +61,11 @@ func TestThatNoWorkIsDoneIfAJobIsNotRetrieved(t *testing.T) {
 func TestThatGettingAJobResultsInWorkBeingExecuted(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		scheduleID := int64(1)
 
-		j = data.Job{
+		lj.Job = data.Job{
 			JobID:      1,
 			ARN:        "arn",
 			Payload:    "payload",
@@ -65,17 +76,27 @@ func TestThatGettingAJobResultsInWorkBeingExecuted(t *testing.T) {
 		return
 	}
 
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		return `{ "response": "ok" }`, nil
 	}
 
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
-		return nil
+		return false, nil
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
 	}
 
-	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter)
+	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, nil, executor, jobCompleter, jobRetrier, jobDeadLetterer)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -95,23 +116,33 @@ func TestThatGettingAJobResultsInWorkBeingExecuted(t *testing.T) {
 func TestThatErrorsGettingAJobResultsInNoWorkBeingExecuted(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		err = errors.New("failed to get job")
 		return
 	}
 
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		return `{ "response": "ok" }`, nil
 	}
 
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
-		return nil
+		return false, nil
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
 	}
 
-	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter)
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
+	}
+
+	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, nil, executor, jobCompleter, jobRetrier, jobDeadLetterer)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -128,45 +159,125 @@ func TestThatErrorsGettingAJobResultsInNoWorkBeingExecuted(t *testing.T) {
 	expected.Assert(t, actual)
 }
 
-func TestThatWhenJobsAndCompletionsFailTheyGetRetried(t *testing.T) {
+func TestThatFailedJobsAreRescheduled(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		scheduleID := int64(1)
 
-		j = data.Job{
-			JobID:      1,
-			ARN:        "arn",
-			Payload:    "payload",
-			ScheduleID: &scheduleID,
-			When:       time.Now().UTC(),
+		lj.Job = data.Job{
+			JobID:        1,
+			ARN:          "arn",
+			Payload:      "payload",
+			ScheduleID:   &scheduleID,
+			When:         time.Now().UTC(),
+			RetryPolicy:  &data.RetryPolicy{MaxAttempts: 3, InitialIntervalSeconds: 10, Multiplier: 2},
+			AttemptCount: 1,
 		}
 		ok = true
 		return
 	}
 
 	executions := 0
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		executions++
-		if executions == 1 {
-			return "", errors.New("failed for no reason whatsoever")
+		return "", errors.New("failed for no reason whatsoever")
+	}
+
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
+		actual.JobCompleted = true
+		return false, nil
+	}
+
+	retries := 0
+	var actualRetryAt time.Time
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		actualRetryAt = retryAt
+		retries++
+		if retries == 1 {
+			return false, errors.New("failed for no reason whatsoever")
 		}
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
+	}
+
+	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, nil, executor, jobCompleter, jobRetrier, jobDeadLetterer)
+
+	var err error
+	start := time.Now().UTC()
+	actual.WorkDone, err = w()
+	actual.ErrorOccurred = err != nil
+
+	expected := Values{
+		ErrorOccurred: true,
+		JobRetrieved:  true,
+		JobExecuted:   true,
+		JobCompleted:  false, // The job isn't complete, it will be tried again later.
+		JobRetried:    true,
+		WorkDone:      false,
+	}
+
+	expected.Assert(t, actual)
+	if executions != 1 {
+		t.Errorf("expected the work to be executed once and rescheduled, but was executed %v times", executions)
+	}
+	if retries != 2 {
+		t.Errorf("expected rescheduling the job to be retried, but it wasn't")
+	}
+	// The second attempt failed, so the interval is 10 * 2 seconds.
+	if actualRetryAt.Before(start.Add(time.Second*20)) || actualRetryAt.After(time.Now().UTC().Add(time.Second*20)) {
+		t.Errorf("expected the job to be rescheduled in 20 seconds, but was rescheduled for %v", actualRetryAt)
+	}
+}
+
+func TestThatCompletionsAreRetried(t *testing.T) {
+	actual := Values{}
+
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+		actual.JobRetrieved = true
+		lj.Job = data.Job{
+			JobID:   1,
+			ARN:     "arn",
+			Payload: "payload",
+			When:    time.Now().UTC(),
+		}
+		ok = true
+		return
+	}
+
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
+		actual.JobExecuted = true
 		return `{ "response": "ok" }`, nil
 	}
 
 	completions := 0
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
 		completions++
 		if completions == 1 {
-			return errors.New("failed for no reason whatsoever")
+			return false, errors.New("failed for no reason whatsoever")
 		}
-		return nil
+		return false, nil
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
 	}
 
-	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter)
+	w := NewJobWorker(nodeName, lockExpiryMins, jobGetter, nil, executor, jobCompleter, jobRetrier, jobDeadLetterer)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -181,60 +292,73 @@ func TestThatWhenJobsAndCompletionsFailTheyGetRetried(t *testing.T) {
 	}
 
 	expected.Assert(t, actual)
-	if executions != 2 {
-		t.Errorf("expected the work to be retried, but it wasn't")
-	}
 	if completions != 2 {
 		t.Errorf("expected job completion to be retried, but it wasn't")
 	}
 }
 
-func TestThatJobExecutionRetriesAreTimeLimited(t *testing.T) {
+func TestThatJobsAreMarkedAsDeadWhenAttemptsAreExhausted(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		scheduleID := int64(1)
 
-		j = data.Job{
+		lj.Job = data.Job{
 			JobID:      1,
 			ARN:        "arn",
 			Payload:    "payload",
 			ScheduleID: &scheduleID,
 			When:       time.Now().UTC(),
+			RetryPolicy: &data.RetryPolicy{
+				MaxAttempts: 2,
+			},
+			AttemptCount: 1,
 		}
 		ok = true
 		return
 	}
 
 	executions := 0
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		executions++
 		return "", errors.New("failed for no reason whatsoever")
 	}
 
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
-		return nil
+		return false, nil
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
 	}
 
 	var err error
 	timeout := time.Second * 1
-	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter, timeout)
+	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, nil, 0, executor, jobCompleter, jobRetrier, jobDeadLetterer, timeout)
 	actual.ErrorOccurred = err != nil
 
 	expected := Values{
-		ErrorOccurred: true,
-		JobRetrieved:  true,
-		JobExecuted:   true,  // The SNS was never sent, because of errors, but the function was called.
-		JobCompleted:  true,  // It was completed, but marked as errored.
-		WorkDone:      false, // The SNS was never sent, because of errors.
+		ErrorOccurred:   true,
+		JobRetrieved:    true,
+		JobExecuted:     true,  // The SNS was never sent, because of errors, but the function was called.
+		JobCompleted:    false, // It was completed as a dead letter instead.
+		JobRetried:      false, // The final attempt failed, so there are no more retries.
+		JobDeadLettered: true,  // It was completed, but marked as dead.
+		WorkDone:        false, // The SNS was never sent, because of errors.
 	}
 
 	expected.Assert(t, actual)
-	if executions < 1 {
-		t.Errorf("expected the work to be retried multiple times (up to 5 seconds), but it wasn't")
+	if executions != 1 {
+		t.Errorf("expected the work to be executed once, but was executed %v times", executions)
 	}
 	actualErrMsg := err.Error()
 	expectedErrMsg := "execution: failed for no reason whatsoever"
@@ -246,11 +370,11 @@ func TestThatJobExecutionRetriesAreTimeLimited(t *testing.T) {
 func TestThatMarkCompleteRetriesAreTimeLimited(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		scheduleID := int64(1)
 
-		j = data.Job{
+		lj.Job = data.Job{
 			JobID:      1,
 			ARN:        "arn",
 			Payload:    "payload",
@@ -261,21 +385,31 @@ func TestThatMarkCompleteRetriesAreTimeLimited(t *testing.T) {
 		return
 	}
 
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		return "", nil
 	}
 
 	completions := 0
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
 		completions++
-		return errors.New("failed for no reason whatsoever")
+		return false, errors.New("failed for no reason whatsoever")
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 1, false, nil
 	}
 
 	var err error
 	timeout := time.Second * 1
-	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter, timeout)
+	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, nil, 0, executor, jobCompleter, jobRetrier, jobDeadLetterer, timeout)
 	actual.ErrorOccurred = err != nil
 
 	expected := Values{
@@ -300,42 +434,55 @@ func TestThatMarkCompleteRetriesAreTimeLimited(t *testing.T) {
 func TestThatBothExecutionAndCompletionErrorsAreTracked(t *testing.T) {
 	actual := Values{}
 
-	jobGetter := func(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
+	jobGetter := func(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
 		actual.JobRetrieved = true
 		scheduleID := int64(1)
 
-		j = data.Job{
+		lj.Job = data.Job{
 			JobID:      1,
 			ARN:        "arn",
 			Payload:    "payload",
 			ScheduleID: &scheduleID,
 			When:       time.Now().UTC(),
+			RetryPolicy: &data.RetryPolicy{
+				MaxAttempts: 1,
+			},
 		}
 		ok = true
 		return
 	}
 
-	executor := func(arn string, payload string) (resp string, err error) {
+	executor := func(ctx context.Context, j data.Job) (resp string, err error) {
 		actual.JobExecuted = true
 		return "", errors.New("execution error")
 	}
 
-	jobCompleter := func(jobID int64, resp string, err error) error {
+	jobCompleter := func(jobID, jobLeaseID int64, resp string, err error) (bool, error) {
 		actual.JobCompleted = true
-		return errors.New("completion error")
+		return false, nil
+	}
+
+	jobRetrier := func(jobID, jobLeaseID int64, resp string, err error, retryAt time.Time) (bool, error) {
+		actual.JobRetried = true
+		return false, nil
+	}
+
+	jobDeadLetterer := func(j data.Job, jobLeaseID int64, resp string, jobErr error) (int64, bool, error) {
+		actual.JobDeadLettered = true
+		return 0, false, errors.New("completion error")
 	}
 
 	var err error
 	timeout := time.Millisecond * 100
-	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, executor, jobCompleter, timeout)
+	actual.WorkDone, err = findAndExecuteWork(nodeName, lockExpiryMins, jobGetter, nil, 0, executor, jobCompleter, jobRetrier, jobDeadLetterer, timeout)
 	actual.ErrorOccurred = err != nil
 
 	expected := Values{
-		ErrorOccurred: true,
-		JobRetrieved:  true,
-		JobExecuted:   true,  // The SNS executor was called, but it returned an error.
-		JobCompleted:  true,  // The job completer was called, but it returned an error.
-		WorkDone:      false, // No work was done.
+		ErrorOccurred:   true,
+		JobRetrieved:    true,
+		JobExecuted:     true,  // The SNS executor was called, but it returned an error.
+		JobDeadLettered: true,  // The job dead letterer was called, but it returned an error.
+		WorkDone:        false, // No work was done.
 	}
 
 	expected.Assert(t, actual)
@@ -347,11 +494,13 @@ func TestThatBothExecutionAndCompletionErrorsAreTracked(t *testing.T) {
 }
 
 type Values struct {
-	WorkDone      bool
-	JobRetrieved  bool
-	JobExecuted   bool
-	JobCompleted  bool
-	ErrorOccurred bool
+	WorkDone        bool
+	JobRetrieved    bool
+	JobExecuted     bool
+	JobCompleted    bool
+	JobRetried      bool
+	JobDeadLettered bool
+	ErrorOccurred   bool
 }
 
 func (expected Values) Assert(t *testing.T, actual Values) {
@@ -370,4 +519,10 @@ func (expected Values) Assert(t *testing.T, actual Values) {
 	if expected.JobCompleted != actual.JobCompleted {
 		t.Errorf("expected job completed=%v, but got %v", expected.JobCompleted, actual.JobCompleted)
 	}
+	if expected.JobRetried != actual.JobRetried {
+		t.Errorf("expected job retried=%v, but got %v", expected.JobRetried, actual.JobRetried)
+	}
+	if expected.JobDeadLettered != actual.JobDeadLettered {
+		t.Errorf("expected job dead lettered=%v, but got %v", expected.JobDeadLettered, actual.JobDeadLettered)
+	}
 }
@@ -0,0 +1,163 @@
+// Package memory provides an in-memory storage backend for callme, with the same job, schedule and lease semantics
+// as the database backends. It's useful for tests and local development, but nothing is persisted, so the worker
+// and API must run in the same process to share it.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// Database holds the jobs and schedules. It's safe for use by multiple goroutines.
+type Database struct {
+	m sync.Mutex
+
+	jobs          map[int64]*job
+	deadLetters   []*data.DeadLetter
+	schedules     map[int64]*schedule
+	crontabs      map[int64]*data.Crontab
+	crontabLeases map[int64]*crontabLease
+
+	idempotencyKeys map[idempotencyScope]*idempotencyKey
+	dedupeKeys      map[string]*jobDedupe
+
+	lastJobID          int64
+	lastJobLeaseID     int64
+	lastJobResponseID  int64
+	lastJobAttemptID   int64
+	lastJobDuplicateID int64
+	lastDeadLetterID   int64
+	lastScheduleID     int64
+	lastPauseID        int64
+	lastCrontabID      int64
+	lastCrontabLeaseID int64
+}
+
+type job struct {
+	data.Job
+	leases     []jobLease
+	response   *data.JobResponse
+	attempts   []data.JobAttempt
+	duplicates []data.JobDuplicate
+}
+
+type jobLease struct {
+	jobLeaseID int64
+	lockedBy   string
+	at         time.Time
+	until      time.Time
+}
+
+type schedule struct {
+	data.Schedule
+	pauses []data.SchedulePause
+}
+
+// The resources which can be created with an idempotency key.
+const (
+	idempotencyResourceJob      = "job"
+	idempotencyResourceSchedule = "schedule"
+)
+
+// idempotencyScope is the unique part of an idempotency key.
+type idempotencyScope struct {
+	resource string
+	caller   string
+	key      string
+}
+
+type idempotencyKey struct {
+	requestHash string
+	resourceID  int64
+	created     time.Time
+}
+
+// jobDedupe holds a dedupe key for a job until the end of its dedupe window.
+type jobDedupe struct {
+	jobID   int64
+	expires time.Time
+}
+
+type crontabLease struct {
+	crontabID int64
+	lockedBy  string
+	at        time.Time
+	until     time.Time
+	rescinded bool
+}
+
+// NewDatabase creates an empty Database.
+func NewDatabase() *Database {
+	return &Database{
+		jobs:          make(map[int64]*job),
+		schedules:     make(map[int64]*schedule),
+		crontabs:      make(map[int64]*data.Crontab),
+		crontabLeases: make(map[int64]*crontabLease),
+
+		idempotencyKeys: make(map[idempotencyScope]*idempotencyKey),
+		dedupeKeys:      make(map[string]*jobDedupe),
+	}
+}
+
+// UpdateSchema does nothing, since there's no schema to migrate.
+func (db *Database) UpdateSchema() error {
+	return nil
+}
+
+// findIdempotencyKey returns the ID of the resource which was created with the key, if there is one.
+func (db *Database) findIdempotencyKey(resource string, key data.IdempotencyKey) (resourceID int64, found bool, err error) {
+	k, ok := db.idempotencyKeys[idempotencyScope{resource: resource, caller: key.Caller, key: key.Key}]
+	if !ok {
+		return
+	}
+	if k.requestHash != key.RequestHash {
+		return 0, false, data.ErrIdempotencyKeyConflict
+	}
+	return k.resourceID, true, nil
+}
+
+func (db *Database) insertIdempotencyKey(resource string, key data.IdempotencyKey, resourceID int64) {
+	db.idempotencyKeys[idempotencyScope{resource: resource, caller: key.Caller, key: key.Key}] = &idempotencyKey{
+		requestHash: key.RequestHash,
+		resourceID:  resourceID,
+		created:     now(),
+	}
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// The copy functions make sure that callers can't modify the stored data through pointers.
+
+func copyHTTPRequest(r *data.HTTPRequest) *data.HTTPRequest {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	if r.Headers != nil {
+		c.Headers = make(map[string]string, len(r.Headers))
+		for k, v := range r.Headers {
+			c.Headers[k] = v
+		}
+	}
+	return &c
+}
+
+func copyRetryPolicy(p *data.RetryPolicy) *data.RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+func copyInt64(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
@@ -0,0 +1,780 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// JobManager provides features to manage jobs held in memory.
+type JobManager struct {
+	DB *Database
+}
+
+// NewJobManager creates a new JobManager.
+func NewJobManager(db *Database) JobManager {
+	return JobManager{
+		DB: db,
+	}
+}
+
+// view copies the job, so that it can be returned to callers.
+func (j *job) view() data.Job {
+	v := j.Job
+	v.ScheduleID = copyInt64(j.ScheduleID)
+	v.HTTPRequest = copyHTTPRequest(j.HTTPRequest)
+	v.RetryPolicy = copyRetryPolicy(j.RetryPolicy)
+	v.AttemptCount = len(j.attempts)
+	return v
+}
+
+// isLeased returns whether the job has a current lease.
+func (j *job) isLeased(at time.Time) bool {
+	for _, l := range j.leases {
+		if !l.until.Before(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCurrentLease returns whether the lease is current, which it is if it's the job's latest lease and the job hasn't
+// been completed.
+func (j *job) isCurrentLease(jobLeaseID int64) bool {
+	return j.response == nil && len(j.leases) > 0 && j.leases[len(j.leases)-1].jobLeaseID == jobLeaseID
+}
+
+func (db *Database) insertJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64) (*job, error) {
+	if scheduleID != nil {
+		if _, ok := db.schedules[*scheduleID]; !ok {
+			return nil, fmt.Errorf("memory: schedule %v does not exist", *scheduleID)
+		}
+	}
+	db.lastJobID++
+	j := &job{
+		Job: data.Job{
+			JobID:       db.lastJobID,
+			ScheduleID:  copyInt64(scheduleID),
+			When:        when.UTC(),
+			ARN:         arn,
+			Payload:     payload,
+			HTTPRequest: copyHTTPRequest(httpRequest),
+			RetryPolicy: copyRetryPolicy(retryPolicy),
+		},
+	}
+	db.jobs[j.JobID] = j
+	return j, nil
+}
+
+func (db *Database) getJob(jobID int64) (*job, error) {
+	j, ok := db.jobs[jobID]
+	if !ok {
+		return nil, fmt.Errorf("memory: job %v does not exist", jobID)
+	}
+	return j, nil
+}
+
+// insertAttempt records an attempt at a job, numbering it after the job's previous attempts.
+func (db *Database) insertAttempt(j *job, resp string, isError bool, errorString string, retryAt time.Time) {
+	db.lastJobAttemptID++
+	j.attempts = append(j.attempts, data.JobAttempt{
+		JobAttemptID: db.lastJobAttemptID,
+		JobID:        j.JobID,
+		Attempt:      len(j.attempts) + 1,
+		Time:         now(),
+		Response:     resp,
+		IsError:      isError,
+		Error:        errorString,
+		RetryAt:      retryAt,
+	})
+}
+
+// insertLease leases a job.
+func (db *Database) insertLease(j *job, lockedBy string, at time.Time, lockExpiryMinutes int) data.LeasedJob {
+	db.lastJobLeaseID++
+	j.leases = append(j.leases, jobLease{
+		jobLeaseID: db.lastJobLeaseID,
+		lockedBy:   lockedBy,
+		at:         at,
+		until:      at.Add(time.Duration(lockExpiryMinutes) * time.Minute),
+	})
+	return data.LeasedJob{Job: j.view(), JobLeaseID: db.lastJobLeaseID}
+}
+
+// insertDuplicate records an execution which was completed using a lease that wasn't current.
+func (db *Database) insertDuplicate(j *job, jobLeaseID int64, resp string, isError bool, errorString string) {
+	db.lastJobDuplicateID++
+	j.duplicates = append(j.duplicates, data.JobDuplicate{
+		JobDuplicateID: db.lastJobDuplicateID,
+		JobID:          j.JobID,
+		JobLeaseID:     jobLeaseID,
+		Time:           now(),
+		Response:       resp,
+		IsError:        isError,
+		Error:          errorString,
+	})
+}
+
+// insertResponse completes a job.
+func (db *Database) insertResponse(j *job, resp string, isError bool, errorString string) {
+	db.lastJobResponseID++
+	j.response = &data.JobResponse{
+		JobResponseID: db.lastJobResponseID,
+		JobID:         j.JobID,
+		Time:          now(),
+		Response:      resp,
+		IsError:       isError,
+		Error:         errorString,
+	}
+	db.releaseDedupeKey(j.JobID)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// StartJob schedules a job to start in the future, unless a job was started with the dedupe key within its dedupe
+// window, in which case that job is returned.
+func (m JobManager) StartJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j data.Job, duplicate bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	stored, duplicate, err := m.DB.startJob(when, arn, payload, httpRequest, retryPolicy, scheduleID, dedupeKey, dedupeWindow)
+	if err != nil {
+		return data.Job{
+			ARN:         arn,
+			Payload:     payload,
+			HTTPRequest: httpRequest,
+			RetryPolicy: retryPolicy,
+			ScheduleID:  scheduleID,
+			When:        when,
+			DedupeKey:   dedupeKey,
+		}, false, err
+	}
+	j = stored.view()
+	j.DedupeKey = dedupeKey
+	return j, duplicate, nil
+}
+
+// StartJobs starts a batch of jobs, so either all of the jobs are started, or none are.
+func (m JobManager) StartJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	// Starting a job only fails if its schedule doesn't exist, so check them all before starting any.
+	for _, j := range jobs {
+		if j.ScheduleID != nil {
+			if _, ok := m.DB.schedules[*j.ScheduleID]; !ok {
+				return nil, fmt.Errorf("memory: schedule %v does not exist", *j.ScheduleID)
+			}
+		}
+	}
+	started = make([]data.StartedJob, len(jobs))
+	for i, j := range jobs {
+		stored, duplicate, err := m.DB.startJob(j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, j.ScheduleID, j.DedupeKey, dedupeWindow)
+		if err != nil {
+			return nil, err
+		}
+		started[i] = data.StartedJob{Job: stored.view(), Duplicate: duplicate}
+		started[i].Job.DedupeKey = j.DedupeKey
+	}
+	return
+}
+
+// StartJobIdempotently starts a job, unless a job was already started with the idempotency key, in which case that
+// job is returned.
+func (m JobManager) StartJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	jobID, existing, err := m.DB.findIdempotencyKey(idempotencyResourceJob, key)
+	if err != nil {
+		return
+	}
+	if existing {
+		// The job may have been deleted since it was started.
+		if ej, ok := m.DB.jobs[jobID]; ok {
+			j = ej.view()
+			j.DedupeKey = m.DB.dedupeKeyOf(jobID)
+			return j, true, false, nil
+		}
+		return data.Job{JobID: jobID}, true, false, nil
+	}
+	nj, duplicate, err := m.DB.startJob(when, arn, payload, httpRequest, retryPolicy, nil, dedupeKey, dedupeWindow)
+	if err != nil {
+		return
+	}
+	m.DB.insertIdempotencyKey(idempotencyResourceJob, key, nj.JobID)
+	j = nj.view()
+	j.DedupeKey = dedupeKey
+	return j, false, duplicate, nil
+}
+
+// startJob inserts a job, unless a pending job was started with the dedupe key and the key's window hasn't ended, in
+// which case that job is returned with duplicate set to true.
+func (db *Database) startJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j *job, duplicate bool, err error) {
+	if dedupeKey != "" {
+		if d, ok := db.dedupeKeys[dedupeKey]; ok && d.expires.After(now()) {
+			if existing, ok := db.jobs[d.jobID]; ok && existing.response == nil {
+				return existing, true, nil
+			}
+		}
+	}
+	if j, err = db.insertJob(when, arn, payload, httpRequest, retryPolicy, scheduleID); err != nil {
+		return
+	}
+	if dedupeKey != "" {
+		db.dedupeKeys[dedupeKey] = &jobDedupe{jobID: j.JobID, expires: now().Add(dedupeWindow)}
+	}
+	return j, false, nil
+}
+
+// releaseDedupeKey releases the dedupe key held by a job which has been completed, so that the key can start a new job.
+func (db *Database) releaseDedupeKey(jobID int64) {
+	if key := db.dedupeKeyOf(jobID); key != "" {
+		delete(db.dedupeKeys, key)
+	}
+}
+
+// dedupeKeyOf returns the dedupe key which is held for the job, if there is one.
+func (db *Database) dedupeKeyOf(jobID int64) string {
+	for key, d := range db.dedupeKeys {
+		if d.jobID == jobID {
+			return key
+		}
+	}
+	return ""
+}
+
+// GetAvailableJobCount returns the number of jobs to process, i.e. where they have no job response and they're
+// ready to process.
+func (m JobManager) GetAvailableJobCount() (count int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	for _, j := range m.DB.jobs {
+		if !j.When.After(at) && j.response == nil {
+			count++
+		}
+	}
+	return
+}
+
+// GetJob retrieves the earliest job that's ready to run and takes a lease on it.
+func (m JobManager) GetJob(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	var next *job
+	for _, candidate := range m.DB.jobs {
+		if candidate.When.After(at) || candidate.response != nil || candidate.isLeased(at) {
+			continue
+		}
+		if next == nil || candidate.When.Before(next.When) || (candidate.When.Equal(next.When) && candidate.JobID < next.JobID) {
+			next = candidate
+		}
+	}
+	if next == nil {
+		return
+	}
+	return m.DB.insertLease(next, lockedBy, at, lockExpiryMinutes), true, nil
+}
+
+// GetJobs leases up to limit jobs that are ready to run from the queue, in the order they're due.
+func (m JobManager) GetJobs(lockedBy string, lockExpiryMinutes int, limit int) (jobs []data.LeasedJob, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	var available []*job
+	for _, candidate := range m.DB.jobs {
+		if candidate.When.After(at) || candidate.response != nil || candidate.isLeased(at) {
+			continue
+		}
+		available = append(available, candidate)
+	}
+	sort.Slice(available, func(i, j int) bool {
+		if available[i].When.Equal(available[j].When) {
+			return available[i].JobID < available[j].JobID
+		}
+		return available[i].When.Before(available[j].When)
+	})
+	if len(available) > limit {
+		available = available[:limit]
+	}
+	for _, next := range available {
+		jobs = append(jobs, m.DB.insertLease(next, lockedBy, at, lockExpiryMinutes))
+	}
+	return
+}
+
+// GetJobResponse retrieves a completed job's data.
+func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	stored, jobOK := m.DB.jobs[jobID]
+	if !jobOK {
+		return
+	}
+	j = stored.view()
+	j.DedupeKey = m.DB.dedupeKeyOf(jobID)
+	if stored.response != nil {
+		r = *stored.response
+		responseOK = true
+	}
+	return
+}
+
+// CompleteJob marks a job as complete if the lease is current, otherwise the response is recorded as a duplicate.
+func (m JobManager) CompleteJob(jobID, jobLeaseID int64, resp string, jobError error) (duplicate bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	j, err := m.DB.getJob(jobID)
+	if err != nil {
+		return
+	}
+	return m.DB.complete(j, jobLeaseID, resp, jobError), nil
+}
+
+// complete completes a job, or records a duplicate, returning whether it was a duplicate.
+func (db *Database) complete(j *job, jobLeaseID int64, resp string, jobError error) (duplicate bool) {
+	if !j.isCurrentLease(jobLeaseID) {
+		db.insertDuplicate(j, jobLeaseID, resp, jobError != nil, errorString(jobError))
+		return true
+	}
+	db.insertAttempt(j, resp, jobError != nil, errorString(jobError), time.Time{})
+	db.insertResponse(j, resp, jobError != nil, errorString(jobError))
+	return false
+}
+
+// CompleteJobs marks a batch of jobs as complete, recording duplicates for completions whose leases aren't current.
+// If any of the jobs don't exist, none of them are completed.
+func (m JobManager) CompleteJobs(completions []data.JobCompletion) (duplicateJobIDs []int64, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	jobs := make([]*job, len(completions))
+	for i, c := range completions {
+		if jobs[i], err = m.DB.getJob(c.JobID); err != nil {
+			return
+		}
+	}
+	for i, c := range completions {
+		if m.DB.complete(jobs[i], c.JobLeaseID, c.Response, c.Err) {
+			duplicateJobIDs = append(duplicateJobIDs, c.JobID)
+		}
+	}
+	return
+}
+
+// RenewJobLease extends a lease on a job, so that it expires lockExpiryMinutes from now. It returns false if the
+// lease has expired, been released, or been taken over by another worker.
+func (m JobManager) RenewJobLease(jobID, jobLeaseID int64, lockExpiryMinutes int) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	j, exists := m.DB.jobs[jobID]
+	if !exists || len(j.leases) == 0 {
+		return
+	}
+	at := now()
+	l := &j.leases[len(j.leases)-1]
+	if l.jobLeaseID != jobLeaseID || l.until.Before(at) {
+		return
+	}
+	l.until = at.Add(time.Duration(lockExpiryMinutes) * time.Minute)
+	return true, nil
+}
+
+// RetryJob records a failed attempt at a job and reschedules it to run again at the retryAt time, releasing
+// the lease so that any worker can pick it up. If the lease isn't current, the attempt is recorded as a duplicate.
+func (m JobManager) RetryJob(jobID, jobLeaseID int64, resp string, jobError error, retryAt time.Time) (duplicate bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	j, err := m.DB.getJob(jobID)
+	if err != nil {
+		return
+	}
+	if !j.isCurrentLease(jobLeaseID) {
+		m.DB.insertDuplicate(j, jobLeaseID, resp, true, errorString(jobError))
+		return true, nil
+	}
+	retryAt = retryAt.UTC()
+	m.DB.insertAttempt(j, resp, true, errorString(jobError), retryAt)
+	j.When = retryAt
+	// Release the lease so that any worker can pick up the job when it's due.
+	at := now()
+	if l := &j.leases[len(j.leases)-1]; l.until.After(at) {
+		l.until = at.Add(-time.Second)
+	}
+	return false, nil
+}
+
+// GetJobDuplicates gets the duplicate executions of a job, in order.
+func (m JobManager) GetJobDuplicates(jobID int64) (duplicates []data.JobDuplicate, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	duplicates = make([]data.JobDuplicate, 0)
+	if j, ok := m.DB.jobs[jobID]; ok {
+		duplicates = append(duplicates, j.duplicates...)
+	}
+	return
+}
+
+// GetJobAttempts gets the attempts made to execute a job, in order.
+func (m JobManager) GetJobAttempts(jobID int64) (attempts []data.JobAttempt, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	attempts = make([]data.JobAttempt, 0)
+	if j, ok := m.DB.jobs[jobID]; ok {
+		attempts = append(attempts, j.attempts...)
+	}
+	return
+}
+
+// ListJobs lists the jobs which match the filter in ID order, starting after the afterID.
+func (m JobManager) ListJobs(filter data.JobFilter, afterID int64, limit int) (jobs []data.JobSummary, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	var matched []*job
+	for _, j := range m.DB.jobs {
+		if j.JobID > afterID && j.matches(filter, at) {
+			matched = append(matched, j)
+		}
+	}
+	sort.Slice(matched, func(i, k int) bool { return matched[i].JobID < matched[k].JobID })
+	jobs = make([]data.JobSummary, 0)
+	for _, j := range matched {
+		if len(jobs) >= limit {
+			break
+		}
+		jobs = append(jobs, data.JobSummary{Job: j.view(), State: j.state(at)})
+	}
+	return
+}
+
+// state returns the state of the job at the given time.
+func (j *job) state(at time.Time) string {
+	switch {
+	case j.response != nil && j.response.IsError:
+		return data.JobStateFailed
+	case j.response != nil:
+		return data.JobStateSucceeded
+	case j.isLeased(at):
+		return data.JobStateLeased
+	}
+	return data.JobStatePending
+}
+
+func (j *job) matches(filter data.JobFilter, at time.Time) bool {
+	if filter.ScheduleID != nil && (j.ScheduleID == nil || *j.ScheduleID != *filter.ScheduleID) {
+		return false
+	}
+	if filter.State != "" && j.state(at) != filter.State {
+		return false
+	}
+	if !filter.WhenFrom.IsZero() && j.When.Before(filter.WhenFrom) {
+		return false
+	}
+	if !filter.WhenTo.IsZero() && !j.When.Before(filter.WhenTo) {
+		return false
+	}
+	if !strings.HasPrefix(j.ARN, filter.ARNPrefix) {
+		return false
+	}
+	if filter.IsError != nil && j.hasFailedAttempt() != *filter.IsError {
+		return false
+	}
+	return true
+}
+
+func (j *job) hasFailedAttempt() bool {
+	for _, a := range j.attempts {
+		if a.IsError {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteJob deletes a job which hasn't been started.
+func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	j, exists := m.DB.jobs[jobID]
+	if !exists || len(j.leases) > 0 || j.response != nil {
+		return false, nil
+	}
+	delete(m.DB.jobs, jobID)
+	return true, nil
+}
+
+// DeadLetterJob records the final failed attempt at a job, completes it with an error and marks it as a dead letter.
+// If the lease isn't current, the attempt is recorded as a duplicate instead.
+func (m JobManager) DeadLetterJob(j data.Job, jobLeaseID int64, resp string, jobError error) (deadLetterID int64, duplicate bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	stored, err := m.DB.getJob(j.JobID)
+	if err != nil {
+		return
+	}
+	if !stored.isCurrentLease(jobLeaseID) {
+		m.DB.insertDuplicate(stored, jobLeaseID, resp, true, errorString(jobError))
+		return 0, true, nil
+	}
+	m.DB.insertAttempt(stored, resp, true, errorString(jobError), time.Time{})
+	m.DB.insertResponse(stored, resp, true, errorString(jobError))
+	m.DB.lastDeadLetterID++
+	m.DB.deadLetters = append(m.DB.deadLetters, &data.DeadLetter{
+		DeadLetterID: m.DB.lastDeadLetterID,
+		Job:          data.Job{JobID: j.JobID},
+		Time:         now(),
+		Error:        errorString(jobError),
+	})
+	return m.DB.lastDeadLetterID, false, nil
+}
+
+func (db *Database) getDeadLetter(deadLetterID int64) (dl *data.DeadLetter, ok bool) {
+	for _, dl := range db.deadLetters {
+		if dl.DeadLetterID == deadLetterID {
+			return dl, true
+		}
+	}
+	return nil, false
+}
+
+// RecordDeadLetterForward records the result of forwarding a dead letter to the dead letter ARN.
+func (m JobManager) RecordDeadLetterForward(deadLetterID int64, forwardedTo string, forwardError error) error {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	if dl, ok := m.DB.getDeadLetter(deadLetterID); ok {
+		dl.ForwardedTo = forwardedTo
+		dl.ForwardError = errorString(forwardError)
+	}
+	return nil
+}
+
+// GetDeadLetters lists dead letters in ID order, starting after the afterID.
+func (m JobManager) GetDeadLetters(requeued bool, afterID int64, limit int) (dls []data.DeadLetter, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	dls = make([]data.DeadLetter, 0)
+	for _, dl := range m.DB.deadLetters {
+		if len(dls) >= limit {
+			break
+		}
+		if dl.DeadLetterID <= afterID || (dl.RequeuedJobID != nil) != requeued {
+			continue
+		}
+		v := *dl
+		v.RequeuedJobID = copyInt64(dl.RequeuedJobID)
+		if j, ok := m.DB.jobs[dl.Job.JobID]; ok {
+			v.Job = j.view()
+		}
+		dls = append(dls, v)
+	}
+	return
+}
+
+// RequeueDeadLetters creates a new job for each dead letter, returning a map of dead letter ID to new job ID.
+// The new job has the same data as the dead job, so that the history of the dead job is kept.
+func (m JobManager) RequeueDeadLetters(deadLetterIDs []int64) (jobIDs map[int64]int64, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	jobIDs = make(map[int64]int64)
+	for _, id := range deadLetterIDs {
+		dl, ok := m.DB.getDeadLetter(id)
+		if !ok || dl.RequeuedJobID != nil {
+			continue
+		}
+		dead, err := m.DB.getJob(dl.Job.JobID)
+		if err != nil {
+			return jobIDs, err
+		}
+		at := now()
+		j, err := m.DB.insertJob(at, dead.ARN, dead.Payload, dead.HTTPRequest, dead.RetryPolicy, dead.ScheduleID)
+		if err != nil {
+			return jobIDs, err
+		}
+		jobID := j.JobID
+		dl.RequeuedJobID = &jobID
+		dl.RequeuedDate = at
+		jobIDs[id] = j.JobID
+	}
+	return
+}
+
+// PurgeJobs deletes up to limit jobs which were completed before the cutoff, along with their responses, attempts,
+// duplicates and leases. Jobs which have been dead lettered are kept.
+func (m JobManager) PurgeJobs(completedBefore time.Time, limit int) (purged int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	for _, j := range m.DB.completedJobs(completedBefore, limit) {
+		delete(m.DB.jobs, j.JobID)
+		purged++
+	}
+	return
+}
+
+// GetCompletedJobs gets up to limit jobs which were completed before the cutoff, oldest first, with their responses,
+// attempts and duplicates. Jobs which have been dead lettered aren't included.
+func (m JobManager) GetCompletedJobs(completedBefore time.Time, limit int) (jrs []data.JobAndResponse, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	jrs = make([]data.JobAndResponse, 0)
+	for _, j := range m.DB.completedJobs(completedBefore, limit) {
+		jrs = append(jrs, data.JobAndResponse{
+			Job:            j.view(),
+			JobResponse:    *j.response,
+			HasJobResponse: true,
+			Attempts:       append([]data.JobAttempt{}, j.attempts...),
+			Duplicates:     append([]data.JobDuplicate{}, j.duplicates...),
+		})
+	}
+	return
+}
+
+// DeleteCompletedJobs deletes the jobs, along with their responses, attempts, duplicates and leases. Jobs which
+// haven't been completed, or have been dead lettered, are kept.
+func (m JobManager) DeleteCompletedJobs(jobIDs []int64) (deleted int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	deadLettered := m.DB.deadLetteredJobIDs()
+	for _, jobID := range jobIDs {
+		j, ok := m.DB.jobs[jobID]
+		if !ok || j.response == nil || deadLettered[jobID] {
+			continue
+		}
+		delete(m.DB.jobs, jobID)
+		deleted++
+	}
+	return
+}
+
+// completedJobs returns up to limit jobs which were completed before the cutoff, oldest first, excluding dead letters.
+// The caller must hold the lock.
+func (db *Database) completedJobs(completedBefore time.Time, limit int) []*job {
+	deadLettered := db.deadLetteredJobIDs()
+	var completed []*job
+	for _, j := range db.jobs {
+		if j.response != nil && j.response.Time.Before(completedBefore) && !deadLettered[j.JobID] {
+			completed = append(completed, j)
+		}
+	}
+	// Return the oldest first, like the database backends.
+	sort.Slice(completed, func(i, k int) bool {
+		return completed[i].response.Time.Before(completed[k].response.Time)
+	})
+	if len(completed) > limit {
+		completed = completed[:limit]
+	}
+	return completed
+}
+
+// deadLetteredJobIDs returns the set of jobs which have been dead lettered. The caller must hold the lock.
+func (db *Database) deadLetteredJobIDs() map[int64]bool {
+	deadLettered := make(map[int64]bool, len(db.deadLetters))
+	for _, dl := range db.deadLetters {
+		deadLettered[dl.Job.JobID] = true
+	}
+	return deadLettered
+}
+
+// PurgeJobLeases deletes up to limit job leases which expired before the cutoff.
+func (m JobManager) PurgeJobLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	jobIDs := make([]int64, 0, len(m.DB.jobs))
+	for id := range m.DB.jobs {
+		jobIDs = append(jobIDs, id)
+	}
+	sort.Slice(jobIDs, func(i, k int) bool { return jobIDs[i] < jobIDs[k] })
+	for _, id := range jobIDs {
+		j := m.DB.jobs[id]
+		leases := j.leases[:0]
+		for _, l := range j.leases {
+			if purged < limit && l.until.Before(expiredBefore) {
+				purged++
+				continue
+			}
+			leases = append(leases, l)
+		}
+		j.leases = leases
+		if purged >= limit {
+			break
+		}
+	}
+	return
+}
+
+// PurgeIdempotencyKeys deletes up to limit idempotency keys which were created before the cutoff, oldest first.
+func (m JobManager) PurgeIdempotencyKeys(createdBefore time.Time, limit int) (purged int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	var expired []idempotencyScope
+	for scope, k := range m.DB.idempotencyKeys {
+		if k.created.Before(createdBefore) {
+			expired = append(expired, scope)
+		}
+	}
+	sort.Slice(expired, func(i, k int) bool {
+		return m.DB.idempotencyKeys[expired[i]].created.Before(m.DB.idempotencyKeys[expired[k]].created)
+	})
+	for _, scope := range expired {
+		if purged >= limit {
+			break
+		}
+		delete(m.DB.idempotencyKeys, scope)
+		purged++
+	}
+	return
+}
+
+// PurgeDedupeKeys deletes up to limit dedupe keys whose windows ended before the cutoff, oldest first.
+func (m JobManager) PurgeDedupeKeys(expiredBefore time.Time, limit int) (purged int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	var expired []string
+	for key, d := range m.DB.dedupeKeys {
+		if d.expires.Before(expiredBefore) {
+			expired = append(expired, key)
+		}
+	}
+	sort.Slice(expired, func(i, k int) bool {
+		return m.DB.dedupeKeys[expired[i]].expires.Before(m.DB.dedupeKeys[expired[k]].expires)
+	})
+	for _, key := range expired {
+		if purged >= limit {
+			break
+		}
+		delete(m.DB.dedupeKeys, key)
+		purged++
+	}
+	return
+}
@@ -0,0 +1,415 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/welldigital/callme/crontab"
+	"github.com/welldigital/callme/data"
+)
+
+// ScheduleManager provides features to manage schedules held in memory.
+type ScheduleManager struct {
+	DB *Database
+}
+
+// NewScheduleManager creates a new ScheduleManager.
+func NewScheduleManager(db *Database) ScheduleManager {
+	return ScheduleManager{
+		DB: db,
+	}
+}
+
+// view copies the schedule, so that it can be returned to callers.
+func (s *schedule) view() data.Schedule {
+	v := s.Schedule
+	v.HTTPRequest = copyHTTPRequest(s.HTTPRequest)
+	v.RetryPolicy = copyRetryPolicy(s.RetryPolicy)
+	return v
+}
+
+// scheduleCrontabs returns the crontabs of the schedule in ID order.
+func (db *Database) scheduleCrontabs(scheduleID int64) (crontabs []*data.Crontab) {
+	for _, ct := range db.crontabs {
+		if ct.ScheduleID == scheduleID {
+			crontabs = append(crontabs, ct)
+		}
+	}
+	sort.Slice(crontabs, func(i, j int) bool { return crontabs[i].CrontabID < crontabs[j].CrontabID })
+	return
+}
+
+// isLeased returns whether the crontab has a current lease which hasn't been rescinded.
+func (db *Database) isLeased(crontabID int64, at time.Time) bool {
+	for _, l := range db.crontabLeases {
+		if l.crontabID == crontabID && !l.rescinded && !l.until.Before(at) {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *Database) insertCrontab(scheduleID int64, spec string, previous, next, lastUpdated time.Time) {
+	db.lastCrontabID++
+	db.crontabs[db.lastCrontabID] = &data.Crontab{
+		CrontabID:   db.lastCrontabID,
+		ScheduleID:  scheduleID,
+		Crontab:     spec,
+		Previous:    previous,
+		Next:        next.UTC(),
+		LastUpdated: lastUpdated,
+	}
+}
+
+func (db *Database) deleteCrontab(crontabID int64) {
+	// Expired and rescinded leases reference the crontab, so they're removed with it.
+	for id, l := range db.crontabLeases {
+		if l.crontabID == crontabID {
+			delete(db.crontabLeases, id)
+		}
+	}
+	delete(db.crontabs, crontabID)
+}
+
+func (db *Database) rescindCrontabLease(crontabLeaseID int64) {
+	if l, ok := db.crontabLeases[crontabLeaseID]; ok {
+		l.rescinded = true
+	}
+}
+
+// Create creates a repeating schedule. Doesn't require a lease, any process can do this.
+func (m ScheduleManager) Create(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	return m.DB.insertSchedule(from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by), nil
+}
+
+// CreateIdempotently creates a schedule, unless a schedule was already created with the idempotency key, in which
+// case its ID is returned.
+func (m ScheduleManager) CreateIdempotently(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, existing bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	id, existing, err = m.DB.findIdempotencyKey(idempotencyResourceSchedule, key)
+	if err != nil || existing {
+		return
+	}
+	id = m.DB.insertSchedule(from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by)
+	m.DB.insertIdempotencyKey(idempotencyResourceSchedule, key, id)
+	return
+}
+
+func (db *Database) insertSchedule(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) int64 {
+	created := now()
+	db.lastScheduleID++
+	s := &schedule{
+		Schedule: data.Schedule{
+			ScheduleID:    db.lastScheduleID,
+			ExternalID:    externalID,
+			By:            by,
+			ARN:           arn,
+			Payload:       payload,
+			HTTPRequest:   copyHTTPRequest(httpRequest),
+			RetryPolicy:   copyRetryPolicy(retryPolicy),
+			Timezone:      timezone,
+			Until:         until.UTC(),
+			MaxRuns:       maxRuns,
+			MisfirePolicy: misfirePolicy,
+			Created:       created,
+			Active:        true,
+		},
+	}
+	db.schedules[s.ScheduleID] = s
+
+	// Don't allow schedules to be started in the past, since the system will attempt
+	// to catch up.
+	startTime := from.UTC()
+	if from.Before(created) {
+		startTime = created
+	}
+	for _, ct := range crontabs {
+		db.insertCrontab(s.ScheduleID, ct, created, startTime, startTime)
+	}
+	return s.ScheduleID
+}
+
+// Update replaces the fields and crontabs of an active schedule. Unchanged crontabs keep their next run, while new
+// crontabs, and all of the crontabs if the timezone has changed, are next due at their first run after the update.
+// If a worker holds a lease on a crontab which would be removed or recalculated, data.ErrCrontabLeased is returned.
+func (m ScheduleManager) Update(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	s, ok := m.DB.schedules[scheduleID]
+	if !ok || !s.Active {
+		return false, nil
+	}
+
+	var existing []data.Crontab
+	for _, ct := range m.DB.scheduleCrontabs(scheduleID) {
+		existing = append(existing, *ct)
+	}
+	at := now()
+	added, updated, removed, err := crontab.Replace(existing, crontabs, timezone != s.Timezone, timezone, at)
+	if err != nil {
+		return false, err
+	}
+	for _, ct := range append(updated, removed...) {
+		if m.DB.isLeased(ct.CrontabID, at) {
+			return false, data.ErrCrontabLeased
+		}
+	}
+
+	s.ExternalID = externalID
+	s.By = by
+	s.ARN = arn
+	s.Payload = payload
+	s.HTTPRequest = copyHTTPRequest(httpRequest)
+	s.RetryPolicy = copyRetryPolicy(retryPolicy)
+	s.Timezone = timezone
+	s.Until = until.UTC()
+	s.MaxRuns = maxRuns
+	s.MisfirePolicy = misfirePolicy
+	for _, ct := range removed {
+		m.DB.deleteCrontab(ct.CrontabID)
+	}
+	for _, ct := range updated {
+		stored := m.DB.crontabs[ct.CrontabID]
+		stored.Next = ct.Next.UTC()
+		stored.LastUpdated = at
+	}
+	for _, ct := range added {
+		m.DB.insertCrontab(scheduleID, ct.Crontab, at, ct.Next, at)
+	}
+	return true, nil
+}
+
+// Deactivate deactivates a schedule.
+func (m ScheduleManager) Deactivate(scheduleID int64) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	s, ok := m.DB.schedules[scheduleID]
+	if !ok {
+		return false, nil
+	}
+	s.Active = false
+	s.DeactivatedDate = now()
+	return true, nil
+}
+
+// Pause pauses an active schedule, and records it in the schedule's pause history.
+func (m ScheduleManager) Pause(scheduleID int64, by string) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	s, ok := m.DB.schedules[scheduleID]
+	if !ok || !s.Active || s.Paused {
+		return false, nil
+	}
+	s.Paused = true
+	m.DB.lastPauseID++
+	s.pauses = append(s.pauses, data.SchedulePause{
+		SchedulePauseID: m.DB.lastPauseID,
+		ScheduleID:      scheduleID,
+		PausedDate:      now(),
+		PausedBy:        by,
+	})
+	return true, nil
+}
+
+// Resume resumes a paused schedule, recalculating the next run of each crontab from the current time, and
+// optionally making one crontab due immediately to catch up on runs missed while the schedule was paused.
+func (m ScheduleManager) Resume(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	s, ok := m.DB.schedules[scheduleID]
+	if !ok || !s.Active || !s.Paused {
+		return false, nil
+	}
+
+	stored := m.DB.scheduleCrontabs(scheduleID)
+	crontabs := make([]data.Crontab, len(stored))
+	for i, ct := range stored {
+		crontabs[i] = *ct
+	}
+	at := now()
+	next, caughtUp := crontab.Resume(crontabs, s.Timezone, at, catchUp)
+	for i, ct := range stored {
+		ct.Next = next[i].UTC()
+	}
+
+	s.Paused = false
+	for i := range s.pauses {
+		if s.pauses[i].ResumedDate.IsZero() {
+			s.pauses[i].ResumedDate = at
+			s.pauses[i].ResumedBy = by
+			s.pauses[i].CatchUp = caughtUp
+		}
+	}
+	return true, nil
+}
+
+// GetScheduleByID gets a schedule's information by its ID.
+func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	s, ok := m.DB.schedules[scheduleID]
+	if !ok {
+		return
+	}
+	sc.Schedule = s.view()
+	sc.Crontabs = make([]data.Crontab, 0)
+	for _, ct := range m.DB.scheduleCrontabs(scheduleID) {
+		sc.Crontabs = append(sc.Crontabs, *ct)
+	}
+	sc.Pauses = append(make([]data.SchedulePause, 0), s.pauses...)
+	return
+}
+
+// ListSchedules lists the schedules which match the filter in ID order, starting after the afterID.
+func (m ScheduleManager) ListSchedules(filter data.ScheduleFilter, afterID int64, limit int) (scs []data.ScheduleSummary, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	var matched []*schedule
+	for _, s := range m.DB.schedules {
+		if s.ScheduleID > afterID && s.matches(filter) {
+			matched = append(matched, s)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ScheduleID < matched[j].ScheduleID })
+	scs = make([]data.ScheduleSummary, 0)
+	for _, s := range matched {
+		if len(scs) >= limit {
+			break
+		}
+		ss := data.ScheduleSummary{Schedule: s.view(), Crontabs: make([]data.Crontab, 0)}
+		for _, ct := range m.DB.scheduleCrontabs(s.ScheduleID) {
+			ss.Crontabs = append(ss.Crontabs, *ct)
+		}
+		scs = append(scs, ss)
+	}
+	return
+}
+
+func (s *schedule) matches(filter data.ScheduleFilter) bool {
+	if filter.ExternalID != "" && s.ExternalID != filter.ExternalID {
+		return false
+	}
+	if filter.By != "" && s.By != filter.By {
+		return false
+	}
+	if filter.Active != nil && s.Active != *filter.Active {
+		return false
+	}
+	if filter.ARN != "" && s.ARN != filter.ARN {
+		return false
+	}
+	if !filter.CreatedFrom.IsZero() && s.Created.Before(filter.CreatedFrom) {
+		return false
+	}
+	if !filter.CreatedTo.IsZero() && !s.Created.Before(filter.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// GetSchedule is a ScheduleGetter which leases the crontab that's been due for the longest, in order to
+// schedule jobs.
+func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	var next *data.Crontab
+	for _, ct := range m.DB.crontabs {
+		s := m.DB.schedules[ct.ScheduleID]
+		if ct.Next.After(at) || !s.Active || s.Paused || m.DB.isLeased(ct.CrontabID, at) {
+			continue
+		}
+		if next == nil || ct.Next.Before(next.Next) || (ct.Next.Equal(next.Next) && ct.CrontabID < next.CrontabID) {
+			next = ct
+		}
+	}
+	if next == nil {
+		return
+	}
+
+	m.DB.lastCrontabLeaseID++
+	m.DB.crontabLeases[m.DB.lastCrontabLeaseID] = &crontabLease{
+		crontabID: next.CrontabID,
+		lockedBy:  lockedBy,
+		at:        at,
+		until:     at.Add(time.Duration(lockExpiryMinutes) * time.Minute),
+	}
+
+	sc.Schedule = m.DB.schedules[next.ScheduleID].view()
+	sc.Crontab = *next
+	sc.CrontabLeaseID = m.DB.lastCrontabLeaseID
+	return sc, true, nil
+}
+
+// StartJobAndUpdateCron starts a new job based on the schedule record's arn and payload and updates the existing crontab to the new date.
+// If the schedule has already started its maximum number of jobs, no job is started and the returned jobID is zero.
+// It requires a crontabLeaseID so that it can be cancelled, allowing crontab refreshes at a rate faster than the lease timeout.
+func (m ScheduleManager) StartJobAndUpdateCron(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	at := now()
+	if s, ok := m.DB.schedules[scheduleID]; ok && (s.MaxRuns == 0 || s.Runs < s.MaxRuns) {
+		j, err := m.DB.insertJob(at, s.ARN, s.Payload, s.HTTPRequest, s.RetryPolicy, &scheduleID)
+		if err != nil {
+			return 0, err
+		}
+		jobID = j.JobID
+		s.Runs++
+	}
+
+	if ct, ok := m.DB.crontabs[crontabID]; ok {
+		ct.Previous = ct.Next
+		ct.Next = newNext.UTC()
+		ct.LastUpdated = at
+	}
+	m.DB.rescindCrontabLease(crontabLeaseID)
+	return jobID, nil
+}
+
+// SkipCrontab updates the crontab to the new date without starting a job, and releases the lease on it.
+func (m ScheduleManager) SkipCrontab(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	if ct, ok := m.DB.crontabs[crontabID]; ok {
+		ct.Next = newNext.UTC()
+		ct.LastUpdated = now()
+	}
+	m.DB.rescindCrontabLease(crontabLeaseID)
+	return nil
+}
+
+// PurgeCrontabLeases deletes up to limit crontab leases which expired before the cutoff.
+func (m ScheduleManager) PurgeCrontabLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	m.DB.m.Lock()
+	defer m.DB.m.Unlock()
+
+	leaseIDs := make([]int64, 0, len(m.DB.crontabLeases))
+	for id, l := range m.DB.crontabLeases {
+		if l.until.Before(expiredBefore) {
+			leaseIDs = append(leaseIDs, id)
+		}
+	}
+	sort.Slice(leaseIDs, func(i, k int) bool { return leaseIDs[i] < leaseIDs[k] })
+	for _, id := range leaseIDs {
+		if purged >= limit {
+			break
+		}
+		delete(m.DB.crontabLeases, id)
+		purged++
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) (storage.Store, func()) {
+		// Each memory store has its own database.
+		s, err := storage.Open("memory://", storage.PoolOptions{})
+		if err != nil {
+			t.Fatalf("failed to open store: %v", err)
+		}
+		return s, func() {}
+	})
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DatabaseStatsCollector exports the statistics of a database connection pool as gauges.
+type DatabaseStatsCollector struct {
+	db *sql.DB
+}
+
+// NewDatabaseStatsCollector creates a collector for the statistics of the connection pool.
+func NewDatabaseStatsCollector(db *sql.DB) DatabaseStatsCollector {
+	return DatabaseStatsCollector{
+		db: db,
+	}
+}
+
+func newDatabaseDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName("callme", "database", name), help, nil, nil)
+}
+
+var (
+	databaseMaxOpenConnectionsDesc = newDatabaseDesc("max_open_connections", "The maximum number of open connections to the database, or 0 for no limit.")
+	databaseOpenConnectionsDesc    = newDatabaseDesc("open_connections", "The number of established connections to the database, both in use and idle.")
+	databaseInUseDesc              = newDatabaseDesc("in_use_connections", "The number of connections to the database currently in use.")
+	databaseIdleDesc               = newDatabaseDesc("idle_connections", "The number of idle connections to the database.")
+	databaseWaitCountDesc          = newDatabaseDesc("wait_count", "The number of times a connection to the database was waited for.")
+	databaseWaitDurationDesc       = newDatabaseDesc("wait_duration_milliseconds", "The total time spent waiting for connections to the database.")
+	databaseMaxIdleClosedDesc      = newDatabaseDesc("max_idle_closed", "The number of connections closed because of the maximum number of idle connections.")
+	databaseMaxLifetimeClosedDesc  = newDatabaseDesc("max_lifetime_closed", "The number of connections closed because of the maximum connection lifetime.")
+)
+
+// Describe sends the descriptions of the gauges to the channel.
+func (c DatabaseStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- databaseMaxOpenConnectionsDesc
+	ch <- databaseOpenConnectionsDesc
+	ch <- databaseInUseDesc
+	ch <- databaseIdleDesc
+	ch <- databaseWaitCountDesc
+	ch <- databaseWaitDurationDesc
+	ch <- databaseMaxIdleClosedDesc
+	ch <- databaseMaxLifetimeClosedDesc
+}
+
+// Collect reads the statistics of the connection pool, and sends them to the channel.
+func (c DatabaseStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(databaseMaxOpenConnectionsDesc, prometheus.GaugeValue, float64(s.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(databaseOpenConnectionsDesc, prometheus.GaugeValue, float64(s.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(databaseInUseDesc, prometheus.GaugeValue, float64(s.InUse))
+	ch <- prometheus.MustNewConstMetric(databaseIdleDesc, prometheus.GaugeValue, float64(s.Idle))
+	ch <- prometheus.MustNewConstMetric(databaseWaitCountDesc, prometheus.GaugeValue, float64(s.WaitCount))
+	ch <- prometheus.MustNewConstMetric(databaseWaitDurationDesc, prometheus.GaugeValue, float64(s.WaitDuration.Nanoseconds()/1e6))
+	ch <- prometheus.MustNewConstMetric(databaseMaxIdleClosedDesc, prometheus.GaugeValue, float64(s.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(databaseMaxLifetimeClosedDesc, prometheus.GaugeValue, float64(s.MaxLifetimeClosed))
+}
@@ -21,6 +21,26 @@ var JobLeaseDurations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Help:      "Time taken to lease jobs",
 }, []string{"status"})
 
+// JobLeaseBatchSizes is a metric for the number of jobs leased at once by batch job workers.
+var JobLeaseBatchSizes = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "callme",
+	Subsystem: "jobworker",
+	Name:      "job_leased_batch_size",
+	Help:      "The number of jobs leased at once by batch job workers.",
+	Buckets:   []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+})
+
+// JobLeaseRenewalCounts is a metric for the number of attempts to renew the leases of executing jobs.
+var JobLeaseRenewalCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "jobworker",
+		Name:      "job_lease_renewed_total",
+		Help:      "The count of attempts to renew the leases of executing jobs.",
+	},
+	[]string{"status"},
+)
+
 // JobExecutedCounts is a metric for the number of jobs executed.
 var JobExecutedCounts = prometheus.NewCounterVec(
 	prometheus.CounterOpts{
@@ -66,3 +86,14 @@ var JobCompletedDurations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 	Name:      "job_completed_duration_milliseconds",
 	Help:      "Time taken to mark jobs as completed.",
 }, []string{"status"})
+
+// DeadLetterForwardedCounts is a metric for the number of dead letters forwarded to the dead letter ARN.
+var DeadLetterForwardedCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "jobworker",
+		Name:      "dead_letter_forwarded_total",
+		Help:      "The count of dead letters forwarded to the dead letter ARN.",
+	},
+	[]string{"status"},
+)
@@ -0,0 +1,42 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// RetentionPurgedCounts is a metric for the number of rows deleted by the retention worker.
+var RetentionPurgedCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "retention",
+		Name:      "purged_total",
+		Help:      "The count of rows deleted because they were older than the retention period.",
+	},
+	[]string{"table"},
+)
+
+// RetentionPurgeDurations is a metric for the time taken to delete each batch of rows.
+var RetentionPurgeDurations = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "callme",
+	Subsystem: "retention",
+	Name:      "purge_duration_milliseconds",
+	Help:      "Time taken to delete a batch of rows.",
+}, []string{"table", "status"})
+
+// ArchiveWrittenCounts is a metric for the number of archive files written before purging completed jobs.
+var ArchiveWrittenCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "retention",
+		Name:      "archive_written_total",
+		Help:      "The count of archive files written before purging completed jobs.",
+	},
+	[]string{"status"},
+)
+
+// ArchivedJobCounts is a metric for the number of completed jobs archived.
+var ArchivedJobCounts = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "retention",
+		Name:      "archived_jobs_total",
+		Help:      "The count of completed jobs written to archive files.",
+	})
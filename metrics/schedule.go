@@ -58,3 +58,25 @@ var ScheduleJobStartedDurations = prometheus.NewHistogramVec(prometheus.Histogra
 	Name:      "schedule_job_started_duration_milliseconds",
 	Help:      "Time taken to start jobs based on a schedule.",
 }, []string{"status"})
+
+// ScheduleDeactivatedCounts is a metric for the count of schedules deactivated because they reached their until time or maximum runs.
+var ScheduleDeactivatedCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "scheduleworker",
+		Name:      "schedule_deactivated_total",
+		Help:      "The count of schedules deactivated because they ended.",
+	},
+	[]string{"reason", "status"},
+)
+
+// ScheduleSkippedCounts is a metric for the count of scheduled runs which were skipped by a schedule's misfire policy.
+var ScheduleSkippedCounts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "callme",
+		Subsystem: "scheduleworker",
+		Name:      "schedule_skipped_total",
+		Help:      "The count of missed runs skipped by the misfire policy.",
+	},
+	[]string{"policy"},
+)
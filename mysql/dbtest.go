@@ -39,8 +39,12 @@ func CreateTestDatabase() (dsn, dbName string, err error) {
 	dsn = parsedDSN.FormatDSN()
 
 	// Fill it with schema.
-	mm := NewMigrationManager(dsn)
-	err = mm.UpdateSchema()
+	testDB, err := Open(dsn)
+	if err != nil {
+		return
+	}
+	defer testDB.Close()
+	err = NewMigrationManager(testDB).UpdateSchema()
 
 	return
 }
@@ -0,0 +1,80 @@
+package mysql
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+
+	"github.com/welldigital/callme/data"
+)
+
+// The resources which can be created with an idempotency key.
+const (
+	idempotencyResourceJob      = "job"
+	idempotencyResourceSchedule = "schedule"
+)
+
+// idempotencyKeyHash identifies the key within its resource and caller. The key and caller are too long to be indexed
+// together, and are compared case-insensitively by the default collation, so the unique index is on the hash instead.
+func idempotencyKeyHash(resource string, key data.IdempotencyKey) string {
+	h := sha256.New()
+	for _, s := range []string{resource, key.Caller, key.Key} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotently creates a resource and stores the idempotency key in the same transaction, unless a resource was
+// already created with the key, in which case its ID is returned.
+func idempotently(db *sql.DB, resource string, key data.IdempotencyKey, create func(tx *sql.Tx) (id int64, err error)) (id int64, existing bool, err error) {
+	id, existing, err = findIdempotencyKey(db, resource, key)
+	if err != nil || existing {
+		return
+	}
+	if id, err = createWithIdempotencyKey(db, resource, key, create); err == nil {
+		return
+	}
+	// A concurrent request with the same key may have committed first, in which case the unique index rejected this
+	// one, and the resource created by the other request is returned instead.
+	if otherID, found, findErr := findIdempotencyKey(db, resource, key); findErr != nil || found {
+		return otherID, found, findErr
+	}
+	return 0, false, err
+}
+
+func createWithIdempotencyKey(db *sql.DB, resource string, key data.IdempotencyKey, create func(tx *sql.Tx) (id int64, err error)) (id int64, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if id, err = create(tx); err != nil {
+		return
+	}
+	_, err = tx.Exec("INSERT INTO idempotencykey (resource, caller, idempotencykey, keyhash, requesthash, resourceid, created) "+
+		"VALUES (?, ?, ?, ?, ?, ?, utc_timestamp())", resource, key.Caller, key.Key, idempotencyKeyHash(resource, key), key.RequestHash, id)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// findIdempotencyKey returns the ID of the resource which was created with the key, if there is one.
+func findIdempotencyKey(db *sql.DB, resource string, key data.IdempotencyKey) (resourceID int64, found bool, err error) {
+	var requestHash string
+	err = db.QueryRow("SELECT requesthash, resourceid FROM idempotencykey WHERE keyhash = ?", idempotencyKeyHash(resource, key)).
+		Scan(&requestHash, &resourceID)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return
+	}
+	if requestHash != key.RequestHash {
+		return 0, false, data.ErrIdempotencyKeyConflict
+	}
+	return resourceID, true, nil
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"       // Requires MySQL
@@ -11,32 +12,223 @@ import (
 
 // JobManager provides features to manage jobs using MySQL.
 type JobManager struct {
-	ConnectionString string
+	DB *sql.DB
 }
 
 // NewJobManager creates a new JobManager.
-func NewJobManager(connectionString string) JobManager {
+func NewJobManager(db *sql.DB) JobManager {
 	return JobManager{
-		ConnectionString: connectionString,
+		DB: db,
 	}
 }
 
-// StartJob schedules a job to start in the future.
-func (m JobManager) StartJob(when time.Time, arn string, payload string, scheduleID *int64) (data.Job, error) {
+// StartJob schedules a job to start in the future, unless a job was started with the dedupe key within its dedupe
+// window, in which case that job is returned.
+func (m JobManager) StartJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j data.Job, duplicate bool, err error) {
+	if dedupeKey == "" {
+		j, err = startJob(m.DB, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return
+	}
+	jobID, duplicate, err := dedupe(m.DB, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return j.JobID, err
+	})
+	if err != nil || !duplicate {
+		j.DedupeKey = dedupeKey
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	return j, true, err
+}
+
+// StartJobIdempotently starts a job, unless a job was already started with the idempotency key, in which case that
+// job is returned.
+func (m JobManager) StartJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	j, existing, duplicate, err = m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+	if err == nil || dedupeKey == "" {
+		return
+	}
+	// A concurrent request with the same dedupe key may have committed first, in which case the unique index rejected
+	// this one. Starting the job again finds the other request's job, and records the key against it.
+	if _, held, findErr := findDedupeKey(m.DB, dedupeKey); findErr != nil || !held {
+		return
+	}
+	return m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+}
+
+func (m JobManager) startJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	jobID, existing, err := idempotently(m.DB, idempotencyResourceJob, key, func(tx *sql.Tx) (int64, error) {
+		if dedupeKey == "" {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		}
+		var jobID int64
+		jobID, duplicate, err = startJobWithDedupeKey(tx, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		})
+		j.DedupeKey = dedupeKey
+		return jobID, err
+	})
+	if err != nil || (!existing && !duplicate) {
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	// If the job was started by a concurrent request with the same idempotency key, it's not a duplicate.
+	return j, existing, duplicate && !existing, err
+}
+
+// StartJobs starts a batch of jobs in a single transaction, so either all of the jobs are started, or none are. Jobs
+// without a dedupe key are inserted with a single statement, jobs with a dedupe key are started one at a time, so that
+// each key is checked against the jobs started before it.
+func (m JobManager) StartJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	if started, err = m.startJobs(jobs, dedupeWindow); err == nil {
+		return
+	}
+	// A concurrent request may have taken one of the batch's dedupe keys first, in which case the unique index
+	// rejected this batch. Starting the batch again finds the other request's job, and returns it as a duplicate.
+	if held, findErr := anyDedupeKeyHeld(m.DB, jobs); findErr != nil || !held {
+		return nil, err
+	}
+	return m.startJobs(jobs, dedupeWindow)
+}
+
+func (m JobManager) startJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	started = make([]data.StartedJob, len(jobs))
+	var undeduped []data.Job
+	var undedupedIndexes []int
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			undeduped = append(undeduped, j)
+			undedupedIndexes = append(undedupedIndexes, i)
+		}
+	}
+	inserted, err := insertJobs(tx, undeduped)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := range inserted {
+		started[undedupedIndexes[i]].Job = j
+	}
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			continue
+		}
+		if started[i], err = startDedupedJob(tx, j, dedupeWindow); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	// Duplicates are returned as they are now, which may be a job started earlier in the batch.
+	for i := range started {
+		if !started[i].Duplicate {
+			continue
+		}
+		if started[i].Job, err = m.getStartedJob(started[i].Job.JobID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func startDedupedJob(tx *sql.Tx, j data.Job, dedupeWindow time.Duration) (s data.StartedJob, err error) {
+	jobID, duplicate, err := startJobWithDedupeKey(tx, j.DedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		s.Job, err = startJob(tx, j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, j.ScheduleID)
+		return s.Job.JobID, err
+	})
+	if duplicate {
+		return data.StartedJob{Job: data.Job{JobID: jobID}, Duplicate: true}, err
+	}
+	s.Job.DedupeKey = j.DedupeKey
+	return s, err
+}
+
+// insertJobs inserts the jobs with a single statement, and returns them with their IDs. InnoDB allocates consecutive
+// IDs to the rows of a multi-row INSERT, and LAST_INSERT_ID() is the first of them.
+func insertJobs(tx *sql.Tx, jobs []data.Job) (inserted []data.Job, err error) {
+	if len(jobs) == 0 {
+		return
+	}
+	var valuesSQL string
+	var valuesArgs []interface{}
+	inserted = make([]data.Job, len(jobs))
+	for i, j := range jobs {
+		inserted[i] = data.Job{
+			ARN:         j.ARN,
+			Payload:     j.Payload,
+			HTTPRequest: j.HTTPRequest,
+			RetryPolicy: j.RetryPolicy,
+			ScheduleID:  j.ScheduleID,
+			When:        j.When,
+		}
+		httpRequestJSON, err := marshalHTTPRequest(j.HTTPRequest)
+		if err != nil {
+			return nil, err
+		}
+		retryPolicyJSON, err := marshalRetryPolicy(j.RetryPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			valuesSQL = "(?, ?, ?, ?, ?, ?)"
+		} else {
+			valuesSQL += ", (?, ?, ?, ?, ?, ?)"
+		}
+		valuesArgs = append(valuesArgs, j.ARN, j.Payload, httpRequestJSON, retryPolicyJSON, j.ScheduleID, j.When)
+	}
+	result, err := tx.Exec("INSERT INTO `job` (arn, payload, httprequest, retrypolicy, idschedule, `when`) VALUES "+valuesSQL, valuesArgs...)
+	if err != nil {
+		return nil, err
+	}
+	firstID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	for i := range inserted {
+		inserted[i].JobID = firstID + int64(i)
+	}
+	return
+}
+
+// getStartedJob gets a job which was started by an earlier request.
+func (m JobManager) getStartedJob(jobID int64) (j data.Job, err error) {
+	j, _, ok, _, err := m.GetJobResponse(jobID)
+	if !ok {
+		// The job has been deleted since it was started.
+		j = data.Job{JobID: jobID}
+	}
+	return j, err
+}
+
+// startJob inserts a job using either the *sql.DB or a *sql.Tx.
+func startJob(q rowQueryer, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64) (data.Job, error) {
 	j := data.Job{
-		ARN:        arn,
-		Payload:    payload,
-		ScheduleID: scheduleID,
-		When:       when,
+		ARN:         arn,
+		Payload:     payload,
+		HTTPRequest: httpRequest,
+		RetryPolicy: retryPolicy,
+		ScheduleID:  scheduleID,
+		When:        when,
 	}
 
-	db, err := sql.Open("mysql", m.ConnectionString)
+	httpRequestJSON, err := marshalHTTPRequest(j.HTTPRequest)
+	if err != nil {
+		return j, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(j.RetryPolicy)
 	if err != nil {
 		return j, err
 	}
-	defer db.Close()
 
-	row := db.QueryRow("call jm_startjob(?, ?, ?, ?)", j.ARN, j.Payload, j.ScheduleID, j.When)
+	row := q.QueryRow("call jm_startjob(?, ?, ?, ?, ?, ?)", j.ARN, j.Payload, httpRequestJSON, retryPolicyJSON, j.ScheduleID, j.When)
 	err = row.Scan(&j.JobID)
 	return j, err
 }
@@ -46,13 +238,7 @@ func (m JobManager) StartJob(when time.Time, arn string, payload string, schedul
 func (m JobManager) GetAvailableJobCount() (int, error) {
 	count := 0
 
-	db, err := sql.Open("mysql", m.ConnectionString)
-	if err != nil {
-		return count, err
-	}
-	defer db.Close()
-
-	rows, err := db.Query("call jm_getavailablejobcount()")
+	rows, err := m.DB.Query("call jm_getavailablejobcount()")
 	if err != nil {
 		return count, err
 	}
@@ -64,37 +250,65 @@ func (m JobManager) GetAvailableJobCount() (int, error) {
 	return count, err
 }
 
-// GetJob retrieves a job that's ready to run from the queue.
-func (m JobManager) GetJob(lockedBy string, lockExpiryMinutes int) (j data.Job, ok bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
-	if err != nil {
-		return
-	}
-	defer db.Close()
-
-	rows, err := db.Query("call jm_getjob(?, ?)", lockedBy, lockExpiryMinutes)
+// GetJob leases a job that's ready to run from the queue.
+func (m JobManager) GetJob(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+	rows, err := m.DB.Query("call jm_getjob(?, ?)", lockedBy, lockExpiryMinutes)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload)
+		j := &lj.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &lj.JobLeaseID)
+		if err != nil {
+			return
+		}
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
 		ok = true
 		break
 	}
 	return
 }
 
-// GetJobResponse retrieves a completed job's data.
-func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
+// GetJobs leases up to limit jobs that are ready to run from the queue, in the order they're due.
+func (m JobManager) GetJobs(lockedBy string, lockExpiryMinutes int, limit int) (jobs []data.LeasedJob, err error) {
+	rows, err := m.DB.Query("call jm_getjobs(?, ?, ?)", lockedBy, lockExpiryMinutes, limit)
 	if err != nil {
 		return
 	}
-	defer db.Close()
+	defer rows.Close()
+
+	for rows.Next() {
+		var lj data.LeasedJob
+		j := &lj.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &lj.JobLeaseID)
+		if err != nil {
+			return
+		}
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		jobs = append(jobs, lj)
+	}
+	err = rows.Err()
+	return
+}
 
-	rows, err := db.Query("call jm_getjobresponse(?)", jobID)
+// GetJobResponse retrieves a completed job's data.
+func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+	rows, err := m.DB.Query("call jm_getjobresponse(?)", jobID)
 	if err != nil {
 		return
 	}
@@ -105,9 +319,18 @@ func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse,
 		var jrID, jrJobID sql.NullInt64
 		var jrTime gomysql.NullTime
 		var jrResp, jrIsErrorStr, jrError sql.NullString
+		var httpRequestJSON, retryPolicyJSON sql.NullString
 
-		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload,
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount,
 			&jrID, &jrJobID, &jrTime, &jrResp, &jrIsErrorStr, &jrError)
+		if err != nil {
+			return
+		}
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
 
 		if jrID.Int64 > 0 {
 			r.JobResponseID = jrID.Int64
@@ -119,6 +342,12 @@ func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse,
 		}
 		break
 	}
+	if err != nil || !jobOK {
+		return j, r, jobOK, r.JobResponseID > 0, err
+	}
+	// The procedure's results have to be read before the connection can be used for another query.
+	rows.Close()
+	j.DedupeKey, err = getDedupeKey(m.DB, jobID)
 	return j, r, jobOK, r.JobResponseID > 0, err
 }
 
@@ -129,13 +358,8 @@ func convertMySQLBoolean(s string) bool {
 	return false
 }
 
-// CompleteJob marks a job as complete.
-func (m JobManager) CompleteJob(jobID int64, resp string, jobError error) error {
-	db, err := sql.Open("mysql", m.ConnectionString)
-	if err != nil {
-		return err
-	}
-	defer db.Close()
+// CompleteJob marks a job as complete if the lease is current, otherwise the response is recorded as a duplicate.
+func (m JobManager) CompleteJob(jobID, jobLeaseID int64, resp string, jobError error) (duplicate bool, err error) {
 	var isError bool
 	if jobError != nil {
 		isError = true
@@ -144,19 +368,294 @@ func (m JobManager) CompleteJob(jobID int64, resp string, jobError error) error
 	if jobError != nil {
 		errorString = jobError.Error()
 	}
-	_, err = db.Exec("call jm_completejob(?, ?, ?, ?)",
-		jobID, resp, isError, errorString)
-	return err
+	err = m.DB.QueryRow("call jm_completejob(?, ?, ?, ?, ?)",
+		jobID, jobLeaseID, resp, isError, errorString).Scan(&duplicate)
+	return
 }
 
-// DeleteJob deletes a job.
-func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
+// CompleteJobs marks a batch of jobs as complete. The attempts and responses are each inserted with a single
+// statement, so that the batch takes a fixed number of round trips to the database. Completions whose leases aren't
+// current are recorded as duplicates.
+func (m JobManager) CompleteJobs(completions []data.JobCompletion) (duplicateJobIDs []int64, err error) {
+	if len(completions) == 0 {
+		return
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, duplicates, err := partitionCompletions(tx, completions)
+	if err != nil {
+		return
+	}
+
+	if len(current) > 0 {
+		// Each completion is a row of a derived table, so that the attempt number can be counted for each job.
+		var completionSQL, responseSQL string
+		var completionArgs, responseArgs []interface{}
+		for i, c := range current {
+			var errorString string
+			if c.Err != nil {
+				errorString = c.Err.Error()
+			}
+			if i == 0 {
+				completionSQL = "SELECT ? AS idjob, ? AS response, ? AS iserror, ? AS `error`"
+				responseSQL = "(?, utc_timestamp(), ?, ?, ?)"
+			} else {
+				completionSQL += " UNION ALL SELECT ?, ?, ?, ?"
+				responseSQL += ", (?, utc_timestamp(), ?, ?, ?)"
+			}
+			completionArgs = append(completionArgs, c.JobID, c.Response, c.Err != nil, errorString)
+			responseArgs = append(responseArgs, c.JobID, c.Response, c.Err != nil, errorString)
+		}
+
+		_, err = tx.Exec("INSERT INTO jobattempt (idjob, attempt, `time`, response, iserror, `error`, retryat) "+
+			"SELECT c.idjob, (SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = c.idjob) + 1, utc_timestamp(), c.response, c.iserror, c.`error`, NULL "+
+			"FROM ("+completionSQL+") c", completionArgs...)
+		if err != nil {
+			return
+		}
+		_, err = tx.Exec("INSERT INTO jobresponse (idjob, `time`, response, iserror, `error`) VALUES "+responseSQL, responseArgs...)
+		if err != nil {
+			return
+		}
+		// Release the jobs' dedupe keys, so that the keys can start new jobs.
+		jobIDs := make([]int64, len(current))
+		for i, c := range current {
+			jobIDs[i] = c.JobID
+		}
+		if _, err = tx.Exec("DELETE FROM jobdedupe WHERE idjob IN "+in(len(jobIDs)), args(jobIDs)...); err != nil {
+			return
+		}
+	}
+
+	if len(duplicates) > 0 {
+		var duplicateSQL string
+		var duplicateArgs []interface{}
+		for i, c := range duplicates {
+			var errorString string
+			if c.Err != nil {
+				errorString = c.Err.Error()
+			}
+			if i == 0 {
+				duplicateSQL = "(?, ?, utc_timestamp(), ?, ?, ?)"
+			} else {
+				duplicateSQL += ", (?, ?, utc_timestamp(), ?, ?, ?)"
+			}
+			duplicateArgs = append(duplicateArgs, c.JobID, c.JobLeaseID, c.Response, c.Err != nil, errorString)
+			duplicateJobIDs = append(duplicateJobIDs, c.JobID)
+		}
+		_, err = tx.Exec("INSERT INTO jobduplicate (idjob, idjoblease, `time`, response, iserror, `error`) VALUES "+duplicateSQL, duplicateArgs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// partitionCompletions locks the jobs, and splits the completions into those whose leases are current, and those
+// which are duplicates. A job which is completed more than once in the same batch is only completed by the first.
+func partitionCompletions(tx *sql.Tx, completions []data.JobCompletion) (current, duplicates []data.JobCompletion, err error) {
+	jobIDs := make([]int64, len(completions))
+	for i, c := range completions {
+		jobIDs[i] = c.JobID
+	}
+	rows, err := tx.Query("SELECT j.idjob, (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = j.idjob) "+
+		"FROM `job` j "+
+		"WHERE j.idjob IN "+in(len(jobIDs))+" AND NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) "+
+		"FOR UPDATE", args(jobIDs)...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	currentLeaseIDs := make(map[int64]int64)
+	for rows.Next() {
+		var jobID int64
+		var leaseID sql.NullInt64
+		if err = rows.Scan(&jobID, &leaseID); err != nil {
+			return
+		}
+		if leaseID.Valid {
+			currentLeaseIDs[jobID] = leaseID.Int64
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, c := range completions {
+		if leaseID, ok := currentLeaseIDs[c.JobID]; ok && leaseID == c.JobLeaseID {
+			current = append(current, c)
+			delete(currentLeaseIDs, c.JobID)
+			continue
+		}
+		duplicates = append(duplicates, c)
+	}
+	return
+}
+
+// RenewJobLease extends a lease on a job, so that it expires lockExpiryMinutes from now. It returns false if the
+// lease has expired, been released, or been taken over by another worker.
+func (m JobManager) RenewJobLease(jobID, jobLeaseID int64, lockExpiryMinutes int) (ok bool, err error) {
+	var renewed int
+	err = m.DB.QueryRow("call jm_renewjoblease(?, ?, ?)", jobID, jobLeaseID, lockExpiryMinutes).Scan(&renewed)
+	ok = renewed > 0
+	return
+}
+
+// RetryJob records a failed attempt at a job and reschedules it to run again at the retryAt time, releasing
+// the lease so that any worker can pick it up. If the lease isn't current, the attempt is recorded as a duplicate.
+func (m JobManager) RetryJob(jobID, jobLeaseID int64, resp string, jobError error, retryAt time.Time) (duplicate bool, err error) {
+	var errorString string
+	if jobError != nil {
+		errorString = jobError.Error()
+	}
+	err = m.DB.QueryRow("call jm_retryjob(?, ?, ?, ?, ?)",
+		jobID, jobLeaseID, resp, errorString, retryAt).Scan(&duplicate)
+	return
+}
+
+// GetJobDuplicates gets the duplicate executions of a job, in order.
+func (m JobManager) GetJobDuplicates(jobID int64) (duplicates []data.JobDuplicate, err error) {
+	rows, err := m.DB.Query("SELECT idjobduplicate, idjob, idjoblease, `time`, response, iserror, `error` "+
+		"FROM jobduplicate WHERE idjob = ? ORDER BY idjobduplicate ASC", jobID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	duplicates = make([]data.JobDuplicate, 0)
+	for rows.Next() {
+		var d data.JobDuplicate
+		var isErrorStr string
+		if err = rows.Scan(&d.JobDuplicateID, &d.JobID, &d.JobLeaseID, &d.Time, &d.Response, &isErrorStr, &d.Error); err != nil {
+			return
+		}
+		d.IsError = convertMySQLBoolean(isErrorStr)
+		duplicates = append(duplicates, d)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetJobAttempts gets the attempts made to execute a job, in order.
+func (m JobManager) GetJobAttempts(jobID int64) (attempts []data.JobAttempt, err error) {
+	rows, err := m.DB.Query("call jm_getjobattempts(?)", jobID)
 	if err != nil {
 		return
 	}
-	defer db.Close()
-	result, err := db.Exec("call jm_deletejob(?)", jobID)
+	defer rows.Close()
+
+	attempts = make([]data.JobAttempt, 0)
+	for rows.Next() {
+		var a data.JobAttempt
+		var isErrorStr string
+		var retryAt gomysql.NullTime
+		err = rows.Scan(&a.JobAttemptID, &a.JobID, &a.Attempt, &a.Time, &a.Response, &isErrorStr, &a.Error, &retryAt)
+		if err != nil {
+			return
+		}
+		a.IsError = convertMySQLBoolean(isErrorStr)
+		a.RetryAt = retryAt.Time
+		attempts = append(attempts, a)
+	}
+	return
+}
+
+// leasedSQL matches jobs which have a lease that hasn't expired.
+const leasedSQL = "EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())"
+
+// ListJobs lists the jobs which match the filter in ID order, starting after the afterID.
+func (m JobManager) ListJobs(filter data.JobFilter, afterID int64, limit int) (jobs []data.JobSummary, err error) {
+	where := []string{"j.idjob > ?"}
+	args := []interface{}{afterID}
+	if filter.ScheduleID != nil {
+		where = append(where, "j.idschedule = ?")
+		args = append(args, *filter.ScheduleID)
+	}
+	switch filter.State {
+	case data.JobStatePending:
+		where = append(where, "jr.idjob IS NULL AND NOT "+leasedSQL)
+	case data.JobStateLeased:
+		where = append(where, "jr.idjob IS NULL AND "+leasedSQL)
+	case data.JobStateSucceeded:
+		where = append(where, "jr.iserror = 0")
+	case data.JobStateFailed:
+		where = append(where, "jr.iserror = 1")
+	}
+	if !filter.WhenFrom.IsZero() {
+		where = append(where, "j.`when` >= ?")
+		args = append(args, filter.WhenFrom.UTC())
+	}
+	if !filter.WhenTo.IsZero() {
+		where = append(where, "j.`when` < ?")
+		args = append(args, filter.WhenTo.UTC())
+	}
+	if filter.ARNPrefix != "" {
+		where = append(where, "j.arn LIKE ?")
+		args = append(args, likePrefix(filter.ARNPrefix))
+	}
+	if filter.IsError != nil {
+		where = append(where, "EXISTS (SELECT 1 FROM jobattempt ja WHERE ja.idjob = j.idjob AND ja.iserror = 1) = ?")
+		args = append(args, *filter.IsError)
+	}
+	args = append(args, limit)
+	rows, err := m.DB.Query("SELECT "+
+		"j.idjob, j.idschedule, j.`when`, j.arn, j.payload, j.httprequest, j.retrypolicy, "+
+		"(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount, "+
+		"CASE WHEN jr.iserror = 1 THEN 'failed' WHEN jr.idjob IS NOT NULL THEN 'succeeded' "+
+		"WHEN "+leasedSQL+" THEN 'leased' ELSE 'pending' END AS state "+
+		"FROM `job` j "+
+		"LEFT JOIN jobresponse jr ON jr.idjob = j.idjob "+
+		"WHERE "+strings.Join(where, " AND ")+" "+
+		"ORDER BY j.idjob ASC "+
+		"LIMIT ?", args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	jobs = make([]data.JobSummary, 0)
+	for rows.Next() {
+		var js data.JobSummary
+		j := &js.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &js.State)
+		if err != nil {
+			return
+		}
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		jobs = append(jobs, js)
+	}
+	err = rows.Err()
+	return
+}
+
+// likePrefix returns a LIKE pattern which matches strings that start with the prefix.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// DeleteJob deletes a job.
+func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
+	result, err := m.DB.Exec("call jm_deletejob(?)", jobID)
 	if err != nil {
 		return
 	}
@@ -164,3 +663,255 @@ func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
 	ok = affected > 0
 	return
 }
+
+// DeadLetterJob records the final failed attempt at a job, completes it with an error and marks it as a dead letter.
+// If the lease isn't current, the attempt is recorded as a duplicate instead.
+func (m JobManager) DeadLetterJob(j data.Job, jobLeaseID int64, resp string, jobError error) (deadLetterID int64, duplicate bool, err error) {
+	var errorString string
+	if jobError != nil {
+		errorString = jobError.Error()
+	}
+	row := m.DB.QueryRow("call jm_deadletterjob(?, ?, ?, ?)", j.JobID, jobLeaseID, resp, errorString)
+	err = row.Scan(&deadLetterID, &duplicate)
+	return
+}
+
+// RecordDeadLetterForward records the result of forwarding a dead letter to the dead letter ARN.
+func (m JobManager) RecordDeadLetterForward(deadLetterID int64, forwardedTo string, forwardError error) error {
+	var errorString sql.NullString
+	if forwardError != nil {
+		errorString.String = forwardError.Error()
+		errorString.Valid = true
+	}
+	_, err := m.DB.Exec("call jm_recorddeadletterforward(?, ?, ?)", deadLetterID, forwardedTo, errorString)
+	return err
+}
+
+// GetDeadLetters lists dead letters in ID order, starting after the afterID.
+func (m JobManager) GetDeadLetters(requeued bool, afterID int64, limit int) (dls []data.DeadLetter, err error) {
+	rows, err := m.DB.Query("call jm_getdeadletters(?, ?, ?)", requeued, afterID, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	dls = make([]data.DeadLetter, 0)
+	for rows.Next() {
+		var dl data.DeadLetter
+		var forwardedTo, forwardError sql.NullString
+		var requeuedJobID sql.NullInt64
+		var requeuedDate gomysql.NullTime
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&dl.DeadLetterID, &dl.Time, &dl.Error, &forwardedTo, &forwardError, &requeuedJobID, &requeuedDate,
+			&dl.Job.JobID, &dl.Job.ScheduleID, &dl.Job.When, &dl.Job.ARN, &dl.Job.Payload, &httpRequestJSON, &retryPolicyJSON, &dl.Job.AttemptCount)
+		if err != nil {
+			return
+		}
+		dl.ForwardedTo = forwardedTo.String
+		dl.ForwardError = forwardError.String
+		if requeuedJobID.Valid {
+			dl.RequeuedJobID = &requeuedJobID.Int64
+		}
+		dl.RequeuedDate = requeuedDate.Time
+		dl.Job.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		dl.Job.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		dls = append(dls, dl)
+	}
+	return
+}
+
+// RequeueDeadLetters creates a new job for each dead letter, returning a map of dead letter ID to new job ID.
+func (m JobManager) RequeueDeadLetters(deadLetterIDs []int64) (jobIDs map[int64]int64, err error) {
+	jobIDs = make(map[int64]int64)
+	for _, id := range deadLetterIDs {
+		var jobID int64
+		var ok bool
+		jobID, ok, err = requeueDeadLetter(m.DB, id)
+		if err != nil {
+			return
+		}
+		if ok {
+			jobIDs[id] = jobID
+		}
+	}
+	return
+}
+
+func requeueDeadLetter(db *sql.DB, deadLetterID int64) (jobID int64, ok bool, err error) {
+	rows, err := db.Query("call jm_requeuedeadletter(?)", deadLetterID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err = rows.Scan(&jobID)
+		ok = err == nil
+	}
+	return
+}
+
+// PurgeJobs deletes up to limit jobs which were completed before the cutoff, along with their responses, attempts,
+// duplicates and leases. Jobs which have been dead lettered are kept.
+func (m JobManager) PurgeJobs(completedBefore time.Time, limit int) (purged int, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	jobIDs, err := queryJobIDs(tx, "SELECT jr.idjob FROM jobresponse jr "+
+		"WHERE jr.`time` < ? AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) "+
+		"ORDER BY jr.`time` ASC LIMIT ? FOR UPDATE", completedBefore.UTC(), limit)
+	if err != nil || len(jobIDs) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, jobIDs); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(jobIDs), nil
+}
+
+// GetCompletedJobs gets up to limit jobs which were completed before the cutoff, oldest first, with their responses,
+// attempts and duplicates. Jobs which have been dead lettered aren't included.
+func (m JobManager) GetCompletedJobs(completedBefore time.Time, limit int) (jrs []data.JobAndResponse, err error) {
+	jobIDs, err := queryJobIDs(m.DB, "SELECT jr.idjob FROM jobresponse jr "+
+		"WHERE jr.`time` < ? AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) "+
+		"ORDER BY jr.`time` ASC LIMIT ?", completedBefore.UTC(), limit)
+	if err != nil {
+		return
+	}
+	jrs = make([]data.JobAndResponse, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		var jr data.JobAndResponse
+		var jobOK bool
+		jr.Job, jr.JobResponse, jobOK, jr.HasJobResponse, err = m.GetJobResponse(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if !jobOK {
+			// The job was purged in the meantime.
+			continue
+		}
+		if jr.Attempts, err = m.GetJobAttempts(jobID); err != nil {
+			return nil, err
+		}
+		if jr.Duplicates, err = m.GetJobDuplicates(jobID); err != nil {
+			return nil, err
+		}
+		jrs = append(jrs, jr)
+	}
+	return
+}
+
+// DeleteCompletedJobs deletes the jobs, along with their responses, attempts, duplicates and leases. Jobs which
+// haven't been completed, or have been dead lettered, are kept.
+func (m JobManager) DeleteCompletedJobs(jobIDs []int64) (deleted int, err error) {
+	if len(jobIDs) == 0 {
+		return
+	}
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	completed, err := queryJobIDs(tx, "SELECT jr.idjob FROM jobresponse jr "+
+		"WHERE jr.idjob IN "+in(len(jobIDs))+" AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) "+
+		"FOR UPDATE", args(jobIDs)...)
+	if err != nil || len(completed) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, completed); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(completed), nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// rowQueryer is implemented by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// queryJobIDs runs a query which returns job IDs.
+func queryJobIDs(q queryer, query string, args ...interface{}) (jobIDs []int64, err error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int64
+		if err = rows.Scan(&jobID); err != nil {
+			return
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+	return jobIDs, rows.Err()
+}
+
+// deleteJobs deletes the jobs, and the rows which reference them before the jobs themselves.
+func deleteJobs(tx *sql.Tx, jobIDs []int64) error {
+	for _, table := range []string{"jobattempt", "jobduplicate", "joblease", "jobresponse", "`job`"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE idjob IN "+in(len(jobIDs)), args(jobIDs)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// in returns a list of n placeholders for an IN clause, e.g. (?, ?).
+func in(n int) string {
+	return "(?" + strings.Repeat(", ?", n-1) + ")"
+}
+
+// args converts IDs to query arguments.
+func args(ids []int64) []interface{} {
+	a := make([]interface{}, len(ids))
+	for i, id := range ids {
+		a[i] = id
+	}
+	return a
+}
+
+// PurgeJobLeases deletes up to limit job leases which expired before the cutoff.
+func (m JobManager) PurgeJobLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, "DELETE FROM joblease WHERE `until` < ? ORDER BY `until` ASC LIMIT ?", expiredBefore, limit)
+}
+
+// PurgeIdempotencyKeys deletes up to limit idempotency keys which were created before the cutoff.
+func (m JobManager) PurgeIdempotencyKeys(createdBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, "DELETE FROM idempotencykey WHERE created < ? ORDER BY created ASC LIMIT ?", createdBefore, limit)
+}
+
+// PurgeDedupeKeys deletes up to limit dedupe keys whose windows ended before the cutoff.
+func (m JobManager) PurgeDedupeKeys(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, "DELETE FROM jobdedupe WHERE expires < ? ORDER BY expires ASC LIMIT ?", expiredBefore, limit)
+}
+
+// purge runs a DELETE statement which takes a cutoff time and a limit, and returns the number of rows deleted.
+func purge(db *sql.DB, query string, before time.Time, limit int) (purged int, err error) {
+	res, err := db.Exec(query, before.UTC(), limit)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/welldigital/callme/mysql/migrations"
@@ -36,3 +38,73 @@ func TestMigrationsCanBeLoaded(t *testing.T) {
 		t.Error("got version 0, should have been different")
 	}
 }
+
+func TestThatMigrationsStartAtTheFirstVersion(t *testing.T) {
+	driver, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	defer driver.Close()
+
+	v, err := driver.First()
+	if err != nil {
+		t.Fatalf("failed to find any migrations: %v", err)
+	}
+	if v != 1 {
+		t.Errorf("expected the first version to be 1, got %v", v)
+	}
+}
+
+func TestThatEveryMigrationIsInBinData(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("expected to find migration files")
+	}
+	names := map[string]bool{}
+	for _, name := range migrations.AssetNames() {
+		names[name] = true
+	}
+	for _, f := range files {
+		if !names[filepath.Base(f)] {
+			t.Errorf("migration '%v' is missing from the bindata, run make in the mysql directory", filepath.Base(f))
+		}
+	}
+}
+
+func TestThatEveryMigrationCanBeReverted(t *testing.T) {
+	names := map[string]bool{}
+	for _, name := range migrations.AssetNames() {
+		names[name] = true
+	}
+	for name := range names {
+		if strings.HasSuffix(name, ".up.sql") && !names[strings.TrimSuffix(name, ".up.sql")+".down.sql"] {
+			t.Errorf("migration '%v' doesn't have a down migration", name)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	first, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	defer first.Close()
+	v, err := first.First()
+	if err != nil {
+		t.Fatalf("failed to find any migrations: %v", err)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatalf("failed to get the latest version: %v", err)
+	}
+	if latest <= v {
+		t.Errorf("expected the latest version to be after the first version %v, got %v", v, latest)
+	}
+	if _, _, err = first.ReadUp(latest); err != nil {
+		t.Errorf("expected to be able to read the latest migration %v: %v", latest, err)
+	}
+}
@@ -1 +1,6 @@
+DROP TABLE crontablease;
+DROP TABLE crontab;
+DROP TABLE jobresponse;
+DROP TABLE joblease;
 DROP TABLE `job`;
+DROP TABLE `schedule`;
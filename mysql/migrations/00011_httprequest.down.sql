@@ -0,0 +1,177 @@
+DROP PROCEDURE IF EXISTS `jm_startjob`;
+DROP PROCEDURE IF EXISTS `jm_getjob`;
+DROP PROCEDURE IF EXISTS `jm_getjobresponse`;
+DROP PROCEDURE IF EXISTS `sm_getschedule`;
+DROP PROCEDURE IF EXISTS `sm_getschedulebyid`;
+DROP PROCEDURE IF EXISTS `sm_startjobandupdatecron`;
+
+ALTER TABLE `job` DROP COLUMN `httprequest`;
+ALTER TABLE `schedule` DROP COLUMN `httprequest`;
+
+-- Restore the previous version of jm_startjob.
+CREATE PROCEDURE `jm_startjob`(arn VARCHAR(2048), payload MEDIUMTEXT, idschedule INT, `when` DATETIME(6))
+BEGIN
+	INSERT `job` SET arn=arn, payload=payload, idschedule=idschedule, `when`=`when`;
+	SELECT LAST_INSERT_ID() as idjob;
+END;
+
+-- Restore the previous version of jm_getjob.
+CREATE PROCEDURE `jm_getjob`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`) 				
+		SELECT 
+			j.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+			LEFT JOIN joblease jl ON jl.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			((jl.idjoblease IS NULL) OR (jl.until < utc_timestamp()))
+		ORDER BY j.when ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				j.idjob, 
+				j.idschedule, 
+				j.`when`, 
+				j.arn, 
+				j.payload 
+			FROM 
+				`job` j
+				INNER JOIN joblease jl ON j.idjob = jl.idjob
+			WHERE 
+				jl.idjoblease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+-- Restore the previous version of jm_getjobresponse.
+CREATE PROCEDURE `jm_getjobresponse`(idjob int)
+BEGIN
+	SELECT
+		j.idjob, j.idschedule, j.`when`, j.arn, j.payload, 
+		jr.idjobresponse, jr.idjob, jr.`time`, jr.response, jr.iserror, jr.`error` 
+		FROM `job` j 
+		LEFT JOIN `jobresponse` jr ON jr.idjob = j.idjob 
+		WHERE 
+			j.idjob=idjob;
+END;
+
+-- Restore the previous version of sm_getschedule.
+CREATE PROCEDURE `sm_getschedule`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO crontablease (idcrontab, lockedby, `at`, `until`) 				
+		SELECT 
+			ct.idcrontab,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM
+			`crontab` ct
+		 	INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+			LEFT JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+		WHERE
+			ct.next <= utc_timestamp() AND
+			sc.active = 1 AND
+			(ctl.idcrontab IS NULL OR ctl.rescinded = 1 OR ctl.until < utc_timestamp())
+		ORDER BY ct.next ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				ctl.idcrontablease,
+				sc.`idschedule`, 
+				sc.`externalid`, 
+				sc.`by`,
+				sc.`arn`,
+				sc.`payload`,
+				sc.`created`,
+				sc.`active`,
+				sc.`deactivateddate`,
+				ct.`idcrontab`,
+				ct.`idschedule`, 
+				ct.`crontab`,
+				ct.`previous`,
+				ct.`next`,
+				ct.`lastupdated`
+			FROM 
+				`crontab` ct
+				INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+				INNER JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+			WHERE 
+				ctl.idcrontablease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+-- Restore the previous version of sm_getschedulebyid.
+CREATE PROCEDURE `sm_getschedulebyid`(idschedule INT)
+BEGIN
+	SELECT 
+		sc.`idschedule`, 
+		sc.`externalid`, 
+		sc.`by`,
+		sc.`arn`,
+		sc.`payload`,
+		sc.`created`,
+		sc.`active`,
+		sc.`deactivateddate`,
+		ct.`idcrontab`,
+		ct.`idschedule`, 
+		ct.`crontab`,
+		ct.`previous`,
+		ct.`next`,
+		ct.`lastupdated`
+	FROM 
+		`crontab` ct
+		INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+	WHERE 
+		sc.idschedule = idschedule;
+END;
+
+-- Restore the previous version of sm_startjobandupdatecron.
+CREATE PROCEDURE `sm_startjobandupdatecron`(idschedule int, idcrontab int, idcrontablease int, nextjob timestamp)
+BEGIN
+	START TRANSACTION;
+		SET @lastID=LAST_INSERT_ID(0);
+
+		INSERT INTO `job` (arn, payload, idschedule, `when`)
+		SELECT 
+			s.arn, 
+			s.payload, 
+			s.idschedule, 
+			utc_timestamp() 
+		FROM schedule s
+		WHERE 
+			s.idschedule=idschedule;
+
+		SET @lastID=LAST_INSERT_ID();
+
+		UPDATE crontab ct
+		SET
+			ct.previous=ct.next,
+			ct.next=nextjob,
+			ct.lastupdated=utc_timestamp()
+		WHERE
+			ct.idcrontab=idcrontab;
+
+		UPDATE crontablease ctl
+		SET 
+			rescinded = 1
+		WHERE 
+			ctl.idcrontablease=idcrontablease;
+
+		SELECT @lastID;
+    COMMIT;
+END;
@@ -0,0 +1,263 @@
+ALTER TABLE `job` ADD COLUMN `retrypolicy` MEDIUMTEXT NULL;
+
+ALTER TABLE `schedule` ADD COLUMN `retrypolicy` MEDIUMTEXT NULL;
+
+CREATE TABLE `jobattempt` (
+  `idjobattempt` INT NOT NULL AUTO_INCREMENT,
+  `idjob` INT NOT NULL,
+  `attempt` INT NOT NULL,
+  `time` DATETIME(6) NOT NULL,
+  `response` MEDIUMTEXT NOT NULL,
+  `iserror` BIT NOT NULL,
+  `error` MEDIUMTEXT NOT NULL,
+  `retryat` DATETIME(6) NULL,
+  PRIMARY KEY (`idjobattempt`));
+
+CREATE INDEX idx_jobattempt_idjob ON jobattempt (`idjob`, `attempt`);
+
+ALTER TABLE jobattempt
+	ADD CONSTRAINT fk_jobattempt_idjob
+	FOREIGN KEY (idjob) REFERENCES `job`(idjob);
+
+DROP PROCEDURE IF EXISTS `jm_startjob`;
+
+CREATE PROCEDURE `jm_startjob`(arn VARCHAR(2048), payload MEDIUMTEXT, httprequest MEDIUMTEXT, retrypolicy MEDIUMTEXT, idschedule INT, `when` DATETIME(6))
+BEGIN
+	INSERT `job` SET arn=arn, payload=payload, httprequest=httprequest, retrypolicy=retrypolicy, idschedule=idschedule, `when`=`when`;
+	SELECT LAST_INSERT_ID() as idjob;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_getjob`;
+
+-- A job can have several leases once it has been retried, so only jobs without a current lease are available.
+CREATE PROCEDURE `jm_getjob`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`) 				
+		SELECT 
+			j.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				j.idjob, 
+				j.idschedule, 
+				j.`when`, 
+				j.arn, 
+				j.payload,
+				j.httprequest,
+				j.retrypolicy,
+				(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount
+			FROM 
+				`job` j
+				INNER JOIN joblease jl ON j.idjob = jl.idjob
+			WHERE 
+				jl.idjoblease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_getjobresponse`;
+
+CREATE PROCEDURE `jm_getjobresponse`(idjob int)
+BEGIN
+	SELECT
+		j.idjob, j.idschedule, j.`when`, j.arn, j.payload, j.httprequest, j.retrypolicy,
+		(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount,
+		jr.idjobresponse, jr.idjob, jr.`time`, jr.response, jr.iserror, jr.`error` 
+		FROM `job` j 
+		LEFT JOIN `jobresponse` jr ON jr.idjob = j.idjob 
+		WHERE 
+			j.idjob=idjob;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_getjobattempts`;
+
+CREATE PROCEDURE `jm_getjobattempts`(idjob int)
+BEGIN
+	SELECT
+		ja.idjobattempt, ja.idjob, ja.attempt, ja.`time`, ja.response, ja.iserror, ja.`error`, ja.retryat
+		FROM `jobattempt` ja
+		WHERE
+			ja.idjob=idjob
+		ORDER BY ja.attempt ASC;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_completejob`;
+
+CREATE PROCEDURE `jm_completejob`(idjob INT, resp MEDIUMTEXT, iserror bit, errorstring MEDIUMTEXT)
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, iserror, errorstring, NULL
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		INSERT INTO jobresponse
+				(idjob, `time`, response, iserror, `error`)
+			VALUES
+				(idjob, utc_timestamp(), resp, iserror, errorstring);
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_retryjob`;
+
+CREATE PROCEDURE `jm_retryjob`(idjob INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT, retryat DATETIME(6))
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, retryat
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		UPDATE `job` j
+		SET
+			j.`when` = retryat
+		WHERE
+			j.idjob = idjob;
+
+		-- Release the lease so that any worker can pick up the job when it's due.
+		UPDATE joblease jl
+		SET
+			jl.`until` = TIMESTAMPADD(SECOND, -1, utc_timestamp())
+		WHERE
+			jl.idjob = idjob AND
+			jl.`until` > utc_timestamp();
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `sm_getschedule`;
+
+CREATE PROCEDURE `sm_getschedule`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO crontablease (idcrontab, lockedby, `at`, `until`) 				
+		SELECT 
+			ct.idcrontab,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM
+			`crontab` ct
+		 	INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+			LEFT JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+		WHERE
+			ct.next <= utc_timestamp() AND
+			sc.active = 1 AND
+			(ctl.idcrontab IS NULL OR ctl.rescinded = 1 OR ctl.until < utc_timestamp())
+		ORDER BY ct.next ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				ctl.idcrontablease,
+				sc.`idschedule`, 
+				sc.`externalid`, 
+				sc.`by`,
+				sc.`arn`,
+				sc.`payload`,
+				sc.`httprequest`,
+				sc.`retrypolicy`,
+				sc.`created`,
+				sc.`active`,
+				sc.`deactivateddate`,
+				ct.`idcrontab`,
+				ct.`idschedule`, 
+				ct.`crontab`,
+				ct.`previous`,
+				ct.`next`,
+				ct.`lastupdated`
+			FROM 
+				`crontab` ct
+				INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+				INNER JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+			WHERE 
+				ctl.idcrontablease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `sm_getschedulebyid`;
+
+CREATE PROCEDURE `sm_getschedulebyid`(idschedule INT)
+BEGIN
+	SELECT 
+		sc.`idschedule`, 
+		sc.`externalid`, 
+		sc.`by`,
+		sc.`arn`,
+		sc.`payload`,
+		sc.`httprequest`,
+		sc.`retrypolicy`,
+		sc.`created`,
+		sc.`active`,
+		sc.`deactivateddate`,
+		ct.`idcrontab`,
+		ct.`idschedule`, 
+		ct.`crontab`,
+		ct.`previous`,
+		ct.`next`,
+		ct.`lastupdated`
+	FROM 
+		`crontab` ct
+		INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+	WHERE 
+		sc.idschedule = idschedule;
+END;
+
+DROP PROCEDURE IF EXISTS `sm_startjobandupdatecron`;
+
+CREATE PROCEDURE `sm_startjobandupdatecron`(idcrontab int, idschedule int, idcrontablease int, nextjob timestamp)
+BEGIN
+	START TRANSACTION;
+		SET @lastID=LAST_INSERT_ID(0);
+
+		INSERT INTO `job` (arn, payload, httprequest, retrypolicy, idschedule, `when`)
+		SELECT 
+			s.arn, 
+			s.payload, 
+			s.httprequest,
+			s.retrypolicy,
+			s.idschedule, 
+			utc_timestamp() 
+		FROM schedule s
+		WHERE 
+			s.idschedule=idschedule;
+
+		SET @lastID=LAST_INSERT_ID();
+
+		UPDATE crontab ct
+		SET
+			ct.previous=ct.next,
+			ct.next=nextjob,
+			ct.lastupdated=utc_timestamp()
+		WHERE
+			ct.idcrontab=idcrontab;
+
+		UPDATE crontablease ctl
+		SET 
+			rescinded = 1
+		WHERE 
+			ctl.idcrontablease=idcrontablease;
+
+		SELECT @lastID;
+    COMMIT;
+END;
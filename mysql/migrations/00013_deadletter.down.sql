@@ -0,0 +1,6 @@
+DROP PROCEDURE IF EXISTS `jm_deadletterjob`;
+DROP PROCEDURE IF EXISTS `jm_recorddeadletterforward`;
+DROP PROCEDURE IF EXISTS `jm_getdeadletters`;
+DROP PROCEDURE IF EXISTS `jm_requeuedeadletter`;
+
+DROP TABLE `deadletter`;
@@ -0,0 +1,113 @@
+CREATE TABLE `deadletter` (
+  `iddeadletter` INT NOT NULL AUTO_INCREMENT,
+  `idjob` INT NOT NULL,
+  `time` DATETIME(6) NOT NULL,
+  `error` MEDIUMTEXT NOT NULL,
+  `forwardedto` VARCHAR(2048) NULL,
+  `forwarderror` MEDIUMTEXT NULL,
+  `idrequeuedjob` INT NULL,
+  `requeueddate` DATETIME(6) NULL,
+  PRIMARY KEY (`iddeadletter`));
+
+CREATE UNIQUE INDEX idx_deadletter_idjob ON deadletter (`idjob`);
+
+CREATE INDEX idx_deadletter_idrequeuedjob ON deadletter (`idrequeuedjob`, `iddeadletter`);
+
+ALTER TABLE deadletter
+	ADD CONSTRAINT fk_deadletter_idjob
+	FOREIGN KEY (idjob) REFERENCES `job`(idjob);
+
+DROP PROCEDURE IF EXISTS `jm_deadletterjob`;
+
+CREATE PROCEDURE `jm_deadletterjob`(idjob INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT)
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, NULL
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		INSERT INTO jobresponse
+				(idjob, `time`, response, iserror, `error`)
+			VALUES
+				(idjob, utc_timestamp(), resp, 1, errorstring);
+
+		INSERT INTO deadletter
+				(idjob, `time`, `error`)
+			VALUES
+				(idjob, utc_timestamp(), errorstring);
+
+		SELECT LAST_INSERT_ID() AS iddeadletter;
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_recorddeadletterforward`;
+
+CREATE PROCEDURE `jm_recorddeadletterforward`(iddeadletter INT, forwardedto VARCHAR(2048), forwarderror MEDIUMTEXT)
+BEGIN
+	UPDATE deadletter dl
+	SET
+		dl.forwardedto = forwardedto,
+		dl.forwarderror = forwarderror
+	WHERE
+		dl.iddeadletter = iddeadletter;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_getdeadletters`;
+
+CREATE PROCEDURE `jm_getdeadletters`(requeued BIT, afterid INT, lim INT)
+BEGIN
+	SELECT
+		dl.iddeadletter, dl.`time`, dl.`error`, dl.forwardedto, dl.forwarderror, dl.idrequeuedjob, dl.requeueddate,
+		j.idjob, j.idschedule, j.`when`, j.arn, j.payload, j.httprequest, j.retrypolicy,
+		(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount
+	FROM
+		deadletter dl
+		INNER JOIN `job` j ON j.idjob = dl.idjob
+	WHERE
+		dl.iddeadletter > afterid AND
+		((requeued = 1 AND dl.idrequeuedjob IS NOT NULL) OR (requeued = 0 AND dl.idrequeuedjob IS NULL))
+	ORDER BY dl.iddeadletter ASC
+	LIMIT lim;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_requeuedeadletter`;
+
+-- Requeuing creates a new job with the same data, so that the history of the dead job is kept.
+CREATE PROCEDURE `jm_requeuedeadletter`(iddeadletter INT)
+BEGIN
+	DECLARE deadjob INT DEFAULT NULL;
+	START TRANSACTION;
+		SELECT dl.idjob INTO deadjob
+		FROM deadletter dl
+		WHERE
+			dl.iddeadletter = iddeadletter AND
+			dl.idrequeuedjob IS NULL
+		FOR UPDATE;
+
+		IF deadjob IS NOT NULL THEN
+			INSERT INTO `job` (arn, payload, httprequest, retrypolicy, idschedule, `when`)
+			SELECT
+				j.arn,
+				j.payload,
+				j.httprequest,
+				j.retrypolicy,
+				j.idschedule,
+				utc_timestamp()
+			FROM `job` j
+			WHERE
+				j.idjob = deadjob;
+
+			UPDATE deadletter dl
+			SET
+				dl.idrequeuedjob = LAST_INSERT_ID(),
+				dl.requeueddate = utc_timestamp()
+			WHERE
+				dl.iddeadletter = iddeadletter;
+
+			SELECT LAST_INSERT_ID() AS idjob;
+		END IF;
+	COMMIT;
+END;
@@ -0,0 +1,126 @@
+DROP PROCEDURE IF EXISTS `sm_getschedule`;
+DROP PROCEDURE IF EXISTS `sm_getschedulebyid`;
+DROP PROCEDURE IF EXISTS `sm_startjobandupdatecron`;
+
+ALTER TABLE `schedule` DROP COLUMN `until`;
+ALTER TABLE `schedule` DROP COLUMN `maxruns`;
+ALTER TABLE `schedule` DROP COLUMN `runs`;
+
+-- Restore the previous version of sm_getschedule.
+CREATE PROCEDURE `sm_getschedule`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO crontablease (idcrontab, lockedby, `at`, `until`) 				
+		SELECT 
+			ct.idcrontab,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM
+			`crontab` ct
+		 	INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+			LEFT JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+		WHERE
+			ct.next <= utc_timestamp() AND
+			sc.active = 1 AND
+			(ctl.idcrontab IS NULL OR ctl.rescinded = 1 OR ctl.until < utc_timestamp())
+		ORDER BY ct.next ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				ctl.idcrontablease,
+				sc.`idschedule`, 
+				sc.`externalid`, 
+				sc.`by`,
+				sc.`arn`,
+				sc.`payload`,
+				sc.`httprequest`,
+				sc.`retrypolicy`,
+				sc.`timezone`,
+				sc.`created`,
+				sc.`active`,
+				sc.`deactivateddate`,
+				ct.`idcrontab`,
+				ct.`idschedule`, 
+				ct.`crontab`,
+				ct.`previous`,
+				ct.`next`,
+				ct.`lastupdated`
+			FROM 
+				`crontab` ct
+				INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+				INNER JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+			WHERE 
+				ctl.idcrontablease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+-- Restore the previous version of sm_getschedulebyid.
+CREATE PROCEDURE `sm_getschedulebyid`(idschedule INT)
+BEGIN
+	SELECT 
+		sc.`idschedule`, 
+		sc.`externalid`, 
+		sc.`by`,
+		sc.`arn`,
+		sc.`payload`,
+		sc.`httprequest`,
+		sc.`retrypolicy`,
+		sc.`timezone`,
+		sc.`created`,
+		sc.`active`,
+		sc.`deactivateddate`,
+		ct.`idcrontab`,
+		ct.`idschedule`, 
+		ct.`crontab`,
+		ct.`previous`,
+		ct.`next`,
+		ct.`lastupdated`
+	FROM 
+		`crontab` ct
+		INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+	WHERE 
+		sc.idschedule = idschedule;
+END;
+
+-- Restore the previous version of sm_startjobandupdatecron.
+CREATE PROCEDURE `sm_startjobandupdatecron`(idcrontab int, idschedule int, idcrontablease int, nextjob timestamp)
+BEGIN
+	START TRANSACTION;
+		SET @lastID=LAST_INSERT_ID(0);
+
+		INSERT INTO `job` (arn, payload, httprequest, retrypolicy, idschedule, `when`)
+		SELECT 
+			s.arn, 
+			s.payload, 
+			s.httprequest,
+			s.retrypolicy,
+			s.idschedule, 
+			utc_timestamp() 
+		FROM schedule s
+		WHERE 
+			s.idschedule=idschedule;
+
+		SET @lastID=LAST_INSERT_ID();
+
+		UPDATE crontab ct
+		SET
+			ct.previous=ct.next,
+			ct.next=nextjob,
+			ct.lastupdated=utc_timestamp()
+		WHERE
+			ct.idcrontab=idcrontab;
+
+		UPDATE crontablease ctl
+		SET 
+			rescinded = 1
+		WHERE 
+			ctl.idcrontablease=idcrontablease;
+
+		SELECT @lastID;
+    COMMIT;
+END;
@@ -0,0 +1,109 @@
+ALTER TABLE `schedule` ADD COLUMN `paused` BIT NOT NULL DEFAULT 0;
+
+CREATE TABLE `schedulepause` (
+  `idschedulepause` INT NOT NULL AUTO_INCREMENT,
+  `idschedule` INT NOT NULL,
+  `pauseddate` DATETIME(6) NOT NULL,
+  `pausedby` VARCHAR(256) NOT NULL,
+  `resumeddate` DATETIME(6) NULL,
+  `resumedby` VARCHAR(256) NOT NULL,
+  `catchup` BIT NOT NULL,
+  PRIMARY KEY (`idschedulepause`));
+
+CREATE INDEX idx_schedulepause_idschedule ON schedulepause (`idschedule`, `resumeddate`);
+
+ALTER TABLE schedulepause
+	ADD CONSTRAINT fk_schedulepause_idschedule
+	FOREIGN KEY (idschedule) REFERENCES `schedule`(idschedule);
+
+DROP PROCEDURE IF EXISTS `sm_getschedule`;
+
+CREATE PROCEDURE `sm_getschedule`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO crontablease (idcrontab, lockedby, `at`, `until`) 				
+		SELECT 
+			ct.idcrontab,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM
+			`crontab` ct
+		 	INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+			LEFT JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+		WHERE
+			ct.next <= utc_timestamp() AND
+			sc.active = 1 AND
+			sc.paused = 0 AND
+			(ctl.idcrontab IS NULL OR ctl.rescinded = 1 OR ctl.until < utc_timestamp())
+		ORDER BY ct.next ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				ctl.idcrontablease,
+				sc.`idschedule`, 
+				sc.`externalid`, 
+				sc.`by`,
+				sc.`arn`,
+				sc.`payload`,
+				sc.`httprequest`,
+				sc.`retrypolicy`,
+				sc.`timezone`,
+				sc.`until`,
+				sc.`maxruns`,
+				sc.`runs`,
+				sc.`created`,
+				sc.`active`,
+				sc.`deactivateddate`,
+				sc.`paused`,
+				ct.`idcrontab`,
+				ct.`idschedule`, 
+				ct.`crontab`,
+				ct.`previous`,
+				ct.`next`,
+				ct.`lastupdated`
+			FROM 
+				`crontab` ct
+				INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+				INNER JOIN crontablease ctl ON ctl.idcrontab = ct.idcrontab
+			WHERE 
+				ctl.idcrontablease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `sm_getschedulebyid`;
+
+CREATE PROCEDURE `sm_getschedulebyid`(idschedule INT)
+BEGIN
+	SELECT 
+		sc.`idschedule`, 
+		sc.`externalid`, 
+		sc.`by`,
+		sc.`arn`,
+		sc.`payload`,
+		sc.`httprequest`,
+		sc.`retrypolicy`,
+		sc.`timezone`,
+		sc.`until`,
+		sc.`maxruns`,
+		sc.`runs`,
+		sc.`created`,
+		sc.`active`,
+		sc.`deactivateddate`,
+		sc.`paused`,
+		ct.`idcrontab`,
+		ct.`idschedule`, 
+		ct.`crontab`,
+		ct.`previous`,
+		ct.`next`,
+		ct.`lastupdated`
+	FROM 
+		`crontab` ct
+		INNER JOIN `schedule` sc ON sc.idschedule = ct.idschedule
+	WHERE 
+		sc.idschedule = idschedule;
+END;
@@ -0,0 +1 @@
+DROP PROCEDURE IF EXISTS `jm_getjobs`;
@@ -0,0 +1,47 @@
+DROP PROCEDURE IF EXISTS `jm_getjobs`;
+
+-- Leases up to lim jobs in a single transaction. The leased jobs are collected in a temporary table, which is
+-- private to the connection, so that they can be returned without being confused with other workers' leases.
+CREATE PROCEDURE `jm_getjobs`(lockedby varchar(256), lockExpiryMinutes int, lim int)
+BEGIN
+	DROP TEMPORARY TABLE IF EXISTS leasedjob;
+	CREATE TEMPORARY TABLE leasedjob (idjob INT NOT NULL PRIMARY KEY);
+
+	START TRANSACTION;
+		INSERT INTO leasedjob (idjob)
+		SELECT
+			j.idjob
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT lim;
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`)
+		SELECT
+			lj.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM leasedjob lj;
+
+		SELECT
+			j.idjob,
+			j.idschedule,
+			j.`when`,
+			j.arn,
+			j.payload,
+			j.httprequest,
+			j.retrypolicy,
+			(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount
+		FROM
+			`job` j
+			INNER JOIN leasedjob lj ON lj.idjob = j.idjob
+		ORDER BY j.`when` ASC;
+	COMMIT;
+
+	DROP TEMPORARY TABLE leasedjob;
+END;
@@ -0,0 +1,3 @@
+DROP INDEX idx_crontablease_until ON crontablease;
+DROP INDEX idx_joblease_until ON joblease;
+DROP INDEX idx_jobresponse_time ON jobresponse;
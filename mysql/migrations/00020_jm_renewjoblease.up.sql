@@ -0,0 +1,30 @@
+DROP PROCEDURE IF EXISTS `jm_renewjoblease`;
+
+-- Extends a lease if it's the job's latest lease and it hasn't expired. The lease is identified by its id rather than
+-- the worker's name, since every routine in a worker process shares the name. Returns 1 if the lease was renewed, or 0
+-- if it has been released or taken over by another worker.
+CREATE PROCEDURE `jm_renewjoblease`(jobID int, jobLeaseID int, lockExpiryMinutes int)
+BEGIN
+	DECLARE renewedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		SELECT
+			jl.idjoblease INTO renewedID
+		FROM
+			joblease jl
+		WHERE
+			jl.idjoblease = jobLeaseID AND
+			jl.idjob = jobID AND
+			jl.`until` >= utc_timestamp() AND
+			jl.idjoblease = (SELECT MAX(idjoblease) FROM joblease WHERE idjob = jobID)
+		FOR UPDATE;
+
+		UPDATE joblease
+		SET
+			`until` = TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		WHERE
+			idjoblease = renewedID;
+	COMMIT;
+
+	SELECT renewedID IS NOT NULL;
+END;
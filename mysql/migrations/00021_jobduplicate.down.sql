@@ -0,0 +1,162 @@
+DROP PROCEDURE IF EXISTS `jm_getjob`;
+DROP PROCEDURE IF EXISTS `jm_getjobs`;
+DROP PROCEDURE IF EXISTS `jm_completejob`;
+DROP PROCEDURE IF EXISTS `jm_retryjob`;
+DROP PROCEDURE IF EXISTS `jm_deadletterjob`;
+
+DROP TABLE `jobduplicate`;
+
+-- Restore the previous version of jm_getjob.
+CREATE PROCEDURE `jm_getjob`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`) 				
+		SELECT 
+			j.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT 
+				j.idjob, 
+				j.idschedule, 
+				j.`when`, 
+				j.arn, 
+				j.payload,
+				j.httprequest,
+				j.retrypolicy,
+				(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount
+			FROM 
+				`job` j
+				INNER JOIN joblease jl ON j.idjob = jl.idjob
+			WHERE 
+				jl.idjoblease = LAST_INSERT_ID();
+		END IF;
+    COMMIT;
+END;
+
+-- Restore the previous version of jm_getjobs.
+CREATE PROCEDURE `jm_getjobs`(lockedby varchar(256), lockExpiryMinutes int, lim int)
+BEGIN
+	DROP TEMPORARY TABLE IF EXISTS leasedjob;
+	CREATE TEMPORARY TABLE leasedjob (idjob INT NOT NULL PRIMARY KEY);
+
+	START TRANSACTION;
+		INSERT INTO leasedjob (idjob)
+		SELECT
+			j.idjob
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT lim;
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`)
+		SELECT
+			lj.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM leasedjob lj;
+
+		SELECT
+			j.idjob,
+			j.idschedule,
+			j.`when`,
+			j.arn,
+			j.payload,
+			j.httprequest,
+			j.retrypolicy,
+			(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount
+		FROM
+			`job` j
+			INNER JOIN leasedjob lj ON lj.idjob = j.idjob
+		ORDER BY j.`when` ASC;
+	COMMIT;
+
+	DROP TEMPORARY TABLE leasedjob;
+END;
+
+-- Restore the previous version of jm_completejob.
+CREATE PROCEDURE `jm_completejob`(idjob INT, resp MEDIUMTEXT, iserror bit, errorstring MEDIUMTEXT)
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, iserror, errorstring, NULL
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		INSERT INTO jobresponse
+				(idjob, `time`, response, iserror, `error`)
+			VALUES
+				(idjob, utc_timestamp(), resp, iserror, errorstring);
+	COMMIT;
+END;
+
+-- Restore the previous version of jm_retryjob.
+CREATE PROCEDURE `jm_retryjob`(idjob INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT, retryat DATETIME(6))
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, retryat
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		UPDATE `job` j
+		SET
+			j.`when` = retryat
+		WHERE
+			j.idjob = idjob;
+
+		-- Release the lease so that any worker can pick up the job when it's due.
+		UPDATE joblease jl
+		SET
+			jl.`until` = TIMESTAMPADD(SECOND, -1, utc_timestamp())
+		WHERE
+			jl.idjob = idjob AND
+			jl.`until` > utc_timestamp();
+	COMMIT;
+END;
+
+-- Restore the previous version of jm_deadletterjob.
+CREATE PROCEDURE `jm_deadletterjob`(idjob INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT)
+BEGIN
+	START TRANSACTION;
+		INSERT INTO jobattempt
+				(idjob, attempt, `time`, response, iserror, `error`, retryat)
+			SELECT
+				idjob, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, NULL
+			FROM jobattempt ja
+			WHERE ja.idjob = idjob;
+
+		INSERT INTO jobresponse
+				(idjob, `time`, response, iserror, `error`)
+			VALUES
+				(idjob, utc_timestamp(), resp, 1, errorstring);
+
+		INSERT INTO deadletter
+				(idjob, `time`, `error`)
+			VALUES
+				(idjob, utc_timestamp(), errorstring);
+
+		SELECT LAST_INSERT_ID() AS iddeadletter;
+	COMMIT;
+END;
@@ -0,0 +1,236 @@
+-- Executions which were completed using a lease that was no longer current. There's no reference to the lease,
+-- because expired leases are purged by the retention worker.
+CREATE TABLE `jobduplicate` (
+  `idjobduplicate` INT NOT NULL AUTO_INCREMENT,
+  `idjob` INT NOT NULL,
+  `idjoblease` INT NOT NULL,
+  `time` DATETIME(6) NOT NULL,
+  `response` MEDIUMTEXT NOT NULL,
+  `iserror` BIT NOT NULL,
+  `error` MEDIUMTEXT NOT NULL,
+  PRIMARY KEY (`idjobduplicate`));
+
+CREATE INDEX idx_jobduplicate_idjob ON jobduplicate (`idjob`);
+
+ALTER TABLE jobduplicate
+	ADD CONSTRAINT fk_jobduplicate_idjob
+	FOREIGN KEY (idjob) REFERENCES `job`(idjob);
+
+DROP PROCEDURE IF EXISTS `jm_getjob`;
+
+-- Returns the id of the new lease along with the job, so that the job can only be completed by the lease's holder.
+CREATE PROCEDURE `jm_getjob`(lockedby varchar(256), lockExpiryMinutes int)
+BEGIN
+	START TRANSACTION;
+		SET @lastID = LAST_INSERT_ID(0);
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`)
+		SELECT
+			j.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT 1;
+
+		IF LAST_INSERT_ID() > 0 THEN
+			SELECT
+				j.idjob,
+				j.idschedule,
+				j.`when`,
+				j.arn,
+				j.payload,
+				j.httprequest,
+				j.retrypolicy,
+				(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount,
+				jl.idjoblease
+			FROM
+				`job` j
+				INNER JOIN joblease jl ON j.idjob = jl.idjob
+			WHERE
+				jl.idjoblease = LAST_INSERT_ID();
+		END IF;
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_getjobs`;
+
+-- Leases up to lim jobs in a single transaction, returning the id of each job's new lease.
+CREATE PROCEDURE `jm_getjobs`(lockedby varchar(256), lockExpiryMinutes int, lim int)
+BEGIN
+	DROP TEMPORARY TABLE IF EXISTS leasedjob;
+	CREATE TEMPORARY TABLE leasedjob (idjob INT NOT NULL PRIMARY KEY);
+
+	START TRANSACTION;
+		INSERT INTO leasedjob (idjob)
+		SELECT
+			j.idjob
+		FROM `job` j
+			LEFT JOIN jobresponse jr ON jr.idjob = j.idjob
+		WHERE
+			jr.idjob IS NULL AND
+			j.when <= utc_timestamp() AND
+			NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl.until >= utc_timestamp())
+		ORDER BY j.when ASC
+		LIMIT lim;
+
+		INSERT INTO joblease (idjob, lockedby, `at`, `until`)
+		SELECT
+			lj.idjob,
+			lockedby,
+			utc_timestamp(),
+			TIMESTAMPADD(MINUTE, lockExpiryMinutes, utc_timestamp())
+		FROM leasedjob lj;
+
+		SELECT
+			j.idjob,
+			j.idschedule,
+			j.`when`,
+			j.arn,
+			j.payload,
+			j.httprequest,
+			j.retrypolicy,
+			(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount,
+			(SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = j.idjob) AS idjoblease
+		FROM
+			`job` j
+			INNER JOIN leasedjob lj ON lj.idjob = j.idjob
+		ORDER BY j.`when` ASC;
+	COMMIT;
+
+	DROP TEMPORARY TABLE leasedjob;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_completejob`;
+
+-- Completes the job if the lease is current, otherwise the response is recorded as a duplicate. A lease is current if
+-- it's the job's latest lease and the job hasn't been completed, so once a lease has expired and the job has been leased
+-- again, or completed by another worker, completing it with the old lease is a duplicate. Returns whether the completion
+-- was a duplicate.
+CREATE PROCEDURE `jm_completejob`(jobID INT, jobLeaseID INT, resp MEDIUMTEXT, iserror bit, errorstring MEDIUMTEXT)
+BEGIN
+	DECLARE lockedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		-- Lock the job, so that concurrent completions are handled one at a time.
+		SELECT j.idjob INTO lockedID FROM `job` j WHERE j.idjob = jobID FOR UPDATE;
+
+		IF jobLeaseID = (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = jobID) AND
+			NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = jobID) THEN
+			INSERT INTO jobattempt
+					(idjob, attempt, `time`, response, iserror, `error`, retryat)
+				SELECT
+					jobID, COUNT(*) + 1, utc_timestamp(), resp, iserror, errorstring, NULL
+				FROM jobattempt ja
+				WHERE ja.idjob = jobID;
+
+			INSERT INTO jobresponse
+					(idjob, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, utc_timestamp(), resp, iserror, errorstring);
+
+			SELECT 0 AS duplicate;
+		ELSE
+			INSERT INTO jobduplicate
+					(idjob, idjoblease, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, jobLeaseID, utc_timestamp(), resp, iserror, errorstring);
+
+			SELECT 1 AS duplicate;
+		END IF;
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_retryjob`;
+
+-- Records the failed attempt and releases the lease if it's current, otherwise the attempt is recorded as a duplicate.
+-- Returns whether the attempt was a duplicate.
+CREATE PROCEDURE `jm_retryjob`(jobID INT, jobLeaseID INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT, retryat DATETIME(6))
+BEGIN
+	DECLARE lockedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		SELECT j.idjob INTO lockedID FROM `job` j WHERE j.idjob = jobID FOR UPDATE;
+
+		IF jobLeaseID = (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = jobID) AND
+			NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = jobID) THEN
+			INSERT INTO jobattempt
+					(idjob, attempt, `time`, response, iserror, `error`, retryat)
+				SELECT
+					jobID, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, retryat
+				FROM jobattempt ja
+				WHERE ja.idjob = jobID;
+
+			UPDATE `job` j
+			SET
+				j.`when` = retryat
+			WHERE
+				j.idjob = jobID;
+
+			-- Release the lease so that any worker can pick up the job when it's due.
+			UPDATE joblease jl
+			SET
+				jl.`until` = TIMESTAMPADD(SECOND, -1, utc_timestamp())
+			WHERE
+				jl.idjoblease = jobLeaseID AND
+				jl.`until` > utc_timestamp();
+
+			SELECT 0 AS duplicate;
+		ELSE
+			INSERT INTO jobduplicate
+					(idjob, idjoblease, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, jobLeaseID, utc_timestamp(), resp, 1, errorstring);
+
+			SELECT 1 AS duplicate;
+		END IF;
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_deadletterjob`;
+
+-- Completes the job with an error and marks it as dead if the lease is current, otherwise the final attempt is
+-- recorded as a duplicate. Returns the id of the dead letter, and whether the attempt was a duplicate.
+CREATE PROCEDURE `jm_deadletterjob`(jobID INT, jobLeaseID INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT)
+BEGIN
+	DECLARE lockedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		SELECT j.idjob INTO lockedID FROM `job` j WHERE j.idjob = jobID FOR UPDATE;
+
+		IF jobLeaseID = (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = jobID) AND
+			NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = jobID) THEN
+			INSERT INTO jobattempt
+					(idjob, attempt, `time`, response, iserror, `error`, retryat)
+				SELECT
+					jobID, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, NULL
+				FROM jobattempt ja
+				WHERE ja.idjob = jobID;
+
+			INSERT INTO jobresponse
+					(idjob, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, utc_timestamp(), resp, 1, errorstring);
+
+			INSERT INTO deadletter
+					(idjob, `time`, `error`)
+				VALUES
+					(jobID, utc_timestamp(), errorstring);
+
+			SELECT LAST_INSERT_ID() AS iddeadletter, 0 AS duplicate;
+		ELSE
+			INSERT INTO jobduplicate
+					(idjob, idjoblease, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, jobLeaseID, utc_timestamp(), resp, 1, errorstring);
+
+			SELECT 0 AS iddeadletter, 1 AS duplicate;
+		END IF;
+	COMMIT;
+END;
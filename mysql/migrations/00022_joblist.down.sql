@@ -0,0 +1,3 @@
+DROP INDEX idx_job_arn ON `job`;
+DROP INDEX idx_job_when ON `job`;
+DROP INDEX idx_job_idschedule ON `job`;
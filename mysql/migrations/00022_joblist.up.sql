@@ -0,0 +1,6 @@
+-- Indexes used to filter the jobs listed by the API.
+CREATE INDEX idx_job_idschedule ON `job` (`idschedule`, `idjob`);
+
+CREATE INDEX idx_job_when ON `job` (`when`);
+
+CREATE INDEX idx_job_arn ON `job` (`arn`(255));
@@ -0,0 +1,5 @@
+DROP INDEX idx_crontab_idschedule ON `crontab`;
+DROP INDEX idx_schedule_created ON `schedule`;
+DROP INDEX idx_schedule_arn ON `schedule`;
+DROP INDEX idx_schedule_by ON `schedule`;
+DROP INDEX idx_schedule_externalid ON `schedule`;
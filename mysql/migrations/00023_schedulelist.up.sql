@@ -0,0 +1,10 @@
+-- Indexes used to filter the schedules listed by the API, and to find their crontabs.
+CREATE INDEX idx_schedule_externalid ON `schedule` (`externalid`);
+
+CREATE INDEX idx_schedule_by ON `schedule` (`by`);
+
+CREATE INDEX idx_schedule_arn ON `schedule` (`arn`(255));
+
+CREATE INDEX idx_schedule_created ON `schedule` (`created`);
+
+CREATE INDEX idx_crontab_idschedule ON `crontab` (`idschedule`, `idcrontab`);
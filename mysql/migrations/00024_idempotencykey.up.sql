@@ -0,0 +1,17 @@
+-- The idempotency keys of requests which created jobs and schedules. Keys are unique per resource and caller, which is
+-- enforced by the unique index on keyhash, a hash of the resource, caller and key, because they're too long to be
+-- indexed together. There's no reference to the resource, because keys are kept after their jobs are purged or deleted.
+CREATE TABLE idempotencykey (
+  ididempotencykey INT NOT NULL AUTO_INCREMENT,
+  resource VARCHAR(16) NOT NULL,
+  caller VARCHAR(256) NOT NULL,
+  idempotencykey VARCHAR(256) NOT NULL,
+  keyhash CHAR(64) NOT NULL,
+  requesthash CHAR(64) NOT NULL,
+  resourceid INT NOT NULL,
+  created DATETIME(6) NOT NULL,
+  PRIMARY KEY (ididempotencykey));
+
+CREATE UNIQUE INDEX idx_idempotencykey_keyhash ON idempotencykey (keyhash);
+
+CREATE INDEX idx_idempotencykey_created ON idempotencykey (created);
@@ -0,0 +1,17 @@
+-- The dedupe keys of jobs. A key is held by a job until its dedupe window ends, after which it can be taken by a new
+-- job. Keys are unique, which is enforced by the unique index on keyhash, a hash of the key, because the key is too
+-- long to be indexed. There's no reference to the job, because a job can be deleted or purged while its key is held,
+-- which releases the key.
+CREATE TABLE jobdedupe (
+  idjobdedupe INT NOT NULL AUTO_INCREMENT,
+  dedupekey VARCHAR(256) NOT NULL,
+  keyhash CHAR(64) NOT NULL,
+  idjob INT NOT NULL,
+  expires DATETIME(6) NOT NULL,
+  PRIMARY KEY (idjobdedupe));
+
+CREATE UNIQUE INDEX idx_jobdedupe_keyhash ON jobdedupe (keyhash);
+
+CREATE INDEX idx_jobdedupe_idjob ON jobdedupe (idjob);
+
+CREATE INDEX idx_jobdedupe_expires ON jobdedupe (expires);
@@ -0,0 +1,88 @@
+DROP PROCEDURE IF EXISTS `jm_completejob`;
+
+-- Completes the job if the lease is current, otherwise the response is recorded as a duplicate. A lease is current if
+-- it's the job's latest lease and the job hasn't been completed, so once a lease has expired and the job has been leased
+-- again, or completed by another worker, completing it with the old lease is a duplicate. Returns whether the completion
+-- was a duplicate. Completing the job releases its dedupe key.
+CREATE PROCEDURE `jm_completejob`(jobID INT, jobLeaseID INT, resp MEDIUMTEXT, iserror bit, errorstring MEDIUMTEXT)
+BEGIN
+	DECLARE lockedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		-- Lock the job, so that concurrent completions are handled one at a time.
+		SELECT j.idjob INTO lockedID FROM `job` j WHERE j.idjob = jobID FOR UPDATE;
+
+		IF jobLeaseID = (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = jobID) AND
+			NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = jobID) THEN
+			INSERT INTO jobattempt
+					(idjob, attempt, `time`, response, iserror, `error`, retryat)
+				SELECT
+					jobID, COUNT(*) + 1, utc_timestamp(), resp, iserror, errorstring, NULL
+				FROM jobattempt ja
+				WHERE ja.idjob = jobID;
+
+			INSERT INTO jobresponse
+					(idjob, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, utc_timestamp(), resp, iserror, errorstring);
+
+			-- Release the job's dedupe key, so that the key can start a new job.
+			DELETE FROM jobdedupe WHERE idjob = jobID;
+
+			SELECT 0 AS duplicate;
+		ELSE
+			INSERT INTO jobduplicate
+					(idjob, idjoblease, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, jobLeaseID, utc_timestamp(), resp, iserror, errorstring);
+
+			SELECT 1 AS duplicate;
+		END IF;
+	COMMIT;
+END;
+
+DROP PROCEDURE IF EXISTS `jm_deadletterjob`;
+
+-- Completes the job with an error and marks it as dead if the lease is current, otherwise the final attempt is
+-- recorded as a duplicate. Completing the job releases its dedupe key. Returns the id of the dead letter, and whether
+-- the attempt was a duplicate.
+CREATE PROCEDURE `jm_deadletterjob`(jobID INT, jobLeaseID INT, resp MEDIUMTEXT, errorstring MEDIUMTEXT)
+BEGIN
+	DECLARE lockedID INT DEFAULT NULL;
+
+	START TRANSACTION;
+		SELECT j.idjob INTO lockedID FROM `job` j WHERE j.idjob = jobID FOR UPDATE;
+
+		IF jobLeaseID = (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = jobID) AND
+			NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = jobID) THEN
+			INSERT INTO jobattempt
+					(idjob, attempt, `time`, response, iserror, `error`, retryat)
+				SELECT
+					jobID, COUNT(*) + 1, utc_timestamp(), resp, 1, errorstring, NULL
+				FROM jobattempt ja
+				WHERE ja.idjob = jobID;
+
+			INSERT INTO jobresponse
+					(idjob, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, utc_timestamp(), resp, 1, errorstring);
+
+			-- Release the job's dedupe key, so that the key can start a new job.
+			DELETE FROM jobdedupe WHERE idjob = jobID;
+
+			INSERT INTO deadletter
+					(idjob, `time`, `error`)
+				VALUES
+					(jobID, utc_timestamp(), errorstring);
+
+			SELECT LAST_INSERT_ID() AS iddeadletter, 0 AS duplicate;
+		ELSE
+			INSERT INTO jobduplicate
+					(idjob, idjoblease, `time`, response, iserror, `error`)
+				VALUES
+					(jobID, jobLeaseID, utc_timestamp(), resp, 1, errorstring);
+
+			SELECT 0 AS iddeadletter, 1 AS duplicate;
+		END IF;
+	COMMIT;
+END;
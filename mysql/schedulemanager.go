@@ -2,61 +2,98 @@ package mysql
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql" // Requires MySQL
+	_ "github.com/go-sql-driver/mysql"       // Requires MySQL
+	gomysql "github.com/go-sql-driver/mysql" // Requires MySQL
+	"github.com/welldigital/callme/crontab"
 	"github.com/welldigital/callme/data"
 )
 
 // ScheduleManager provides features to manage schedules using MySQL.
 type ScheduleManager struct {
-	ConnectionString string
+	DB *sql.DB
 }
 
 // NewScheduleManager creates a new ScheduleManager.
-func NewScheduleManager(connectionString string) ScheduleManager {
+func NewScheduleManager(db *sql.DB) ScheduleManager {
 	return ScheduleManager{
-		ConnectionString: connectionString,
+		DB: db,
 	}
 }
 
 // Create creates a repeating schedule. Doesn't require a lease, any process can do this.
-func (m ScheduleManager) Create(from time.Time, arn string, payload string, crontabs []string, externalID string, by string) (id int64, err error) {
+func (m ScheduleManager) Create(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err = createSchedule(tx, from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// CreateIdempotently creates a schedule, unless a schedule was already created with the idempotency key, in which
+// case its ID is returned.
+func (m ScheduleManager) CreateIdempotently(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, existing bool, err error) {
+	return idempotently(m.DB, idempotencyResourceSchedule, key, func(tx *sql.Tx) (int64, error) {
+		return createSchedule(tx, from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by)
+	})
+}
+
+// createSchedule inserts a schedule and its crontabs in the transaction.
+func createSchedule(tx *sql.Tx, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, err error) {
 	s := data.Schedule{
 		ExternalID:      externalID,
 		By:              by,
 		ARN:             arn,
 		Payload:         payload,
+		HTTPRequest:     httpRequest,
+		RetryPolicy:     retryPolicy,
+		Timezone:        timezone,
+		Until:           until,
+		MaxRuns:         maxRuns,
+		MisfirePolicy:   misfirePolicy,
 		Created:         time.Now().UTC(),
 		Active:          true,
 		DeactivatedDate: time.Time{},
 	}
 
-	db, err := sql.Open("mysql", m.ConnectionString)
+	httpRequestJSON, err := marshalHTTPRequest(s.HTTPRequest)
 	if err != nil {
 		return 0, err
 	}
-	defer db.Close()
+	retryPolicyJSON, err := marshalRetryPolicy(s.RetryPolicy)
+	if err != nil {
+		return 0, err
+	}
+
+	var untilValue *time.Time
+	if !s.Until.IsZero() {
+		untilValue = &s.Until
+	}
 
 	scheduleInsertSQL := "INSERT INTO `schedule` " +
-		"(`externalid`,`by`,`arn`,`payload`,`created`,`active`) " +
-		"VALUES (?, ?, ?, ?, ?, ?)"
+		"(`externalid`,`by`,`arn`,`payload`,`httprequest`,`retrypolicy`,`timezone`,`until`,`maxruns`,`misfirepolicy`,`created`,`active`) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 
 	crontabInsertSQL := "INSERT INTO `crontab` " +
 		"(`idschedule`, `crontab`, `previous`, `next`, `lastupdated`)" +
 		"VALUES (?, ?, ?, ?, ?)"
 
-	tx, err := db.Begin()
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback()
-
 	scheduleInsert, err := tx.Prepare(scheduleInsertSQL)
 	if err != nil {
 		return 0, err
 	}
-	res, err := scheduleInsert.Exec(s.ExternalID, s.By, s.ARN, s.Payload, s.Created, s.Active)
+	res, err := scheduleInsert.Exec(s.ExternalID, s.By, s.ARN, s.Payload, httpRequestJSON, retryPolicyJSON, s.Timezone, untilValue, s.MaxRuns, s.MisfirePolicy, s.Created, s.Active)
 	if err != nil {
 		return 0, err
 	}
@@ -77,30 +114,121 @@ func (m ScheduleManager) Create(from time.Time, arn string, payload string, cron
 		startTime = s.Created
 	}
 
-	for _, crontab := range crontabs {
+	for _, ct := range crontabs {
 		// The first insertion needs to initialise dates with start times.
-		_, err := crontabInsert.Exec(scheduleID, crontab, s.Created, startTime, startTime)
+		_, err := crontabInsert.Exec(scheduleID, ct, s.Created, startTime, startTime)
 		if err != nil {
 			return 0, err
 		}
 	}
+	return scheduleID, nil
+}
 
-	err = tx.Commit()
+// Update replaces the fields and crontabs of an active schedule. Unchanged crontabs keep their next run, while new
+// crontabs, and all of the crontabs if the timezone has changed, are next due at their first run after the update.
+// If a worker holds a lease on a crontab which would be removed or recalculated, data.ErrCrontabLeased is returned.
+func (m ScheduleManager) Update(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (ok bool, err error) {
+	httpRequestJSON, err := marshalHTTPRequest(httpRequest)
 	if err != nil {
-		return 0, err
+		return false, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(retryPolicy)
+	if err != nil {
+		return false, err
 	}
-	return res.LastInsertId()
-}
 
-// Deactivate deactivates a schedule.
-func (m ScheduleManager) Deactivate(scheduleID int64) (ok bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
+	var untilValue *time.Time
+	if !until.IsZero() {
+		untilValue = &until
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Lock the schedule and its crontabs, so that workers can't lease the crontabs until the update is complete.
+	var previousTimezone string
+	err = tx.QueryRow("SELECT `timezone` FROM `schedule` WHERE `idschedule` = ? AND active = 1 FOR UPDATE",
+		scheduleID).Scan(&previousTimezone)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.Query("SELECT ct.`idcrontab`, ct.`crontab`, ct.`next`, "+
+		"EXISTS(SELECT 1 FROM `crontablease` ctl WHERE ctl.idcrontab = ct.idcrontab AND ctl.rescinded = 0 AND ctl.until >= utc_timestamp()) "+
+		"FROM `crontab` ct WHERE ct.`idschedule` = ? FOR UPDATE", scheduleID)
+	if err != nil {
+		return false, err
+	}
+	var existing []data.Crontab
+	leased := make(map[int64]bool)
+	for rows.Next() {
+		var ct data.Crontab
+		var isLeased bool
+		if err = rows.Scan(&ct.CrontabID, &ct.Crontab, &ct.Next, &isLeased); err != nil {
+			rows.Close()
+			return false, err
+		}
+		existing = append(existing, ct)
+		leased[ct.CrontabID] = isLeased
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	added, updated, removed, err := crontab.Replace(existing, crontabs, timezone != previousTimezone, timezone, now)
+	if err != nil {
+		return false, err
+	}
+	for _, ct := range append(updated, removed...) {
+		if leased[ct.CrontabID] {
+			return false, data.ErrCrontabLeased
+		}
+	}
+
+	_, err = tx.Exec("UPDATE `schedule` SET `externalid` = ?, `by` = ?, `arn` = ?, `payload` = ?, `httprequest` = ?, `retrypolicy` = ?, "+
+		"`timezone` = ?, `until` = ?, `maxruns` = ?, `misfirepolicy` = ? WHERE `idschedule` = ?",
+		externalID, by, arn, payload, httpRequestJSON, retryPolicyJSON, timezone, untilValue, maxRuns, misfirePolicy, scheduleID)
 	if err != nil {
 		return false, err
 	}
-	defer db.Close()
+	for _, ct := range removed {
+		// Expired and rescinded leases reference the crontab, so they're removed with it.
+		if _, err = tx.Exec("DELETE FROM `crontablease` WHERE `idcrontab` = ?", ct.CrontabID); err != nil {
+			return false, err
+		}
+		if _, err = tx.Exec("DELETE FROM `crontab` WHERE `idcrontab` = ?", ct.CrontabID); err != nil {
+			return false, err
+		}
+	}
+	for _, ct := range updated {
+		_, err = tx.Exec("UPDATE `crontab` SET `next` = ?, `lastupdated` = ? WHERE `idcrontab` = ?", ct.Next, now, ct.CrontabID)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, ct := range added {
+		_, err = tx.Exec("INSERT INTO `crontab` (`idschedule`, `crontab`, `previous`, `next`, `lastupdated`) VALUES (?, ?, ?, ?, ?)",
+			scheduleID, ct.Crontab, now, ct.Next, now)
+		if err != nil {
+			return false, err
+		}
+	}
 
-	res, err := db.Exec("UPDATE `schedule` SET active = 0, deactivateddate = utc_timestamp() WHERE `schedule`.`idschedule` = ?",
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// Deactivate deactivates a schedule.
+func (m ScheduleManager) Deactivate(scheduleID int64) (ok bool, err error) {
+	res, err := m.DB.Exec("UPDATE `schedule` SET active = 0, deactivateddate = utc_timestamp() WHERE `schedule`.`idschedule` = ?",
 		scheduleID)
 	if err != nil {
 		return
@@ -112,23 +240,105 @@ func (m ScheduleManager) Deactivate(scheduleID int64) (ok bool, err error) {
 	return
 }
 
-// GetScheduleByID gets a schedule's information by its ID.
-func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
+// Pause pauses an active schedule, and records it in the schedule's pause history.
+func (m ScheduleManager) Pause(scheduleID int64, by string) (ok bool, err error) {
+	tx, err := m.DB.Begin()
 	if err != nil {
-		return
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE `schedule` SET paused = 1 WHERE `schedule`.`idschedule` = ? AND active = 1 AND paused = 0",
+		scheduleID)
+	if err != nil {
+		return false, err
+	}
+	affectedRows, err := res.RowsAffected()
+	if err != nil || affectedRows == 0 {
+		return false, err
+	}
+
+	_, err = tx.Exec("INSERT INTO `schedulepause` (`idschedule`, `pauseddate`, `pausedby`, `resumedby`, `catchup`) "+
+		"VALUES (?, utc_timestamp(), ?, '', 0)", scheduleID, by)
+	if err != nil {
+		return false, err
 	}
-	defer db.Close()
 
-	rows, err := db.Query("call sm_getschedulebyid(?)", scheduleID)
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// Resume resumes a paused schedule, recalculating the next run of each crontab from the current time, and
+// optionally making one crontab due immediately to catch up on runs missed while the schedule was paused.
+func (m ScheduleManager) Resume(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var timezone string
+	err = tx.QueryRow("SELECT `timezone` FROM `schedule` WHERE `idschedule` = ? AND active = 1 AND paused = 1 FOR UPDATE",
+		scheduleID).Scan(&timezone)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.Query("SELECT `idcrontab`, `crontab`, `next` FROM `crontab` WHERE `idschedule` = ?", scheduleID)
+	if err != nil {
+		return false, err
+	}
+	var crontabs []data.Crontab
+	for rows.Next() {
+		var ct data.Crontab
+		if err = rows.Scan(&ct.CrontabID, &ct.Crontab, &ct.Next); err != nil {
+			rows.Close()
+			return false, err
+		}
+		crontabs = append(crontabs, ct)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	next, caughtUp := crontab.Resume(crontabs, timezone, time.Now().UTC(), catchUp)
+	for i, ct := range crontabs {
+		_, err = tx.Exec("UPDATE `crontab` SET `next` = ? WHERE `idcrontab` = ?", next[i], ct.CrontabID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, err = tx.Exec("UPDATE `schedule` SET paused = 0 WHERE `idschedule` = ?", scheduleID)
+	if err != nil {
+		return false, err
+	}
+	_, err = tx.Exec("UPDATE `schedulepause` SET `resumeddate` = utc_timestamp(), `resumedby` = ?, `catchup` = ? "+
+		"WHERE `idschedule` = ? AND `resumeddate` IS NULL", by, caughtUp, scheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// GetScheduleByID gets a schedule's information by its ID.
+func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+	rows, err := m.DB.Query("call sm_getschedulebyid(?)", scheduleID)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
 	sc.Crontabs = make([]data.Crontab, 0)
-	var isActiveStr string
-	var deactivatedDate *time.Time
+	var isActiveStr, isPausedStr string
+	var deactivatedDate, until *time.Time
+	var httpRequestJSON, retryPolicyJSON sql.NullString
 	for rows.Next() {
 		var ct data.Crontab
 		err = rows.Scan(&sc.Schedule.ScheduleID,
@@ -136,9 +346,17 @@ func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCron
 			&sc.Schedule.By,
 			&sc.Schedule.ARN,
 			&sc.Schedule.Payload,
+			&httpRequestJSON,
+			&retryPolicyJSON,
+			&sc.Schedule.Timezone,
+			&until,
+			&sc.Schedule.MaxRuns,
+			&sc.Schedule.Runs,
+			&sc.Schedule.MisfirePolicy,
 			&sc.Schedule.Created,
 			&isActiveStr,
 			&deactivatedDate,
+			&isPausedStr,
 			&ct.CrontabID,
 			&ct.ScheduleID,
 			&ct.Crontab,
@@ -149,31 +367,198 @@ func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCron
 			return
 		}
 		sc.Schedule.Active = convertMySQLBoolean(isActiveStr)
+		sc.Schedule.Paused = convertMySQLBoolean(isPausedStr)
 		if deactivatedDate != nil {
 			sc.Schedule.DeactivatedDate = *deactivatedDate
 		}
+		if until != nil {
+			sc.Schedule.Until = *until
+		}
+		sc.Schedule.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		sc.Schedule.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
 		sc.Crontabs = append(sc.Crontabs, ct)
 		ok = true
 	}
+	if err = rows.Err(); err != nil || !ok {
+		return
+	}
+	// Release the connection before querying the pauses, so that a pool of one connection can't deadlock.
+	rows.Close()
+	sc.Pauses, err = getSchedulePauses(m.DB, scheduleID)
 	return
 }
 
-// GetSchedule is a ScheduleGetter which locks a schedule where Next is in the past, in order to schedule jobs.
-func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
+func getSchedulePauses(db *sql.DB, scheduleID int64) (pauses []data.SchedulePause, err error) {
+	rows, err := db.Query("SELECT `idschedulepause`, `idschedule`, `pauseddate`, `pausedby`, `resumeddate`, `resumedby`, `catchup` "+
+		"FROM `schedulepause` WHERE `idschedule` = ? ORDER BY `idschedulepause`", scheduleID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	pauses = make([]data.SchedulePause, 0)
+	for rows.Next() {
+		var p data.SchedulePause
+		var resumedDate gomysql.NullTime
+		var catchUpStr string
+		err = rows.Scan(&p.SchedulePauseID, &p.ScheduleID, &p.PausedDate, &p.PausedBy, &resumedDate, &p.ResumedBy, &catchUpStr)
+		if err != nil {
+			return
+		}
+		p.ResumedDate = resumedDate.Time
+		p.CatchUp = convertMySQLBoolean(catchUpStr)
+		pauses = append(pauses, p)
+	}
+	err = rows.Err()
+	return
+}
+
+// ListSchedules lists the schedules which match the filter in ID order, starting after the afterID.
+func (m ScheduleManager) ListSchedules(filter data.ScheduleFilter, afterID int64, limit int) (scs []data.ScheduleSummary, err error) {
+	where := []string{"sc.idschedule > ?"}
+	args := []interface{}{afterID}
+	if filter.ExternalID != "" {
+		where = append(where, "sc.externalid = ?")
+		args = append(args, filter.ExternalID)
+	}
+	if filter.By != "" {
+		where = append(where, "sc.`by` = ?")
+		args = append(args, filter.By)
+	}
+	if filter.Active != nil {
+		where = append(where, "sc.active = ?")
+		args = append(args, *filter.Active)
+	}
+	if filter.ARN != "" {
+		where = append(where, "sc.arn = ?")
+		args = append(args, filter.ARN)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where = append(where, "sc.created >= ?")
+		args = append(args, filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		where = append(where, "sc.created < ?")
+		args = append(args, filter.CreatedTo.UTC())
+	}
+	args = append(args, limit)
+	scs, err = listSchedules(m.DB, "SELECT "+
+		"sc.idschedule, sc.externalid, sc.`by`, sc.arn, sc.payload, sc.httprequest, sc.retrypolicy, sc.timezone, "+
+		"sc.`until`, sc.maxruns, sc.runs, sc.misfirepolicy, sc.created, sc.active, sc.deactivateddate, sc.paused "+
+		"FROM `schedule` sc "+
+		"WHERE "+strings.Join(where, " AND ")+" "+
+		"ORDER BY sc.idschedule ASC "+
+		"LIMIT ?", args...)
+	if err != nil || len(scs) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(scs))
+	for i, ss := range scs {
+		ids[i] = ss.Schedule.ScheduleID
+	}
+	crontabs, err := getCrontabs(m.DB, ids)
 	if err != nil {
 		return
 	}
-	defer db.Close()
+	for i := range scs {
+		scs[i].Crontabs = append(scs[i].Crontabs, crontabs[scs[i].Schedule.ScheduleID]...)
+	}
+	return
+}
 
-	rows, err := db.Query("call sm_getschedule(?, ?)", lockedBy, lockExpiryMinutes)
+func listSchedules(db *sql.DB, query string, args ...interface{}) (scs []data.ScheduleSummary, err error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
-	var isActiveStr string
-	var deactivatedDate *time.Time
+	scs = make([]data.ScheduleSummary, 0)
+	for rows.Next() {
+		sc := data.ScheduleSummary{Crontabs: make([]data.Crontab, 0)}
+		var isActiveStr, isPausedStr string
+		var deactivatedDate, until *time.Time
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&sc.Schedule.ScheduleID,
+			&sc.Schedule.ExternalID,
+			&sc.Schedule.By,
+			&sc.Schedule.ARN,
+			&sc.Schedule.Payload,
+			&httpRequestJSON,
+			&retryPolicyJSON,
+			&sc.Schedule.Timezone,
+			&until,
+			&sc.Schedule.MaxRuns,
+			&sc.Schedule.Runs,
+			&sc.Schedule.MisfirePolicy,
+			&sc.Schedule.Created,
+			&isActiveStr,
+			&deactivatedDate,
+			&isPausedStr)
+		if err != nil {
+			return
+		}
+		sc.Schedule.Active = convertMySQLBoolean(isActiveStr)
+		sc.Schedule.Paused = convertMySQLBoolean(isPausedStr)
+		if deactivatedDate != nil {
+			sc.Schedule.DeactivatedDate = *deactivatedDate
+		}
+		if until != nil {
+			sc.Schedule.Until = *until
+		}
+		sc.Schedule.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		sc.Schedule.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		scs = append(scs, sc)
+	}
+	err = rows.Err()
+	return
+}
+
+// getCrontabs gets the crontabs of the schedules in ID order, keyed by schedule ID.
+func getCrontabs(db *sql.DB, scheduleIDs []int64) (crontabs map[int64][]data.Crontab, err error) {
+	rows, err := db.Query("SELECT ct.idcrontab, ct.idschedule, ct.crontab, ct.previous, ct.`next`, ct.lastupdated "+
+		"FROM `crontab` ct WHERE ct.idschedule IN "+in(len(scheduleIDs))+" ORDER BY ct.idcrontab", args(scheduleIDs)...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	crontabs = make(map[int64][]data.Crontab)
+	for rows.Next() {
+		var ct data.Crontab
+		if err = rows.Scan(&ct.CrontabID, &ct.ScheduleID, &ct.Crontab, &ct.Previous, &ct.Next, &ct.LastUpdated); err != nil {
+			return
+		}
+		crontabs[ct.ScheduleID] = append(crontabs[ct.ScheduleID], ct)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetSchedule is a ScheduleGetter which locks a schedule where Next is in the past, in order to schedule jobs.
+func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+	rows, err := m.DB.Query("call sm_getschedule(?, ?)", lockedBy, lockExpiryMinutes)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	var isActiveStr, isPausedStr string
+	var deactivatedDate, until *time.Time
+	var httpRequestJSON, retryPolicyJSON sql.NullString
 	for rows.Next() {
 		err = rows.Scan(&sc.CrontabLeaseID,
 			&sc.Schedule.ScheduleID,
@@ -181,9 +566,17 @@ func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc
 			&sc.Schedule.By,
 			&sc.Schedule.ARN,
 			&sc.Schedule.Payload,
+			&httpRequestJSON,
+			&retryPolicyJSON,
+			&sc.Schedule.Timezone,
+			&until,
+			&sc.Schedule.MaxRuns,
+			&sc.Schedule.Runs,
+			&sc.Schedule.MisfirePolicy,
 			&sc.Schedule.Created,
 			&isActiveStr,
 			&deactivatedDate,
+			&isPausedStr,
 			&sc.Crontab.CrontabID,
 			&sc.Crontab.ScheduleID,
 			&sc.Crontab.Crontab,
@@ -191,9 +584,21 @@ func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc
 			&sc.Crontab.Next,
 			&sc.Crontab.LastUpdated)
 		sc.Schedule.Active = convertMySQLBoolean(isActiveStr)
+		sc.Schedule.Paused = convertMySQLBoolean(isPausedStr)
 		if deactivatedDate != nil {
 			sc.Schedule.DeactivatedDate = *deactivatedDate
 		}
+		if until != nil {
+			sc.Schedule.Until = *until
+		}
+		if err != nil {
+			return
+		}
+		sc.Schedule.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		sc.Schedule.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
 		if err != nil {
 			return
 		}
@@ -203,15 +608,10 @@ func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc
 }
 
 // StartJobAndUpdateCron starts a new job based on the schedule record's arn and payload and updates the existing crontab to the new date.
+// If the schedule has already started its maximum number of jobs, no job is started and the returned jobID is zero.
 // It requires a crontabLeaseID so that it can be cancelled, allowing crontab refreshes at a rate faster than the lease timeout.
 func (m ScheduleManager) StartJobAndUpdateCron(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
-	db, err := sql.Open("mysql", m.ConnectionString)
-	if err != nil {
-		return 0, err
-	}
-	defer db.Close()
-
-	rows, err := db.Query("call sm_startjobandupdatecron(?, ?, ?, ?)", crontabID, scheduleID, crontabLeaseID, newNext)
+	rows, err := m.DB.Query("call sm_startjobandupdatecron(?, ?, ?, ?)", crontabID, scheduleID, crontabLeaseID, newNext)
 	if err != nil {
 		return
 	}
@@ -222,3 +622,14 @@ func (m ScheduleManager) StartJobAndUpdateCron(crontabID, scheduleID, crontabLea
 
 	return
 }
+
+// SkipCrontab updates the crontab to the new date without starting a job, and releases the lease on it.
+func (m ScheduleManager) SkipCrontab(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+	_, err = m.DB.Exec("call sm_skipcrontab(?, ?, ?)", crontabID, crontabLeaseID, newNext)
+	return err
+}
+
+// PurgeCrontabLeases deletes up to limit crontab leases which expired before the cutoff.
+func (m ScheduleManager) PurgeCrontabLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, "DELETE FROM crontablease WHERE `until` < ? ORDER BY `until` ASC LIMIT ?", expiredBefore, limit)
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"os"
 
 	"github.com/welldigital/callme/logger"
 	"github.com/welldigital/callme/mysql/migrations"
@@ -13,49 +14,109 @@ import (
 	bindata "github.com/mattes/migrate/source/go-bindata"
 )
 
+// Open opens a pool of connections to the MySQL database. The pool is safe for concurrent use, so a
+// single pool should be shared by the managers, and closed when the process exits.
+func Open(connectionString string) (*sql.DB, error) {
+	return sql.Open("mysql", connectionString)
+}
+
 // MigrationManager provides features to manage jobs using MySQL.
 type MigrationManager struct {
-	ConnectionString string
+	DB *sql.DB
 }
 
 // NewMigrationManager creates a new MigrationManager.
-func NewMigrationManager(connectionString string) MigrationManager {
+func NewMigrationManager(db *sql.DB) MigrationManager {
 	return MigrationManager{
-		ConnectionString: connectionString,
+		DB: db,
 	}
 }
 
 // UpdateSchema updates the database schema to match.
 func (m MigrationManager) UpdateSchema() error {
-	db, err := sql.Open("mysql", m.ConnectionString)
+	r, err := m.migrator()
 	if err != nil {
 		return err
 	}
-	defer db.Close()
+	err = r.Up()
+	// Don't error on no changes.
+	if err == migrate.ErrNoChange {
+		err = nil
+	}
+	return err
+}
 
-	driver, err := mysql.WithInstance(db, &mysql.Config{})
+// Version returns the version of the database schema, and whether a migration failed part way through,
+// leaving the schema dirty. The version is 0 if no migrations have been run.
+func (m MigrationManager) Version() (version uint, dirty bool, err error) {
+	r, err := m.migrator()
 	if err != nil {
-		return err
+		return
+	}
+	version, dirty, err = r.Version()
+	if err == migrate.ErrNilVersion {
+		err = nil
 	}
+	return
+}
 
-	migrationSource, err := LoadMigrations()
+// LatestVersion returns the version of the last migration included with the program.
+func (m MigrationManager) LatestVersion() (uint, error) {
+	return latestVersion()
+}
+
+// Down reverts the last n migrations.
+func (m MigrationManager) Down(n int) error {
+	r, err := m.migrator()
 	if err != nil {
 		return err
 	}
+	return r.Steps(-n)
+}
 
-	r, err := migrate.NewWithInstance("go-bindata", migrationSource, "mysql", driver)
+// Goto migrates the database schema up or down to the version.
+func (m MigrationManager) Goto(version uint) error {
+	r, err := m.migrator()
 	if err != nil {
 		return err
 	}
-	r.Log = MigrationLogger{}
-	err = r.Up()
-	// Don't error on no changes.
+	err = r.Migrate(version)
 	if err == migrate.ErrNoChange {
 		err = nil
 	}
 	return err
 }
 
+// Force sets the version of the database schema without running any migrations, and clears the dirty flag.
+// It's used to recover after a migration has failed part way through and the database has been fixed by hand.
+func (m MigrationManager) Force(version int) error {
+	r, err := m.migrator()
+	if err != nil {
+		return err
+	}
+	return r.Force(version)
+}
+
+// migrator doesn't need to be closed, closing it would close the database which is shared with the managers.
+func (m MigrationManager) migrator() (*migrate.Migrate, error) {
+	driver, err := mysql.WithInstance(m.DB, &mysql.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	migrationSource, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := migrate.NewWithInstance("go-bindata", migrationSource, "mysql", driver)
+	if err != nil {
+		return nil, err
+	}
+	r.Log = MigrationLogger{}
+	return r, nil
+}
+
 // MigrationLogger provides a logger which the migration system can use.
 type MigrationLogger struct {
 }
@@ -79,3 +140,20 @@ func LoadMigrations() (source.Driver, error) {
 
 	return bindata.WithInstance(s)
 }
+
+func latestVersion() (version uint, err error) {
+	migrationSource, err := LoadMigrations()
+	if err != nil {
+		return
+	}
+	defer migrationSource.Close()
+	next, err := migrationSource.First()
+	for err == nil {
+		version = next
+		next, err = migrationSource.Next(version)
+	}
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
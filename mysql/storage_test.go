@@ -0,0 +1,51 @@
+package mysql_test
+
+import (
+	"database/sql"
+	"testing"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/welldigital/callme/mysql"
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	if !testing.Short() {
+		storagetest.Run(t, func(t *testing.T) (storage.Store, func()) {
+			dsn, dbName, err := mysql.CreateTestDatabase()
+			if err != nil {
+				t.Fatalf("failed to create test database with error: %v", err)
+			}
+			s, err := storage.Open(dsn, storage.PoolOptions{})
+			if err != nil {
+				mysql.DropTestDatabase(dbName)
+				t.Fatalf("failed to open test database with error: %v", err)
+			}
+			return s, func() {
+				s.Close()
+				mysql.DropTestDatabase(dbName)
+			}
+		})
+	}
+}
+
+func TestStartJobsInFewStatements(t *testing.T) {
+	if !testing.Short() {
+		dsn, dbName, err := mysql.CreateTestDatabase()
+		if err != nil {
+			t.Fatalf("failed to create test database with error: %v", err)
+		}
+		defer mysql.DropTestDatabase(dbName)
+		counter := &storagetest.StatementCounter{Driver: &gomysql.MySQLDriver{}}
+		sql.Register("mysql-counting", counter)
+		db, err := sql.Open("mysql-counting", dsn)
+		if err != nil {
+			t.Fatalf("failed to open test database with error: %v", err)
+		}
+		defer db.Close()
+
+		jm := mysql.NewJobManager(db)
+		storagetest.StartsJobsInFewStatements(t, jm.StartJobs, jm.GetJobResponse, counter)
+	}
+}
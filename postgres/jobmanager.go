@@ -0,0 +1,1121 @@
+package postgres
+
+import (
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/welldigital/callme/data"
+)
+
+// utcNow is the current UTC time, all times are stored in UTC.
+const utcNow = "(now() AT TIME ZONE 'utc')"
+
+// JobManager provides features to manage jobs using PostgreSQL.
+type JobManager struct {
+	DB *sql.DB
+}
+
+// NewJobManager creates a new JobManager.
+func NewJobManager(db *sql.DB) JobManager {
+	return JobManager{
+		DB: db,
+	}
+}
+
+// StartJob schedules a job to start in the future, unless a job was started with the dedupe key within its dedupe
+// window, in which case that job is returned.
+func (m JobManager) StartJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j data.Job, duplicate bool, err error) {
+	if dedupeKey == "" {
+		j, err = startJob(m.DB, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return
+	}
+	jobID, duplicate, err := dedupe(m.DB, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return j.JobID, err
+	})
+	if err != nil || !duplicate {
+		j.DedupeKey = dedupeKey
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	return j, true, err
+}
+
+// StartJobIdempotently starts a job, unless a job was already started with the idempotency key, in which case that
+// job is returned.
+func (m JobManager) StartJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	j, existing, duplicate, err = m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+	if err == nil || dedupeKey == "" {
+		return
+	}
+	// A concurrent request with the same dedupe key may have committed first, in which case the unique index rejected
+	// this one. Starting the job again finds the other request's job, and records the key against it.
+	if _, held, findErr := findDedupeKey(m.DB, dedupeKey); findErr != nil || !held {
+		return
+	}
+	return m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+}
+
+func (m JobManager) startJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	jobID, existing, err := idempotently(m.DB, idempotencyResourceJob, key, func(tx *sql.Tx) (int64, error) {
+		if dedupeKey == "" {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		}
+		var jobID int64
+		jobID, duplicate, err = startJobWithDedupeKey(tx, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		})
+		j.DedupeKey = dedupeKey
+		return jobID, err
+	})
+	if err != nil || (!existing && !duplicate) {
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	// If the job was started by a concurrent request with the same idempotency key, it's not a duplicate.
+	return j, existing, duplicate && !existing, err
+}
+
+// StartJobs starts a batch of jobs in a single transaction, so either all of the jobs are started, or none are. Jobs
+// without a dedupe key are inserted with a single statement, jobs with a dedupe key are started one at a time, so that
+// each key is checked against the jobs started before it.
+func (m JobManager) StartJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	if started, err = m.startJobs(jobs, dedupeWindow); err == nil {
+		return
+	}
+	// A concurrent request may have taken one of the batch's dedupe keys first, in which case the unique index
+	// rejected this batch. Starting the batch again finds the other request's job, and returns it as a duplicate.
+	if held, findErr := anyDedupeKeyHeld(m.DB, jobs); findErr != nil || !held {
+		return nil, err
+	}
+	return m.startJobs(jobs, dedupeWindow)
+}
+
+func (m JobManager) startJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	started = make([]data.StartedJob, len(jobs))
+	var undeduped []data.Job
+	var undedupedIndexes []int
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			undeduped = append(undeduped, j)
+			undedupedIndexes = append(undedupedIndexes, i)
+		}
+	}
+	inserted, err := insertJobs(tx, undeduped)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := range inserted {
+		started[undedupedIndexes[i]].Job = j
+	}
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			continue
+		}
+		if started[i], err = startDedupedJob(tx, j, dedupeWindow); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	// Duplicates are returned as they are now, which may be a job started earlier in the batch.
+	for i := range started {
+		if !started[i].Duplicate {
+			continue
+		}
+		if started[i].Job, err = m.getStartedJob(started[i].Job.JobID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func startDedupedJob(tx *sql.Tx, j data.Job, dedupeWindow time.Duration) (s data.StartedJob, err error) {
+	jobID, duplicate, err := startJobWithDedupeKey(tx, j.DedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		s.Job, err = startJob(tx, j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, j.ScheduleID)
+		return s.Job.JobID, err
+	})
+	if duplicate {
+		return data.StartedJob{Job: data.Job{JobID: jobID}, Duplicate: true}, err
+	}
+	s.Job.DedupeKey = j.DedupeKey
+	return s, err
+}
+
+// insertJobs inserts the jobs with a single statement, and returns them with their IDs. The jobs are passed as arrays,
+// and inserted in order, so that their IDs are taken from the sequence in the same order.
+func insertJobs(tx *sql.Tx, jobs []data.Job) (inserted []data.Job, err error) {
+	if len(jobs) == 0 {
+		return
+	}
+	inserted = make([]data.Job, len(jobs))
+	arns := make([]string, len(jobs))
+	payloads := make([]string, len(jobs))
+	httpRequests := make([]sql.NullString, len(jobs))
+	retryPolicies := make([]sql.NullString, len(jobs))
+	scheduleIDs := make([]*int64, len(jobs))
+	whens := make([]string, len(jobs))
+	for i, j := range jobs {
+		inserted[i] = data.Job{
+			ARN:         j.ARN,
+			Payload:     j.Payload,
+			HTTPRequest: j.HTTPRequest,
+			RetryPolicy: j.RetryPolicy,
+			ScheduleID:  j.ScheduleID,
+			When:        j.When,
+		}
+		if httpRequests[i], err = marshalHTTPRequest(j.HTTPRequest); err != nil {
+			return nil, err
+		}
+		if retryPolicies[i], err = marshalRetryPolicy(j.RetryPolicy); err != nil {
+			return nil, err
+		}
+		arns[i] = j.ARN
+		payloads[i] = j.Payload
+		scheduleIDs[i] = j.ScheduleID
+		whens[i] = j.When.UTC().Format("2006-01-02 15:04:05.999999")
+	}
+	rows, err := tx.Query(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") `+
+		`SELECT j.arn, j.payload, j.httprequest, j.retrypolicy, j.idschedule, j."when" `+
+		`FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::int[], $6::timestamp[]) WITH ORDINALITY `+
+		`AS j(arn, payload, httprequest, retrypolicy, idschedule, "when", n) `+
+		`ORDER BY j.n RETURNING idjob`,
+		pq.Array(arns), pq.Array(payloads), pq.Array(httpRequests), pq.Array(retryPolicies), pq.Array(scheduleIDs), pq.Array(whens))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var jobIDs []int64
+	for rows.Next() {
+		var jobID int64
+		if err = rows.Scan(&jobID); err != nil {
+			return nil, err
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't promise an order, but the IDs were taken from the sequence in the order of the jobs.
+	sort.Slice(jobIDs, func(i, j int) bool { return jobIDs[i] < jobIDs[j] })
+	for i := range inserted {
+		inserted[i].JobID = jobIDs[i]
+	}
+	return
+}
+
+// getStartedJob gets a job which was started by an earlier request.
+func (m JobManager) getStartedJob(jobID int64) (j data.Job, err error) {
+	j, _, ok, _, err := m.GetJobResponse(jobID)
+	if !ok {
+		// The job has been deleted since it was started.
+		j = data.Job{JobID: jobID}
+	}
+	return j, err
+}
+
+// startJob inserts a job using either the *sql.DB or a *sql.Tx.
+func startJob(q rowQueryer, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64) (data.Job, error) {
+	j := data.Job{
+		ARN:         arn,
+		Payload:     payload,
+		HTTPRequest: httpRequest,
+		RetryPolicy: retryPolicy,
+		ScheduleID:  scheduleID,
+		When:        when,
+	}
+
+	httpRequestJSON, err := marshalHTTPRequest(j.HTTPRequest)
+	if err != nil {
+		return j, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(j.RetryPolicy)
+	if err != nil {
+		return j, err
+	}
+
+	row := q.QueryRow(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") `+
+		`VALUES ($1, $2, $3, $4, $5, $6) RETURNING idjob`,
+		j.ARN, j.Payload, httpRequestJSON, retryPolicyJSON, j.ScheduleID, j.When.UTC())
+	err = row.Scan(&j.JobID)
+	return j, err
+}
+
+// GetAvailableJobCount returns the number of jobs present in the DB to process, i.e. where they have no job response and
+// they're ready to process.
+func (m JobManager) GetAvailableJobCount() (count int, err error) {
+	err = m.DB.QueryRow(`SELECT COUNT(*) FROM job j ` +
+		`WHERE j."when" <= ` + utcNow + ` AND NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob)`).Scan(&count)
+	return
+}
+
+// GetJob leases a job that's ready to run from the queue. The job's row is locked while the lease is taken, and
+// rows which are locked by other workers are skipped, so that workers don't wait for each other.
+func (m JobManager) GetJob(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	j := &lj.Job
+	// A job can have several leases once it has been retried, so only jobs without a current lease are available.
+	var httpRequestJSON, retryPolicyJSON sql.NullString
+	err = tx.QueryRow(`SELECT j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM job j `+
+		`WHERE `+
+		`j."when" <= `+utcNow+` AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= `+utcNow+`) `+
+		`ORDER BY j."when" ASC `+
+		`LIMIT 1 `+
+		`FOR UPDATE OF j SKIP LOCKED`).
+		Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	utc(&j.When)
+	j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+	if err != nil {
+		return
+	}
+	j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+	if err != nil {
+		return
+	}
+
+	err = tx.QueryRow(`INSERT INTO joblease (idjob, lockedby, "at", "until") `+
+		`VALUES ($1, $2, `+utcNow+`, `+utcNow+` + $3::int * INTERVAL '1 minute') RETURNING idjoblease`,
+		j.JobID, lockedBy, lockExpiryMinutes).Scan(&lj.JobLeaseID)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	ok = err == nil
+	return
+}
+
+// GetJobs leases up to limit jobs that are ready to run from the queue, in the order they're due. As with GetJob,
+// rows which are locked by other workers are skipped, so concurrent workers lease different jobs.
+func (m JobManager) GetJobs(lockedBy string, lockExpiryMinutes int, limit int) (jobs []data.LeasedJob, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM job j `+
+		`WHERE `+
+		`j."when" <= `+utcNow+` AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= `+utcNow+`) `+
+		`ORDER BY j."when" ASC `+
+		`LIMIT $1 `+
+		`FOR UPDATE OF j SKIP LOCKED`, limit)
+	if err != nil {
+		return
+	}
+	var jobIDs []int64
+	for rows.Next() {
+		var j data.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		utc(&j.When)
+		if j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, data.LeasedJob{Job: j})
+		jobIDs = append(jobIDs, j.JobID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+
+	rows, err = tx.Query(`INSERT INTO joblease (idjob, lockedby, "at", "until") `+
+		`SELECT unnest($1::int[]), $2, `+utcNow+`, `+utcNow+` + $3::int * INTERVAL '1 minute' `+
+		`RETURNING idjob, idjoblease`,
+		pq.Array(jobIDs), lockedBy, lockExpiryMinutes)
+	if err != nil {
+		return nil, err
+	}
+	leaseIDs := make(map[int64]int64, len(jobs))
+	for rows.Next() {
+		var jobID, leaseID int64
+		if err = rows.Scan(&jobID, &leaseID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		leaseIDs[jobID] = leaseID
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range jobs {
+		jobs[i].JobLeaseID = leaseIDs[jobs[i].Job.JobID]
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetJobResponse retrieves a completed job's data.
+func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+	var jrID, jrJobID sql.NullInt64
+	var jrTime pq.NullTime
+	var jrResp, jrError sql.NullString
+	var jrIsError sql.NullBool
+	var httpRequestJSON, retryPolicyJSON, dedupeKey sql.NullString
+	err = m.DB.QueryRow(`SELECT `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount, `+
+		`(SELECT d.dedupekey FROM jobdedupe d WHERE d.idjob = j.idjob) AS dedupekey, `+
+		`jr.idjobresponse, jr.idjob, jr."time", jr.response, jr.iserror, jr.error `+
+		`FROM job j `+
+		`LEFT JOIN jobresponse jr ON jr.idjob = j.idjob `+
+		`WHERE j.idjob = $1`, jobID).
+		Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &dedupeKey,
+			&jrID, &jrJobID, &jrTime, &jrResp, &jrIsError, &jrError)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	jobOK = true
+	j.DedupeKey = dedupeKey.String
+	utc(&j.When)
+	j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+	if err != nil {
+		return
+	}
+	j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+
+	if jrID.Int64 > 0 {
+		r.JobResponseID = jrID.Int64
+		r.JobID = jrJobID.Int64
+		r.Time = jrTime.Time.UTC()
+		r.Response = jrResp.String
+		r.IsError = jrIsError.Bool
+		r.Error = jrError.String
+	}
+	return j, r, jobOK, r.JobResponseID > 0, err
+}
+
+// insertAttempt records an attempt at a job, numbering it after the job's previous attempts.
+func insertAttempt(tx *sql.Tx, jobID int64, resp string, isError bool, errorString string, retryAt *time.Time) error {
+	_, err := tx.Exec(`INSERT INTO jobattempt (idjob, attempt, "time", response, iserror, error, retryat) `+
+		`SELECT $1, COUNT(*) + 1, `+utcNow+`, $2, $3, $4, $5 FROM jobattempt ja WHERE ja.idjob = $1`,
+		jobID, resp, isError, errorString, retryAt)
+	return err
+}
+
+// utc converts times read from TIMESTAMP columns, which lib/pq returns in a zero offset zone, to UTC.
+func utc(times ...*time.Time) {
+	for _, t := range times {
+		*t = t.UTC()
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// currentLease locks the job, and returns whether the lease is current. A lease is current if it's the job's latest
+// lease and the job hasn't been completed.
+func currentLease(tx *sql.Tx, jobID, jobLeaseID int64) (current bool, err error) {
+	err = tx.QueryRow(`SELECT `+
+		`COALESCE((SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = j.idjob) = $2, FALSE) AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) `+
+		`FROM job j WHERE j.idjob = $1 FOR UPDATE OF j`, jobID, jobLeaseID).Scan(&current)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return
+}
+
+// insertDuplicate records an execution which was completed using a lease that wasn't current.
+func insertDuplicate(tx *sql.Tx, jobID, jobLeaseID int64, resp string, isError bool, errorString string) error {
+	_, err := tx.Exec(`INSERT INTO jobduplicate (idjob, idjoblease, "time", response, iserror, error) `+
+		`VALUES ($1, $2, `+utcNow+`, $3, $4, $5)`, jobID, jobLeaseID, resp, isError, errorString)
+	return err
+}
+
+// CompleteJob marks a job as complete if the lease is current, otherwise the response is recorded as a duplicate.
+func (m JobManager) CompleteJob(jobID, jobLeaseID int64, resp string, jobError error) (duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := currentLease(tx, jobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		if err = insertDuplicate(tx, jobID, jobLeaseID, resp, jobError != nil, errorString(jobError)); err != nil {
+			return
+		}
+		return true, tx.Commit()
+	}
+	if err = insertAttempt(tx, jobID, resp, jobError != nil, errorString(jobError), nil); err != nil {
+		return
+	}
+	_, err = tx.Exec(`INSERT INTO jobresponse (idjob, "time", response, iserror, error) VALUES ($1, `+utcNow+`, $2, $3, $4)`,
+		jobID, resp, jobError != nil, errorString(jobError))
+	if err != nil {
+		return
+	}
+	if err = releaseDedupeKeys(tx, jobID); err != nil {
+		return
+	}
+	return false, tx.Commit()
+}
+
+// completionsSQL unnests the arrays returned by completionArrays into rows.
+const completionsSQL = `unnest($1::int[], $2::int[], $3::text[], $4::boolean[], $5::text[]) AS c(idjob, idjoblease, response, iserror, error)`
+
+// completionArrays converts completions to arrays of job IDs, lease IDs, responses, error flags and errors.
+func completionArrays(completions []data.JobCompletion) []interface{} {
+	jobIDs := make([]int64, len(completions))
+	leaseIDs := make([]int64, len(completions))
+	responses := make([]string, len(completions))
+	isErrors := make([]bool, len(completions))
+	errorStrings := make([]string, len(completions))
+	for i, c := range completions {
+		jobIDs[i] = c.JobID
+		leaseIDs[i] = c.JobLeaseID
+		responses[i] = c.Response
+		isErrors[i] = c.Err != nil
+		errorStrings[i] = errorString(c.Err)
+	}
+	return []interface{}{pq.Array(jobIDs), pq.Array(leaseIDs), pq.Array(responses), pq.Array(isErrors), pq.Array(errorStrings)}
+}
+
+// CompleteJobs marks a batch of jobs as complete. The completions are passed as arrays, so that the attempts and
+// responses are each inserted with a single statement. Completions whose leases aren't current are recorded as
+// duplicates.
+func (m JobManager) CompleteJobs(completions []data.JobCompletion) (duplicateJobIDs []int64, err error) {
+	if len(completions) == 0 {
+		return
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, duplicates, err := partitionCompletions(tx, completions)
+	if err != nil {
+		return
+	}
+	if len(current) > 0 {
+		_, err = tx.Exec(`INSERT INTO jobattempt (idjob, attempt, "time", response, iserror, error, retryat) `+
+			`SELECT c.idjob, (SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = c.idjob) + 1, `+utcNow+`, c.response, c.iserror, c.error, NULL `+
+			`FROM `+completionsSQL, completionArrays(current)...)
+		if err != nil {
+			return
+		}
+		_, err = tx.Exec(`INSERT INTO jobresponse (idjob, "time", response, iserror, error) `+
+			`SELECT c.idjob, `+utcNow+`, c.response, c.iserror, c.error `+
+			`FROM `+completionsSQL, completionArrays(current)...)
+		if err != nil {
+			return
+		}
+		jobIDs := make([]int64, len(current))
+		for i, c := range current {
+			jobIDs[i] = c.JobID
+		}
+		if err = releaseDedupeKeys(tx, jobIDs...); err != nil {
+			return
+		}
+	}
+	if len(duplicates) > 0 {
+		_, err = tx.Exec(`INSERT INTO jobduplicate (idjob, idjoblease, "time", response, iserror, error) `+
+			`SELECT c.idjob, c.idjoblease, `+utcNow+`, c.response, c.iserror, c.error `+
+			`FROM `+completionsSQL, completionArrays(duplicates)...)
+		if err != nil {
+			return
+		}
+		for _, c := range duplicates {
+			duplicateJobIDs = append(duplicateJobIDs, c.JobID)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// partitionCompletions locks the jobs, and splits the completions into those whose leases are current, and those
+// which are duplicates. A job which is completed more than once in the same batch is only completed by the first.
+func partitionCompletions(tx *sql.Tx, completions []data.JobCompletion) (current, duplicates []data.JobCompletion, err error) {
+	jobIDs := make([]int64, len(completions))
+	for i, c := range completions {
+		jobIDs[i] = c.JobID
+	}
+	rows, err := tx.Query(`SELECT j.idjob, (SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = j.idjob) `+
+		`FROM job j `+
+		`WHERE j.idjob = ANY($1::int[]) AND NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) `+
+		`FOR UPDATE OF j`, pq.Array(jobIDs))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	currentLeaseIDs := make(map[int64]int64)
+	for rows.Next() {
+		var jobID int64
+		var leaseID sql.NullInt64
+		if err = rows.Scan(&jobID, &leaseID); err != nil {
+			return
+		}
+		if leaseID.Valid {
+			currentLeaseIDs[jobID] = leaseID.Int64
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, c := range completions {
+		if leaseID, ok := currentLeaseIDs[c.JobID]; ok && leaseID == c.JobLeaseID {
+			current = append(current, c)
+			delete(currentLeaseIDs, c.JobID)
+			continue
+		}
+		duplicates = append(duplicates, c)
+	}
+	return
+}
+
+// RenewJobLease extends a lease on a job, so that it expires lockExpiryMinutes from now. It returns false if the
+// lease has expired, been released, or been taken over by another worker.
+func (m JobManager) RenewJobLease(jobID, jobLeaseID int64, lockExpiryMinutes int) (ok bool, err error) {
+	result, err := m.DB.Exec(`UPDATE joblease SET "until" = `+utcNow+` + $3::int * INTERVAL '1 minute' `+
+		`WHERE idjoblease = $2 AND idjob = $1 AND `+
+		`idjoblease = (SELECT MAX(idjoblease) FROM joblease WHERE idjob = $1) AND `+
+		`"until" >= `+utcNow, jobID, jobLeaseID, lockExpiryMinutes)
+	if err != nil {
+		return
+	}
+	renewed, err := result.RowsAffected()
+	ok = renewed > 0
+	return
+}
+
+// RetryJob records a failed attempt at a job and reschedules it to run again at the retryAt time, releasing
+// the lease so that any worker can pick it up. If the lease isn't current, the attempt is recorded as a duplicate.
+func (m JobManager) RetryJob(jobID, jobLeaseID int64, resp string, jobError error, retryAt time.Time) (duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := currentLease(tx, jobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		if err = insertDuplicate(tx, jobID, jobLeaseID, resp, true, errorString(jobError)); err != nil {
+			return
+		}
+		return true, tx.Commit()
+	}
+	retryAt = retryAt.UTC()
+	if err = insertAttempt(tx, jobID, resp, true, errorString(jobError), &retryAt); err != nil {
+		return
+	}
+	if _, err = tx.Exec(`UPDATE job SET "when" = $1 WHERE idjob = $2`, retryAt, jobID); err != nil {
+		return
+	}
+	// Release the lease so that any worker can pick up the job when it's due.
+	_, err = tx.Exec(`UPDATE joblease SET "until" = `+utcNow+` - INTERVAL '1 second' `+
+		`WHERE idjoblease = $1 AND "until" > `+utcNow, jobLeaseID)
+	if err != nil {
+		return
+	}
+	return false, tx.Commit()
+}
+
+// GetJobDuplicates gets the duplicate executions of a job, in order.
+func (m JobManager) GetJobDuplicates(jobID int64) (duplicates []data.JobDuplicate, err error) {
+	rows, err := m.DB.Query(`SELECT jd.idjobduplicate, jd.idjob, jd.idjoblease, jd."time", jd.response, jd.iserror, jd.error `+
+		`FROM jobduplicate jd WHERE jd.idjob = $1 ORDER BY jd.idjobduplicate ASC`, jobID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	duplicates = make([]data.JobDuplicate, 0)
+	for rows.Next() {
+		var d data.JobDuplicate
+		err = rows.Scan(&d.JobDuplicateID, &d.JobID, &d.JobLeaseID, &d.Time, &d.Response, &d.IsError, &d.Error)
+		if err != nil {
+			return
+		}
+		utc(&d.Time)
+		duplicates = append(duplicates, d)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetJobAttempts gets the attempts made to execute a job, in order.
+func (m JobManager) GetJobAttempts(jobID int64) (attempts []data.JobAttempt, err error) {
+	rows, err := m.DB.Query(`SELECT ja.idjobattempt, ja.idjob, ja.attempt, ja."time", ja.response, ja.iserror, ja.error, ja.retryat `+
+		`FROM jobattempt ja WHERE ja.idjob = $1 ORDER BY ja.attempt ASC`, jobID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	attempts = make([]data.JobAttempt, 0)
+	for rows.Next() {
+		var a data.JobAttempt
+		var retryAt pq.NullTime
+		err = rows.Scan(&a.JobAttemptID, &a.JobID, &a.Attempt, &a.Time, &a.Response, &a.IsError, &a.Error, &retryAt)
+		if err != nil {
+			return
+		}
+		a.RetryAt = retryAt.Time.UTC()
+		utc(&a.Time)
+		attempts = append(attempts, a)
+	}
+	err = rows.Err()
+	return
+}
+
+// leasedSQL matches jobs which have a lease that hasn't expired.
+const leasedSQL = `EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= ` + utcNow + `)`
+
+// ListJobs lists the jobs which match the filter in ID order, starting after the afterID.
+func (m JobManager) ListJobs(filter data.JobFilter, afterID int64, limit int) (jobs []data.JobSummary, err error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"j.idjob > " + arg(afterID)}
+	if filter.ScheduleID != nil {
+		where = append(where, "j.idschedule = "+arg(*filter.ScheduleID))
+	}
+	switch filter.State {
+	case data.JobStatePending:
+		where = append(where, "jr.idjob IS NULL AND NOT "+leasedSQL)
+	case data.JobStateLeased:
+		where = append(where, "jr.idjob IS NULL AND "+leasedSQL)
+	case data.JobStateSucceeded:
+		where = append(where, "NOT jr.iserror")
+	case data.JobStateFailed:
+		where = append(where, "jr.iserror")
+	}
+	if !filter.WhenFrom.IsZero() {
+		where = append(where, `j."when" >= `+arg(filter.WhenFrom.UTC()))
+	}
+	if !filter.WhenTo.IsZero() {
+		where = append(where, `j."when" < `+arg(filter.WhenTo.UTC()))
+	}
+	if filter.ARNPrefix != "" {
+		where = append(where, "j.arn LIKE "+arg(likePrefix(filter.ARNPrefix)))
+	}
+	if filter.IsError != nil {
+		where = append(where, "EXISTS (SELECT 1 FROM jobattempt ja WHERE ja.idjob = j.idjob AND ja.iserror) = "+arg(*filter.IsError))
+	}
+	rows, err := m.DB.Query(`SELECT `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount, `+
+		`CASE WHEN jr.iserror THEN 'failed' WHEN jr.idjob IS NOT NULL THEN 'succeeded' `+
+		`WHEN `+leasedSQL+` THEN 'leased' ELSE 'pending' END AS state `+
+		`FROM job j `+
+		`LEFT JOIN jobresponse jr ON jr.idjob = j.idjob `+
+		`WHERE `+strings.Join(where, " AND ")+` `+
+		`ORDER BY j.idjob ASC `+
+		`LIMIT `+arg(limit), args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	jobs = make([]data.JobSummary, 0)
+	for rows.Next() {
+		var js data.JobSummary
+		j := &js.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &js.State)
+		if err != nil {
+			return
+		}
+		utc(&j.When)
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		jobs = append(jobs, js)
+	}
+	err = rows.Err()
+	return
+}
+
+// likePrefix returns a LIKE pattern which matches strings that start with the prefix.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// DeleteJob deletes a job which hasn't been started.
+func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
+	result, err := m.DB.Exec(`DELETE FROM job j WHERE j.idjob = $1 AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob)`, jobID)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	ok = affected > 0
+	return
+}
+
+// DeadLetterJob records the final failed attempt at a job, completes it with an error and marks it as a dead letter.
+// If the lease isn't current, the attempt is recorded as a duplicate instead.
+func (m JobManager) DeadLetterJob(j data.Job, jobLeaseID int64, resp string, jobError error) (deadLetterID int64, duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := currentLease(tx, j.JobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		if err = insertDuplicate(tx, j.JobID, jobLeaseID, resp, true, errorString(jobError)); err != nil {
+			return
+		}
+		return 0, true, tx.Commit()
+	}
+	if err = insertAttempt(tx, j.JobID, resp, true, errorString(jobError), nil); err != nil {
+		return
+	}
+	_, err = tx.Exec(`INSERT INTO jobresponse (idjob, "time", response, iserror, error) VALUES ($1, `+utcNow+`, $2, TRUE, $3)`,
+		j.JobID, resp, errorString(jobError))
+	if err != nil {
+		return
+	}
+	if err = releaseDedupeKeys(tx, j.JobID); err != nil {
+		return
+	}
+	err = tx.QueryRow(`INSERT INTO deadletter (idjob, "time", error) VALUES ($1, `+utcNow+`, $2) RETURNING iddeadletter`,
+		j.JobID, errorString(jobError)).Scan(&deadLetterID)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// RecordDeadLetterForward records the result of forwarding a dead letter to the dead letter ARN.
+func (m JobManager) RecordDeadLetterForward(deadLetterID int64, forwardedTo string, forwardError error) error {
+	var errorString sql.NullString
+	if forwardError != nil {
+		errorString.String = forwardError.Error()
+		errorString.Valid = true
+	}
+	_, err := m.DB.Exec(`UPDATE deadletter SET forwardedto = $1, forwarderror = $2 WHERE iddeadletter = $3`,
+		forwardedTo, errorString, deadLetterID)
+	return err
+}
+
+// GetDeadLetters lists dead letters in ID order, starting after the afterID.
+func (m JobManager) GetDeadLetters(requeued bool, afterID int64, limit int) (dls []data.DeadLetter, err error) {
+	rows, err := m.DB.Query(`SELECT `+
+		`dl.iddeadletter, dl."time", dl.error, dl.forwardedto, dl.forwarderror, dl.idrequeuedjob, dl.requeueddate, `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM deadletter dl `+
+		`INNER JOIN job j ON j.idjob = dl.idjob `+
+		`WHERE dl.iddeadletter > $1 AND (dl.idrequeuedjob IS NOT NULL) = $2 `+
+		`ORDER BY dl.iddeadletter ASC `+
+		`LIMIT $3`, afterID, requeued, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	dls = make([]data.DeadLetter, 0)
+	for rows.Next() {
+		var dl data.DeadLetter
+		var forwardedTo, forwardError sql.NullString
+		var requeuedJobID sql.NullInt64
+		var requeuedDate pq.NullTime
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&dl.DeadLetterID, &dl.Time, &dl.Error, &forwardedTo, &forwardError, &requeuedJobID, &requeuedDate,
+			&dl.Job.JobID, &dl.Job.ScheduleID, &dl.Job.When, &dl.Job.ARN, &dl.Job.Payload, &httpRequestJSON, &retryPolicyJSON, &dl.Job.AttemptCount)
+		if err != nil {
+			return
+		}
+		dl.ForwardedTo = forwardedTo.String
+		dl.ForwardError = forwardError.String
+		if requeuedJobID.Valid {
+			dl.RequeuedJobID = &requeuedJobID.Int64
+		}
+		dl.RequeuedDate = requeuedDate.Time.UTC()
+		utc(&dl.Time, &dl.Job.When)
+		dl.Job.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		dl.Job.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		dls = append(dls, dl)
+	}
+	err = rows.Err()
+	return
+}
+
+// RequeueDeadLetters creates a new job for each dead letter, returning a map of dead letter ID to new job ID.
+func (m JobManager) RequeueDeadLetters(deadLetterIDs []int64) (jobIDs map[int64]int64, err error) {
+	jobIDs = make(map[int64]int64)
+	for _, id := range deadLetterIDs {
+		var jobID int64
+		var ok bool
+		jobID, ok, err = requeueDeadLetter(m.DB, id)
+		if err != nil {
+			return
+		}
+		if ok {
+			jobIDs[id] = jobID
+		}
+	}
+	return
+}
+
+// requeueDeadLetter creates a new job with the same data as the dead job, so that the history of the dead job is kept.
+func requeueDeadLetter(db *sql.DB, deadLetterID int64) (jobID int64, ok bool, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var deadJobID int64
+	err = tx.QueryRow(`SELECT dl.idjob FROM deadletter dl WHERE dl.iddeadletter = $1 AND dl.idrequeuedjob IS NULL FOR UPDATE`,
+		deadLetterID).Scan(&deadJobID)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	err = tx.QueryRow(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") `+
+		`SELECT j.arn, j.payload, j.httprequest, j.retrypolicy, j.idschedule, `+utcNow+` FROM job j WHERE j.idjob = $1 `+
+		`RETURNING idjob`, deadJobID).Scan(&jobID)
+	if err != nil {
+		return
+	}
+	_, err = tx.Exec(`UPDATE deadletter SET idrequeuedjob = $1, requeueddate = `+utcNow+` WHERE iddeadletter = $2`,
+		jobID, deadLetterID)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	ok = err == nil
+	return
+}
+
+// PurgeJobs deletes up to limit jobs which were completed before the cutoff, along with their responses, attempts,
+// duplicates and leases. Jobs which have been dead lettered are kept.
+func (m JobManager) PurgeJobs(completedBefore time.Time, limit int) (purged int, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	jobIDs, err := queryJobIDs(tx, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr."time" < $1 AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) `+
+		`ORDER BY jr."time" ASC LIMIT $2`+` FOR UPDATE OF jr SKIP LOCKED`, completedBefore.UTC(), limit)
+	if err != nil || len(jobIDs) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, jobIDs); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(jobIDs), nil
+}
+
+// GetCompletedJobs gets up to limit jobs which were completed before the cutoff, oldest first, with their responses,
+// attempts and duplicates. Jobs which have been dead lettered aren't included.
+func (m JobManager) GetCompletedJobs(completedBefore time.Time, limit int) (jrs []data.JobAndResponse, err error) {
+	jobIDs, err := queryJobIDs(m.DB, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr."time" < $1 AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) `+
+		`ORDER BY jr."time" ASC LIMIT $2`, completedBefore.UTC(), limit)
+	if err != nil {
+		return
+	}
+	jrs = make([]data.JobAndResponse, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		var jr data.JobAndResponse
+		var jobOK bool
+		jr.Job, jr.JobResponse, jobOK, jr.HasJobResponse, err = m.GetJobResponse(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if !jobOK {
+			// The job was purged in the meantime.
+			continue
+		}
+		if jr.Attempts, err = m.GetJobAttempts(jobID); err != nil {
+			return nil, err
+		}
+		if jr.Duplicates, err = m.GetJobDuplicates(jobID); err != nil {
+			return nil, err
+		}
+		jrs = append(jrs, jr)
+	}
+	return
+}
+
+// DeleteCompletedJobs deletes the jobs, along with their responses, attempts, duplicates and leases. Jobs which
+// haven't been completed, or have been dead lettered, are kept.
+func (m JobManager) DeleteCompletedJobs(jobIDs []int64) (deleted int, err error) {
+	if len(jobIDs) == 0 {
+		return
+	}
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	completed, err := queryJobIDs(tx, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr.idjob = ANY($1::int[]) AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) `+
+		`FOR UPDATE OF jr`, pq.Array(jobIDs))
+	if err != nil || len(completed) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, completed); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(completed), nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// rowQueryer is implemented by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// queryJobIDs runs a query which returns job IDs.
+func queryJobIDs(q queryer, query string, args ...interface{}) (jobIDs []int64, err error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int64
+		if err = rows.Scan(&jobID); err != nil {
+			return
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+	return jobIDs, rows.Err()
+}
+
+// deleteJobs deletes the jobs, and the rows which reference them before the jobs themselves.
+func deleteJobs(tx *sql.Tx, jobIDs []int64) error {
+	for _, table := range []string{"jobattempt", "jobduplicate", "joblease", "jobresponse", "job"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE idjob = ANY($1::int[])`, pq.Array(jobIDs)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeJobLeases deletes up to limit job leases which expired before the cutoff.
+func (m JobManager) PurgeJobLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM joblease WHERE idjoblease IN `+
+		`(SELECT idjoblease FROM joblease WHERE "until" < $1 ORDER BY "until" ASC LIMIT $2)`, expiredBefore, limit)
+}
+
+// PurgeIdempotencyKeys deletes up to limit idempotency keys which were created before the cutoff.
+func (m JobManager) PurgeIdempotencyKeys(createdBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM idempotencykey WHERE ididempotencykey IN `+
+		`(SELECT ididempotencykey FROM idempotencykey WHERE created < $1 ORDER BY created ASC LIMIT $2)`, createdBefore, limit)
+}
+
+// PurgeDedupeKeys deletes up to limit dedupe keys whose windows ended before the cutoff.
+func (m JobManager) PurgeDedupeKeys(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM jobdedupe WHERE idjobdedupe IN `+
+		`(SELECT idjobdedupe FROM jobdedupe WHERE expires < $1 ORDER BY expires ASC LIMIT $2)`, expiredBefore, limit)
+}
+
+// purge runs a DELETE statement which takes a cutoff time and a limit, and returns the number of rows deleted.
+// PostgreSQL has no DELETE ... LIMIT, so the statement has to select the rows to delete with a subquery.
+func purge(db *sql.DB, query string, before time.Time, limit int) (purged int, err error) {
+	res, err := db.Exec(query, before.UTC(), limit)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
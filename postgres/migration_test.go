@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/welldigital/callme/postgres/migrations"
+)
+
+func TestThatBinDataIsPresent(t *testing.T) {
+	names := migrations.AssetNames()
+	if len(names) == 0 {
+		t.Errorf("expected more than 0 names, but got %v", len(names))
+	}
+}
+
+func TestThatBinDataCanBeAccessed(t *testing.T) {
+	name := migrations.AssetNames()[0]
+	_, err := migrations.Asset(name)
+	if err != nil {
+		t.Errorf("couldn't load asset '%v' with error '%v'", name, err)
+	}
+}
+
+func TestMigrationsCanBeLoaded(t *testing.T) {
+	driver, err := LoadMigrations()
+	if err != nil {
+		t.Errorf("failed to load migrations: %v", err)
+	}
+	defer driver.Close()
+
+	v, err := driver.First()
+	if err != nil {
+		t.Errorf("failed to find any migrations: %v", err)
+	}
+	if v == 0 {
+		t.Error("got version 0, should have been different")
+	}
+}
+
+func TestThatEveryMigrationCanBeReverted(t *testing.T) {
+	names := map[string]bool{}
+	for _, name := range migrations.AssetNames() {
+		names[name] = true
+	}
+	for name := range names {
+		if strings.HasSuffix(name, ".up.sql") && !names[strings.TrimSuffix(name, ".up.sql")+".down.sql"] {
+			t.Errorf("migration '%v' doesn't have a down migration", name)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	first, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	defer first.Close()
+	v, err := first.First()
+	if err != nil {
+		t.Fatalf("failed to find any migrations: %v", err)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatalf("failed to get the latest version: %v", err)
+	}
+	if latest <= v {
+		t.Errorf("expected the latest version to be after the first version %v, got %v", v, latest)
+	}
+	if _, _, err = first.ReadUp(latest); err != nil {
+		t.Errorf("expected to be able to read the latest migration %v: %v", latest, err)
+	}
+}
@@ -0,0 +1,9 @@
+DROP TABLE deadletter;
+DROP TABLE jobattempt;
+DROP TABLE jobresponse;
+DROP TABLE joblease;
+DROP TABLE job;
+DROP TABLE schedulepause;
+DROP TABLE crontablease;
+DROP TABLE crontab;
+DROP TABLE schedule;
@@ -0,0 +1,104 @@
+CREATE TABLE schedule (
+  idschedule SERIAL PRIMARY KEY,
+  externalid VARCHAR(256) NOT NULL,
+  "by" VARCHAR(256) NOT NULL,
+  arn VARCHAR(2048) NOT NULL,
+  payload TEXT NOT NULL,
+  httprequest TEXT NULL,
+  retrypolicy TEXT NULL,
+  timezone VARCHAR(64) NOT NULL DEFAULT '',
+  "until" TIMESTAMP NULL,
+  maxruns INT NOT NULL DEFAULT 0,
+  runs INT NOT NULL DEFAULT 0,
+  misfirepolicy VARCHAR(16) NOT NULL DEFAULT '',
+  created TIMESTAMP NOT NULL,
+  active BOOLEAN NOT NULL,
+  deactivateddate TIMESTAMP NULL,
+  paused BOOLEAN NOT NULL DEFAULT FALSE);
+
+CREATE TABLE crontab (
+  idcrontab SERIAL PRIMARY KEY,
+  idschedule INT NOT NULL REFERENCES schedule(idschedule),
+  crontab VARCHAR(256) NOT NULL,
+  previous TIMESTAMP NOT NULL,
+  "next" TIMESTAMP NOT NULL,
+  lastupdated TIMESTAMP NOT NULL);
+
+CREATE INDEX idx_crontab_next ON crontab ("next");
+
+CREATE TABLE crontablease (
+  idcrontablease SERIAL PRIMARY KEY,
+  idcrontab INT NOT NULL REFERENCES crontab(idcrontab),
+  lockedby VARCHAR(256) NOT NULL,
+  "at" TIMESTAMP NOT NULL,
+  "until" TIMESTAMP NOT NULL,
+  rescinded BOOLEAN NOT NULL DEFAULT FALSE);
+
+CREATE INDEX idx_crontablease_idcrontab_until ON crontablease (idcrontab, "until");
+
+CREATE TABLE schedulepause (
+  idschedulepause SERIAL PRIMARY KEY,
+  idschedule INT NOT NULL REFERENCES schedule(idschedule),
+  pauseddate TIMESTAMP NOT NULL,
+  pausedby VARCHAR(256) NOT NULL,
+  resumeddate TIMESTAMP NULL,
+  resumedby VARCHAR(256) NOT NULL,
+  catchup BOOLEAN NOT NULL);
+
+CREATE INDEX idx_schedulepause_idschedule ON schedulepause (idschedule, idschedulepause);
+
+CREATE TABLE job (
+  idjob SERIAL PRIMARY KEY,
+  idschedule INT NULL REFERENCES schedule(idschedule),
+  "when" TIMESTAMP NOT NULL,
+  arn VARCHAR(2048) NULL,
+  payload TEXT NULL,
+  httprequest TEXT NULL,
+  retrypolicy TEXT NULL);
+
+CREATE INDEX idx_job_when ON job ("when");
+
+CREATE TABLE joblease (
+  idjoblease SERIAL PRIMARY KEY,
+  idjob INT NOT NULL REFERENCES job(idjob),
+  lockedby VARCHAR(256) NOT NULL,
+  "at" TIMESTAMP NOT NULL,
+  "until" TIMESTAMP NOT NULL);
+
+CREATE INDEX idx_joblease_idjob_until ON joblease (idjob, "until");
+
+CREATE TABLE jobresponse (
+  idjobresponse SERIAL PRIMARY KEY,
+  idjob INT NOT NULL REFERENCES job(idjob),
+  "time" TIMESTAMP NOT NULL,
+  response TEXT NOT NULL,
+  iserror BOOLEAN NOT NULL,
+  error TEXT NOT NULL);
+
+CREATE UNIQUE INDEX idx_jobresponse_idjob ON jobresponse (idjob);
+
+CREATE TABLE jobattempt (
+  idjobattempt SERIAL PRIMARY KEY,
+  idjob INT NOT NULL REFERENCES job(idjob),
+  attempt INT NOT NULL,
+  "time" TIMESTAMP NOT NULL,
+  response TEXT NOT NULL,
+  iserror BOOLEAN NOT NULL,
+  error TEXT NOT NULL,
+  retryat TIMESTAMP NULL);
+
+CREATE INDEX idx_jobattempt_idjob ON jobattempt (idjob, attempt);
+
+CREATE TABLE deadletter (
+  iddeadletter SERIAL PRIMARY KEY,
+  idjob INT NOT NULL REFERENCES job(idjob),
+  "time" TIMESTAMP NOT NULL,
+  error TEXT NOT NULL,
+  forwardedto VARCHAR(2048) NULL,
+  forwarderror TEXT NULL,
+  idrequeuedjob INT NULL,
+  requeueddate TIMESTAMP NULL);
+
+CREATE UNIQUE INDEX idx_deadletter_idjob ON deadletter (idjob);
+
+CREATE INDEX idx_deadletter_idrequeuedjob ON deadletter (idrequeuedjob, iddeadletter);
@@ -0,0 +1,3 @@
+DROP INDEX idx_crontablease_until;
+DROP INDEX idx_joblease_until;
+DROP INDEX idx_jobresponse_time;
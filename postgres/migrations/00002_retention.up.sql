@@ -0,0 +1,6 @@
+-- Indexes used by the retention worker to find the oldest responses and expired leases.
+CREATE INDEX idx_jobresponse_time ON jobresponse ("time");
+
+CREATE INDEX idx_joblease_until ON joblease ("until");
+
+CREATE INDEX idx_crontablease_until ON crontablease ("until");
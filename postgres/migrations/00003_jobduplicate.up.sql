@@ -0,0 +1,12 @@
+-- Executions which were completed using a lease that was no longer current. There's no reference to the lease,
+-- because expired leases are purged by the retention worker.
+CREATE TABLE jobduplicate (
+  idjobduplicate SERIAL PRIMARY KEY,
+  idjob INT NOT NULL REFERENCES job(idjob),
+  idjoblease INT NOT NULL,
+  "time" TIMESTAMP NOT NULL,
+  response TEXT NOT NULL,
+  iserror BOOLEAN NOT NULL,
+  error TEXT NOT NULL);
+
+CREATE INDEX idx_jobduplicate_idjob ON jobduplicate (idjob);
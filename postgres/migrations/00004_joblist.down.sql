@@ -0,0 +1,2 @@
+DROP INDEX idx_job_arn;
+DROP INDEX idx_job_idschedule;
@@ -0,0 +1,5 @@
+-- Indexes used to filter the jobs listed by the API. The ARN index uses varchar_pattern_ops so that it can be used
+-- to match ARN prefixes with LIKE.
+CREATE INDEX idx_job_idschedule ON job (idschedule, idjob);
+
+CREATE INDEX idx_job_arn ON job (arn varchar_pattern_ops);
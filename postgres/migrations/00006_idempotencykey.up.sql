@@ -0,0 +1,14 @@
+-- The idempotency keys of requests which created jobs and schedules. Keys are unique per resource and caller. There's
+-- no reference to the resource, because keys are kept after their jobs are purged or deleted.
+CREATE TABLE idempotencykey (
+  ididempotencykey SERIAL PRIMARY KEY,
+  resource VARCHAR(16) NOT NULL,
+  caller VARCHAR(256) NOT NULL,
+  idempotencykey VARCHAR(256) NOT NULL,
+  requesthash CHAR(64) NOT NULL,
+  resourceid INT NOT NULL,
+  created TIMESTAMP NOT NULL);
+
+CREATE UNIQUE INDEX idx_idempotencykey_resource_caller_key ON idempotencykey (resource, caller, idempotencykey);
+
+CREATE INDEX idx_idempotencykey_created ON idempotencykey (created);
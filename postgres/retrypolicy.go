@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/welldigital/callme/data"
+)
+
+// marshalRetryPolicy converts a RetryPolicy into JSON for storage, a nil RetryPolicy is stored as NULL.
+func marshalRetryPolicy(p *data.RetryPolicy) (s sql.NullString, err error) {
+	if p == nil {
+		return
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+	s.String = string(b)
+	s.Valid = true
+	return
+}
+
+// unmarshalRetryPolicy converts stored JSON back into a RetryPolicy, NULL values return nil.
+func unmarshalRetryPolicy(s sql.NullString) (p *data.RetryPolicy, err error) {
+	if !s.Valid || s.String == "" {
+		return
+	}
+	p = &data.RetryPolicy{}
+	err = json.Unmarshal([]byte(s.String), p)
+	return
+}
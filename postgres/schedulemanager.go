@@ -0,0 +1,595 @@
+package postgres
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/welldigital/callme/crontab"
+	"github.com/welldigital/callme/data"
+)
+
+// ScheduleManager provides features to manage schedules using PostgreSQL.
+type ScheduleManager struct {
+	DB *sql.DB
+}
+
+// NewScheduleManager creates a new ScheduleManager.
+func NewScheduleManager(db *sql.DB) ScheduleManager {
+	return ScheduleManager{
+		DB: db,
+	}
+}
+
+const scheduleColumns = `sc.idschedule, sc.externalid, sc."by", sc.arn, sc.payload, sc.httprequest, sc.retrypolicy, ` +
+	`sc.timezone, sc."until", sc.maxruns, sc.runs, sc.misfirepolicy, sc.created, sc.active, sc.deactivateddate, sc.paused`
+
+const crontabColumns = `ct.idcrontab, ct.idschedule, ct.crontab, ct.previous, ct."next", ct.lastupdated`
+
+// scheduleScanner scans the scheduleColumns into a Schedule.
+type scheduleScanner struct {
+	until, deactivatedDate           pq.NullTime
+	httpRequestJSON, retryPolicyJSON sql.NullString
+}
+
+func (ss *scheduleScanner) dest(s *data.Schedule) []interface{} {
+	return []interface{}{&s.ScheduleID, &s.ExternalID, &s.By, &s.ARN, &s.Payload, &ss.httpRequestJSON, &ss.retryPolicyJSON,
+		&s.Timezone, &ss.until, &s.MaxRuns, &s.Runs, &s.MisfirePolicy, &s.Created, &s.Active, &ss.deactivatedDate, &s.Paused}
+}
+
+func (ss *scheduleScanner) finish(s *data.Schedule) (err error) {
+	s.Until = ss.until.Time.UTC()
+	s.DeactivatedDate = ss.deactivatedDate.Time.UTC()
+	utc(&s.Created)
+	s.HTTPRequest, err = unmarshalHTTPRequest(ss.httpRequestJSON)
+	if err != nil {
+		return
+	}
+	s.RetryPolicy, err = unmarshalRetryPolicy(ss.retryPolicyJSON)
+	return
+}
+
+func crontabDest(ct *data.Crontab) []interface{} {
+	return []interface{}{&ct.CrontabID, &ct.ScheduleID, &ct.Crontab, &ct.Previous, &ct.Next, &ct.LastUpdated}
+}
+
+func nullTime(t time.Time) pq.NullTime {
+	return pq.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
+// Create creates a repeating schedule. Doesn't require a lease, any process can do this.
+func (m ScheduleManager) Create(from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err = createSchedule(tx, from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// CreateIdempotently creates a schedule, unless a schedule was already created with the idempotency key, in which
+// case its ID is returned.
+func (m ScheduleManager) CreateIdempotently(key data.IdempotencyKey, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, existing bool, err error) {
+	return idempotently(m.DB, idempotencyResourceSchedule, key, func(tx *sql.Tx) (int64, error) {
+		return createSchedule(tx, from, arn, payload, httpRequest, retryPolicy, crontabs, timezone, until, maxRuns, misfirePolicy, externalID, by)
+	})
+}
+
+// createSchedule inserts a schedule and its crontabs in the transaction.
+func createSchedule(tx *sql.Tx, from time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (id int64, err error) {
+	httpRequestJSON, err := marshalHTTPRequest(httpRequest)
+	if err != nil {
+		return 0, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(retryPolicy)
+	if err != nil {
+		return 0, err
+	}
+
+	created := time.Now().UTC()
+	err = tx.QueryRow(`INSERT INTO schedule `+
+		`(externalid, "by", arn, payload, httprequest, retrypolicy, timezone, "until", maxruns, misfirepolicy, created, active) `+
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, TRUE) RETURNING idschedule`,
+		externalID, by, arn, payload, httpRequestJSON, retryPolicyJSON, timezone, nullTime(until), maxRuns, misfirePolicy, created).
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Don't allow schedules to be started in the past, since the system will attempt
+	// to catch up.
+	startTime := from.UTC()
+	if from.Before(created) {
+		startTime = created
+	}
+
+	for _, ct := range crontabs {
+		// The first insertion needs to initialise dates with start times.
+		_, err = tx.Exec(`INSERT INTO crontab (idschedule, crontab, previous, "next", lastupdated) VALUES ($1, $2, $3, $4, $5)`,
+			id, ct, created, startTime, startTime)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// Update replaces the fields and crontabs of an active schedule. Unchanged crontabs keep their next run, while new
+// crontabs, and all of the crontabs if the timezone has changed, are next due at their first run after the update.
+// If a worker holds a lease on a crontab which would be removed or recalculated, data.ErrCrontabLeased is returned.
+func (m ScheduleManager) Update(scheduleID int64, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, crontabs []string, timezone string, until time.Time, maxRuns int, misfirePolicy string, externalID string, by string) (ok bool, err error) {
+	httpRequestJSON, err := marshalHTTPRequest(httpRequest)
+	if err != nil {
+		return false, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(retryPolicy)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Lock the schedule and its crontabs, so that workers skip the crontabs until the update is complete.
+	var previousTimezone string
+	err = tx.QueryRow(`SELECT timezone FROM schedule WHERE idschedule = $1 AND active FOR UPDATE`, scheduleID).
+		Scan(&previousTimezone)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.Query(`SELECT ct.idcrontab, ct.crontab, ct."next", `+
+		`EXISTS(SELECT 1 FROM crontablease ctl WHERE ctl.idcrontab = ct.idcrontab AND NOT ctl.rescinded AND ctl."until" >= `+utcNow+`) `+
+		`FROM crontab ct WHERE ct.idschedule = $1 FOR UPDATE OF ct`, scheduleID)
+	if err != nil {
+		return false, err
+	}
+	var existing []data.Crontab
+	leased := make(map[int64]bool)
+	for rows.Next() {
+		var ct data.Crontab
+		var isLeased bool
+		if err = rows.Scan(&ct.CrontabID, &ct.Crontab, &ct.Next, &isLeased); err != nil {
+			rows.Close()
+			return false, err
+		}
+		utc(&ct.Next)
+		existing = append(existing, ct)
+		leased[ct.CrontabID] = isLeased
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	added, updated, removed, err := crontab.Replace(existing, crontabs, timezone != previousTimezone, timezone, now)
+	if err != nil {
+		return false, err
+	}
+	for _, ct := range append(updated, removed...) {
+		if leased[ct.CrontabID] {
+			return false, data.ErrCrontabLeased
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE schedule SET externalid = $1, "by" = $2, arn = $3, payload = $4, httprequest = $5, retrypolicy = $6, `+
+		`timezone = $7, "until" = $8, maxruns = $9, misfirepolicy = $10 WHERE idschedule = $11`,
+		externalID, by, arn, payload, httpRequestJSON, retryPolicyJSON, timezone, nullTime(until), maxRuns, misfirePolicy, scheduleID)
+	if err != nil {
+		return false, err
+	}
+	for _, ct := range removed {
+		// Expired and rescinded leases reference the crontab, so they're removed with it.
+		if _, err = tx.Exec(`DELETE FROM crontablease WHERE idcrontab = $1`, ct.CrontabID); err != nil {
+			return false, err
+		}
+		if _, err = tx.Exec(`DELETE FROM crontab WHERE idcrontab = $1`, ct.CrontabID); err != nil {
+			return false, err
+		}
+	}
+	for _, ct := range updated {
+		_, err = tx.Exec(`UPDATE crontab SET "next" = $1, lastupdated = $2 WHERE idcrontab = $3`, ct.Next.UTC(), now, ct.CrontabID)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, ct := range added {
+		_, err = tx.Exec(`INSERT INTO crontab (idschedule, crontab, previous, "next", lastupdated) VALUES ($1, $2, $3, $4, $5)`,
+			scheduleID, ct.Crontab, now, ct.Next.UTC(), now)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// Deactivate deactivates a schedule.
+func (m ScheduleManager) Deactivate(scheduleID int64) (ok bool, err error) {
+	res, err := m.DB.Exec(`UPDATE schedule SET active = FALSE, deactivateddate = `+utcNow+` WHERE idschedule = $1`, scheduleID)
+	if err != nil {
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	ok = affectedRows > 0
+
+	return
+}
+
+// Pause pauses an active schedule, and records it in the schedule's pause history.
+func (m ScheduleManager) Pause(scheduleID int64, by string) (ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE schedule SET paused = TRUE WHERE idschedule = $1 AND active AND NOT paused`, scheduleID)
+	if err != nil {
+		return false, err
+	}
+	affectedRows, err := res.RowsAffected()
+	if err != nil || affectedRows == 0 {
+		return false, err
+	}
+
+	_, err = tx.Exec(`INSERT INTO schedulepause (idschedule, pauseddate, pausedby, resumedby, catchup) `+
+		`VALUES ($1, `+utcNow+`, $2, '', FALSE)`, scheduleID, by)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// Resume resumes a paused schedule, recalculating the next run of each crontab from the current time, and
+// optionally making one crontab due immediately to catch up on runs missed while the schedule was paused.
+func (m ScheduleManager) Resume(scheduleID int64, by string, catchUp bool) (ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var timezone string
+	err = tx.QueryRow(`SELECT timezone FROM schedule WHERE idschedule = $1 AND active AND paused FOR UPDATE`, scheduleID).
+		Scan(&timezone)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.Query(`SELECT idcrontab, crontab, "next" FROM crontab WHERE idschedule = $1`, scheduleID)
+	if err != nil {
+		return false, err
+	}
+	var crontabs []data.Crontab
+	for rows.Next() {
+		var ct data.Crontab
+		if err = rows.Scan(&ct.CrontabID, &ct.Crontab, &ct.Next); err != nil {
+			rows.Close()
+			return false, err
+		}
+		utc(&ct.Next)
+		crontabs = append(crontabs, ct)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	next, caughtUp := crontab.Resume(crontabs, timezone, time.Now().UTC(), catchUp)
+	for i, ct := range crontabs {
+		_, err = tx.Exec(`UPDATE crontab SET "next" = $1 WHERE idcrontab = $2`, next[i].UTC(), ct.CrontabID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if _, err = tx.Exec(`UPDATE schedule SET paused = FALSE WHERE idschedule = $1`, scheduleID); err != nil {
+		return false, err
+	}
+	_, err = tx.Exec(`UPDATE schedulepause SET resumeddate = `+utcNow+`, resumedby = $1, catchup = $2 `+
+		`WHERE idschedule = $3 AND resumeddate IS NULL`, by, caughtUp, scheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	return err == nil, err
+}
+
+// GetScheduleByID gets a schedule's information by its ID.
+func (m ScheduleManager) GetScheduleByID(scheduleID int64) (sc data.ScheduleCrontabs, ok bool, err error) {
+	rows, err := m.DB.Query(`SELECT `+scheduleColumns+`, `+crontabColumns+` `+
+		`FROM crontab ct `+
+		`INNER JOIN schedule sc ON sc.idschedule = ct.idschedule `+
+		`WHERE sc.idschedule = $1 `+
+		`ORDER BY ct.idcrontab`, scheduleID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	sc.Crontabs = make([]data.Crontab, 0)
+	for rows.Next() {
+		var ss scheduleScanner
+		var ct data.Crontab
+		if err = rows.Scan(append(ss.dest(&sc.Schedule), crontabDest(&ct)...)...); err != nil {
+			return
+		}
+		if err = ss.finish(&sc.Schedule); err != nil {
+			return
+		}
+		utc(&ct.Previous, &ct.Next, &ct.LastUpdated)
+		sc.Crontabs = append(sc.Crontabs, ct)
+		ok = true
+	}
+	if err = rows.Err(); err != nil || !ok {
+		return
+	}
+	// Release the connection before querying the pauses, so that a pool of one connection can't deadlock.
+	rows.Close()
+	sc.Pauses, err = getSchedulePauses(m.DB, scheduleID)
+	return
+}
+
+func getSchedulePauses(db *sql.DB, scheduleID int64) (pauses []data.SchedulePause, err error) {
+	rows, err := db.Query(`SELECT idschedulepause, idschedule, pauseddate, pausedby, resumeddate, resumedby, catchup `+
+		`FROM schedulepause WHERE idschedule = $1 ORDER BY idschedulepause`, scheduleID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	pauses = make([]data.SchedulePause, 0)
+	for rows.Next() {
+		var p data.SchedulePause
+		var resumedDate pq.NullTime
+		err = rows.Scan(&p.SchedulePauseID, &p.ScheduleID, &p.PausedDate, &p.PausedBy, &resumedDate, &p.ResumedBy, &p.CatchUp)
+		if err != nil {
+			return
+		}
+		p.ResumedDate = resumedDate.Time.UTC()
+		utc(&p.PausedDate)
+		pauses = append(pauses, p)
+	}
+	err = rows.Err()
+	return
+}
+
+// ListSchedules lists the schedules which match the filter in ID order, starting after the afterID.
+func (m ScheduleManager) ListSchedules(filter data.ScheduleFilter, afterID int64, limit int) (scs []data.ScheduleSummary, err error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"sc.idschedule > " + arg(afterID)}
+	if filter.ExternalID != "" {
+		where = append(where, "sc.externalid = "+arg(filter.ExternalID))
+	}
+	if filter.By != "" {
+		where = append(where, `sc."by" = `+arg(filter.By))
+	}
+	if filter.Active != nil {
+		where = append(where, "sc.active = "+arg(*filter.Active))
+	}
+	if filter.ARN != "" {
+		where = append(where, "sc.arn = "+arg(filter.ARN))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where = append(where, "sc.created >= "+arg(filter.CreatedFrom.UTC()))
+	}
+	if !filter.CreatedTo.IsZero() {
+		where = append(where, "sc.created < "+arg(filter.CreatedTo.UTC()))
+	}
+	scs, err = listSchedules(m.DB, `SELECT `+scheduleColumns+` `+
+		`FROM schedule sc `+
+		`WHERE `+strings.Join(where, " AND ")+` `+
+		`ORDER BY sc.idschedule ASC `+
+		`LIMIT `+arg(limit), args...)
+	if err != nil || len(scs) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(scs))
+	for i, ss := range scs {
+		ids[i] = ss.Schedule.ScheduleID
+	}
+	crontabs, err := getCrontabs(m.DB, ids)
+	if err != nil {
+		return
+	}
+	for i := range scs {
+		scs[i].Crontabs = append(scs[i].Crontabs, crontabs[scs[i].Schedule.ScheduleID]...)
+	}
+	return
+}
+
+func listSchedules(db *sql.DB, query string, args ...interface{}) (scs []data.ScheduleSummary, err error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	scs = make([]data.ScheduleSummary, 0)
+	for rows.Next() {
+		sc := data.ScheduleSummary{Crontabs: make([]data.Crontab, 0)}
+		var ss scheduleScanner
+		if err = rows.Scan(ss.dest(&sc.Schedule)...); err != nil {
+			return
+		}
+		if err = ss.finish(&sc.Schedule); err != nil {
+			return
+		}
+		scs = append(scs, sc)
+	}
+	err = rows.Err()
+	return
+}
+
+// getCrontabs gets the crontabs of the schedules in ID order, keyed by schedule ID.
+func getCrontabs(db *sql.DB, scheduleIDs []int64) (crontabs map[int64][]data.Crontab, err error) {
+	rows, err := db.Query(`SELECT `+crontabColumns+` FROM crontab ct `+
+		`WHERE ct.idschedule = ANY($1::int[]) ORDER BY ct.idcrontab`, pq.Array(scheduleIDs))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	crontabs = make(map[int64][]data.Crontab)
+	for rows.Next() {
+		var ct data.Crontab
+		if err = rows.Scan(crontabDest(&ct)...); err != nil {
+			return
+		}
+		utc(&ct.Previous, &ct.Next, &ct.LastUpdated)
+		crontabs[ct.ScheduleID] = append(crontabs[ct.ScheduleID], ct)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetSchedule is a ScheduleGetter which leases a crontab where Next is in the past, in order to schedule jobs. The
+// crontab's row is locked while the lease is taken, and rows which are locked by other workers are skipped.
+func (m ScheduleManager) GetSchedule(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var crontabID int64
+	err = tx.QueryRow(`SELECT ct.idcrontab ` +
+		`FROM crontab ct ` +
+		`INNER JOIN schedule sc ON sc.idschedule = ct.idschedule ` +
+		`WHERE ` +
+		`ct."next" <= ` + utcNow + ` AND ` +
+		`sc.active AND ` +
+		`NOT sc.paused AND ` +
+		`NOT EXISTS (SELECT 1 FROM crontablease ctl WHERE ctl.idcrontab = ct.idcrontab AND NOT ctl.rescinded AND ctl."until" >= ` + utcNow + `) ` +
+		`ORDER BY ct."next" ASC ` +
+		`LIMIT 1 ` +
+		`FOR UPDATE OF ct SKIP LOCKED`).Scan(&crontabID)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = tx.QueryRow(`INSERT INTO crontablease (idcrontab, lockedby, "at", "until", rescinded) `+
+		`VALUES ($1, $2, `+utcNow+`, `+utcNow+` + $3::int * INTERVAL '1 minute', FALSE) RETURNING idcrontablease`,
+		crontabID, lockedBy, lockExpiryMinutes).Scan(&sc.CrontabLeaseID)
+	if err != nil {
+		return
+	}
+
+	var ss scheduleScanner
+	err = tx.QueryRow(`SELECT `+scheduleColumns+`, `+crontabColumns+` `+
+		`FROM crontab ct `+
+		`INNER JOIN schedule sc ON sc.idschedule = ct.idschedule `+
+		`WHERE ct.idcrontab = $1`, crontabID).
+		Scan(append(ss.dest(&sc.Schedule), crontabDest(&sc.Crontab)...)...)
+	if err != nil {
+		return
+	}
+	if err = ss.finish(&sc.Schedule); err != nil {
+		return
+	}
+	utc(&sc.Crontab.Previous, &sc.Crontab.Next, &sc.Crontab.LastUpdated)
+
+	err = tx.Commit()
+	ok = err == nil
+	return
+}
+
+// StartJobAndUpdateCron starts a new job based on the schedule record's arn and payload and updates the existing crontab to the new date.
+// If the schedule has already started its maximum number of jobs, no job is started and the returned jobID is zero.
+// It requires a crontabLeaseID so that it can be cancelled, allowing crontab refreshes at a rate faster than the lease timeout.
+func (m ScheduleManager) StartJobAndUpdateCron(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Lock the schedule, so that concurrent crontabs can't start more than maxruns jobs.
+	var runs, maxRuns int
+	err = tx.QueryRow(`SELECT runs, maxruns FROM schedule WHERE idschedule = $1 FOR UPDATE`, scheduleID).Scan(&runs, &maxRuns)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	if err == nil && (maxRuns == 0 || runs < maxRuns) {
+		err = tx.QueryRow(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") `+
+			`SELECT s.arn, s.payload, s.httprequest, s.retrypolicy, s.idschedule, `+utcNow+` FROM schedule s WHERE s.idschedule = $1 `+
+			`RETURNING idjob`, scheduleID).Scan(&jobID)
+		if err != nil {
+			return 0, err
+		}
+		if _, err = tx.Exec(`UPDATE schedule SET runs = runs + 1 WHERE idschedule = $1`, scheduleID); err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE crontab SET previous = "next", "next" = $1, lastupdated = `+utcNow+` WHERE idcrontab = $2`,
+		newNext.UTC(), crontabID)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = tx.Exec(`UPDATE crontablease SET rescinded = TRUE WHERE idcrontablease = $1`, crontabLeaseID); err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	return jobID, err
+}
+
+// SkipCrontab updates the crontab to the new date without starting a job, and releases the lease on it.
+func (m ScheduleManager) SkipCrontab(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`UPDATE crontab SET "next" = $1, lastupdated = `+utcNow+` WHERE idcrontab = $2`, newNext.UTC(), crontabID)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE crontablease SET rescinded = TRUE WHERE idcrontablease = $1`, crontabLeaseID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PurgeCrontabLeases deletes up to limit crontab leases which expired before the cutoff.
+func (m ScheduleManager) PurgeCrontabLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM crontablease WHERE idcrontablease IN `+
+		`(SELECT idcrontablease FROM crontablease WHERE "until" < $1 ORDER BY "until" ASC LIMIT $2)`, expiredBefore, limit)
+}
@@ -0,0 +1,51 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/welldigital/callme/postgres"
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	if !testing.Short() {
+		storagetest.Run(t, func(t *testing.T) (storage.Store, func()) {
+			connectionString, dbName, err := postgres.CreateTestDatabase()
+			if err != nil {
+				t.Fatalf("failed to create test database with error: %v", err)
+			}
+			s, err := storage.Open(connectionString, storage.PoolOptions{})
+			if err != nil {
+				postgres.DropTestDatabase(dbName)
+				t.Fatalf("failed to open test database with error: %v", err)
+			}
+			return s, func() {
+				s.Close()
+				postgres.DropTestDatabase(dbName)
+			}
+		})
+	}
+}
+
+func TestStartJobsInFewStatements(t *testing.T) {
+	if !testing.Short() {
+		connectionString, dbName, err := postgres.CreateTestDatabase()
+		if err != nil {
+			t.Fatalf("failed to create test database with error: %v", err)
+		}
+		defer postgres.DropTestDatabase(dbName)
+		counter := &storagetest.StatementCounter{Driver: &pq.Driver{}}
+		sql.Register("postgres-counting", counter)
+		db, err := sql.Open("postgres-counting", connectionString)
+		if err != nil {
+			t.Fatalf("failed to open test database with error: %v", err)
+		}
+		defer db.Close()
+
+		jm := postgres.NewJobManager(db)
+		storagetest.StartsJobsInFewStatements(t, jm.StartJobs, jm.GetJobResponse, counter)
+	}
+}
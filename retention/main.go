@@ -0,0 +1,71 @@
+// Package retention deletes completed jobs and expired leases once they're older than their retention period, so
+// that the tables don't grow forever.
+package retention
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/metrics"
+	"github.com/welldigital/callme/repetitive"
+)
+
+const pkg = "github.com/welldigital/callme/retention"
+
+// DefaultBatchSize is the number of rows deleted from a table at once.
+const DefaultBatchSize = 1000
+
+// A Policy is the retention period of a table.
+type Policy struct {
+	// Table is the name of the table, used in logs and metrics.
+	Table string
+	// Retention is how long rows are kept for, 0 keeps them forever.
+	Retention time.Duration
+	// Purger deletes up to limit rows which are older than the cutoff, e.g. a data.JobsPurger.
+	Purger func(before time.Time, limit int) (purged int, err error)
+}
+
+// NewRetentionWorker creates a worker for the repetitive.Work function which deletes a batch of rows from each table
+// whose rows are older than its retention period. Deleting in small batches keeps each transaction short, so that the
+// job and schedule workers aren't locked out. The worker reports that work was done while any table has a full batch
+// to delete, so that the repetitive.Work function carries on without sleeping until the backlog is cleared.
+func NewRetentionWorker(workerName string, batchSize int, policies ...Policy) repetitive.Worker {
+	return func() (workDone bool, err error) {
+		return purge(workerName, time.Now().UTC(), batchSize, policies)
+	}
+}
+
+func purge(workerName string, now time.Time, batchSize int, policies []Policy) (workDone bool, err error) {
+	var failed int
+	for _, p := range policies {
+		if p.Retention <= 0 {
+			continue
+		}
+		before := now.Add(-p.Retention)
+		purgeStart := time.Now()
+		purged, purgeErr := p.Purger(before, batchSize)
+		purgeDuration := time.Since(purgeStart) / time.Millisecond
+		if purgeErr != nil {
+			logger.For(pkg, "purge").WithField("workerName", workerName).WithField("table", p.Table).WithError(purgeErr).Error("failed to purge")
+			metrics.RetentionPurgeDurations.WithLabelValues(p.Table, "error").Observe(float64(purgeDuration))
+			if err == nil {
+				err = purgeErr
+			}
+			failed++
+			continue
+		}
+		metrics.RetentionPurgeDurations.WithLabelValues(p.Table, "success").Observe(float64(purgeDuration))
+		metrics.RetentionPurgedCounts.WithLabelValues(p.Table).Add(float64(purged))
+		if purged > 0 {
+			logger.For(pkg, "purge").WithField("workerName", workerName).WithField("table", p.Table).WithField("before", before).Infof("purged %v rows", purged)
+		}
+		if purged >= batchSize {
+			workDone = true
+		}
+	}
+	if failed > 1 {
+		err = fmt.Errorf("failed to purge %v tables, including: %v", failed, err)
+	}
+	return
+}
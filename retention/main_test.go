@@ -0,0 +1,87 @@
+package retention
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const workerName = "retention_test"
+
+type purgeCall struct {
+	before time.Time
+	limit  int
+}
+
+// purger returns a purger which deletes up to the available number of rows, and records its calls.
+func purger(available int, err error, calls *[]purgeCall) func(before time.Time, limit int) (int, error) {
+	return func(before time.Time, limit int) (int, error) {
+		*calls = append(*calls, purgeCall{before: before, limit: limit})
+		if err != nil {
+			return 0, err
+		}
+		if available > limit {
+			return limit, nil
+		}
+		return available, nil
+	}
+}
+
+func TestThatEachTableIsPurgedUsingItsRetentionPeriod(t *testing.T) {
+	now := time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var jobCalls, leaseCalls, disabledCalls []purgeCall
+	policies := []Policy{
+		{Table: "job", Retention: time.Hour * 24 * 30, Purger: purger(5, nil, &jobCalls)},
+		{Table: "joblease", Retention: time.Hour * 24, Purger: purger(0, nil, &leaseCalls)},
+		{Table: "crontablease", Retention: 0, Purger: purger(5, nil, &disabledCalls)},
+	}
+
+	workDone, err := purge(workerName, now, 10, policies)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workDone {
+		t.Error("expected no more work to do, because each batch was smaller than the batch size")
+	}
+	if len(jobCalls) != 1 || !jobCalls[0].before.Equal(now.Add(-time.Hour*24*30)) || jobCalls[0].limit != 10 {
+		t.Errorf("expected jobs completed 30 days before now to be purged in a batch of 10, but got %+v", jobCalls)
+	}
+	if len(leaseCalls) != 1 || !leaseCalls[0].before.Equal(now.Add(-time.Hour*24)) {
+		t.Errorf("expected leases which expired a day before now to be purged, but got %+v", leaseCalls)
+	}
+	if len(disabledCalls) != 0 {
+		t.Errorf("expected a table with no retention period to be kept, but it was purged %v times", len(disabledCalls))
+	}
+}
+
+func TestThatAFullBatchMeansThereIsMoreWorkToDo(t *testing.T) {
+	var calls []purgeCall
+	w := NewRetentionWorker(workerName, 10, Policy{Table: "job", Retention: time.Hour, Purger: purger(25, nil, &calls)})
+
+	workDone, err := w()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !workDone {
+		t.Error("expected more work to do, because a full batch was purged")
+	}
+}
+
+func TestThatAFailedTableDoesNotStopOtherTablesBeingPurged(t *testing.T) {
+	var failedCalls, leaseCalls []purgeCall
+	policies := []Policy{
+		{Table: "job", Retention: time.Hour, Purger: purger(0, errors.New("deadlock"), &failedCalls)},
+		{Table: "joblease", Retention: time.Hour, Purger: purger(10, nil, &leaseCalls)},
+	}
+
+	workDone, err := purge(workerName, time.Now().UTC(), 10, policies)
+	if err == nil || err.Error() != "deadlock" {
+		t.Errorf("expected the error to be returned, but got %v", err)
+	}
+	if len(leaseCalls) != 1 {
+		t.Errorf("expected the leases to be purged after the jobs failed, but got %v calls", len(leaseCalls))
+	}
+	if !workDone {
+		t.Error("expected more work to do, because a full batch of leases was purged")
+	}
+}
@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// MaxAttempts is the largest number of attempts that a policy can allow.
+const MaxAttempts = 100
+
+// MaxIntervalSeconds is the largest delay that a policy can allow between attempts (one day).
+const MaxIntervalSeconds = 60 * 60 * 24
+
+// Default is the policy used by jobs which don't specify one, or for any fields of the policy
+// which are not set. It retries for a total of approximately 4 minutes.
+var Default = data.RetryPolicy{
+	MaxAttempts:            10,
+	InitialIntervalSeconds: 1,
+	MaxIntervalSeconds:     60,
+	Multiplier:             2,
+}
+
+// Policy returns the policy to use, replacing any missing values with the Default.
+func Policy(p *data.RetryPolicy) data.RetryPolicy {
+	if p == nil {
+		return Default
+	}
+	policy := *p
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = Default.MaxAttempts
+	}
+	if policy.InitialIntervalSeconds == 0 {
+		policy.InitialIntervalSeconds = Default.InitialIntervalSeconds
+	}
+	if policy.MaxIntervalSeconds == 0 {
+		policy.MaxIntervalSeconds = Default.MaxIntervalSeconds
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = Default.Multiplier
+	}
+	if policy.MaxIntervalSeconds < policy.InitialIntervalSeconds {
+		policy.MaxIntervalSeconds = policy.InitialIntervalSeconds
+	}
+	return policy
+}
+
+// ShouldRetry returns true if the policy allows another attempt after the given number of attempts have been made.
+func ShouldRetry(p *data.RetryPolicy, attempts int) bool {
+	return attempts < Policy(p).MaxAttempts
+}
+
+// Interval returns the delay before the next attempt, after the given number of attempts have been made.
+func Interval(p *data.RetryPolicy, attempts int) time.Duration {
+	policy := Policy(p)
+	if attempts < 1 {
+		attempts = 1
+	}
+	seconds := float64(policy.InitialIntervalSeconds) * math.Pow(policy.Multiplier, float64(attempts-1))
+	if seconds > float64(policy.MaxIntervalSeconds) {
+		seconds = float64(policy.MaxIntervalSeconds)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Validate checks that the policy's values are within range. A nil policy is valid, and a
+// zero value selects the default.
+func Validate(p *data.RetryPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 0 || p.MaxAttempts > MaxAttempts {
+		return errors.New("retryPolicy.maxAttempts must be 0 (default) or between 1 and 100")
+	}
+	if p.InitialIntervalSeconds < 0 || p.InitialIntervalSeconds > MaxIntervalSeconds {
+		return errors.New("retryPolicy.initialIntervalSeconds must be 0 (default) or between 1 and 86400")
+	}
+	if p.MaxIntervalSeconds < 0 || p.MaxIntervalSeconds > MaxIntervalSeconds {
+		return errors.New("retryPolicy.maxIntervalSeconds must be 0 (default) or between 1 and 86400")
+	}
+	if p.MaxIntervalSeconds > 0 && p.MaxIntervalSeconds < p.InitialIntervalSeconds {
+		return errors.New("retryPolicy.maxIntervalSeconds must not be less than retryPolicy.initialIntervalSeconds")
+	}
+	if p.Multiplier != 0 && (p.Multiplier < 1 || p.Multiplier > 10) {
+		return errors.New("retryPolicy.multiplier must be 0 (default) or between 1 and 10")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+func TestInterval(t *testing.T) {
+	p := &data.RetryPolicy{
+		MaxAttempts:            5,
+		InitialIntervalSeconds: 10,
+		MaxIntervalSeconds:     60,
+		Multiplier:             3,
+	}
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second * 10},
+		{attempts: 2, expected: time.Second * 30},
+		{attempts: 3, expected: time.Second * 60},
+		{attempts: 4, expected: time.Second * 60},
+	}
+	for _, test := range tests {
+		actual := Interval(p, test.attempts)
+		if actual != test.expected {
+			t.Errorf("attempt %v: expected interval %v, got %v", test.attempts, test.expected, actual)
+		}
+	}
+}
+
+func TestThatMissingValuesUseTheDefault(t *testing.T) {
+	if actual := Policy(nil); actual != Default {
+		t.Errorf("nil: expected %v, got %v", Default, actual)
+	}
+	actual := Policy(&data.RetryPolicy{MaxAttempts: 3})
+	expected := Default
+	expected.MaxAttempts = 3
+	if actual != expected {
+		t.Errorf("partial: expected %v, got %v", expected, actual)
+	}
+	if actual := Interval(nil, 1); actual != time.Second {
+		t.Errorf("nil: expected first interval of 1s, got %v", actual)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	p := &data.RetryPolicy{MaxAttempts: 2}
+	if !ShouldRetry(p, 1) {
+		t.Errorf("expected a retry after 1 of 2 attempts")
+	}
+	if ShouldRetry(p, 2) {
+		t.Errorf("expected no retry after 2 of 2 attempts")
+	}
+	if ShouldRetry(&data.RetryPolicy{MaxAttempts: 1}, 1) {
+		t.Errorf("expected no retry when only a single attempt is allowed")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		p           *data.RetryPolicy
+		expectedErr bool
+	}{
+		{name: "nil", p: nil},
+		{name: "empty", p: &data.RetryPolicy{}},
+		{name: "valid", p: &data.RetryPolicy{MaxAttempts: 3, InitialIntervalSeconds: 5, MaxIntervalSeconds: 60, Multiplier: 1.5}},
+		{name: "too many attempts", p: &data.RetryPolicy{MaxAttempts: 101}, expectedErr: true},
+		{name: "negative attempts", p: &data.RetryPolicy{MaxAttempts: -1}, expectedErr: true},
+		{name: "max less than initial", p: &data.RetryPolicy{InitialIntervalSeconds: 10, MaxIntervalSeconds: 5}, expectedErr: true},
+		{name: "interval too long", p: &data.RetryPolicy{MaxIntervalSeconds: 86401}, expectedErr: true},
+		{name: "multiplier too small", p: &data.RetryPolicy{Multiplier: 0.5}, expectedErr: true},
+	}
+	for _, test := range tests {
+		err := Validate(test.p)
+		if test.expectedErr != (err != nil) {
+			t.Errorf("%s: expected error=%v, got '%v'", test.name, test.expectedErr, err)
+		}
+	}
+}
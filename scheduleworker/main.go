@@ -3,30 +3,40 @@ package scheduleworker
 import (
 	"time"
 
+	"github.com/welldigital/callme/crontab"
 	"github.com/welldigital/callme/logger"
 	"github.com/welldigital/callme/metrics"
 	"github.com/welldigital/callme/repetitive"
-	cron "gopkg.in/robfig/cron.v2"
 
 	"github.com/welldigital/callme/data"
 )
 
 const pkg = "github.com/welldigital/callme/scheduleworker"
 
+// DefaultMisfireThreshold is how late a run can start before it's considered to be missed.
+const DefaultMisfireThreshold = time.Minute
+
 // NewScheduleWorker creates a worker for the repetitive.Work function which processes schedules and queues any required jobs.
+// Runs which are due more than the misfireThreshold in the past are missed, and handled by the schedule's misfire policy.
 func NewScheduleWorker(workerName string,
 	lockExpiryMinutes int,
+	misfireThreshold time.Duration,
 	scheduleGetter data.ScheduleGetter,
-	scheduledJobStarter data.ScheduledJobStarter) repetitive.Worker {
+	scheduledJobStarter data.ScheduledJobStarter,
+	crontabSkipper data.CrontabSkipper,
+	scheduleDeactivator data.ScheduleDeactivator) repetitive.Worker {
 	return func() (workDone bool, err error) {
-		return findAndExecuteWork(workerName, lockExpiryMinutes, scheduleGetter, scheduledJobStarter)
+		return findAndExecuteWork(workerName, lockExpiryMinutes, misfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 	}
 }
 
 func findAndExecuteWork(workerName string,
 	lockExpiryMinutes int,
+	misfireThreshold time.Duration,
 	scheduleGetter data.ScheduleGetter,
 	scheduledJobStarter data.ScheduledJobStarter,
+	crontabSkipper data.CrontabSkipper,
+	scheduleDeactivator data.ScheduleDeactivator,
 ) (workDone bool, err error) {
 	// See if there's some work to do.
 	scheduleGetStart := time.Now()
@@ -47,7 +57,14 @@ func findAndExecuteWork(workerName string,
 	metrics.ScheduleLeaseCounts.WithLabelValues("success").Inc()
 	metrics.ScheduleLeaseDurations.WithLabelValues("success").Observe(float64(scheduleGetDuration))
 
-	c, err := cron.Parse(sc.Crontab.Crontab)
+	// Don't start any more jobs if the schedule has ended.
+	if reason, ended := endReason(sc.Schedule, sc.Schedule.Runs, sc.Crontab.Next); ended {
+		err = deactivate(workerName, scheduleDeactivator, sc, reason)
+		workDone = true
+		return
+	}
+
+	c, err := crontab.Parse(sc.Crontab.Crontab, sc.Schedule.Timezone)
 	if err != nil {
 		logger.WithCrontab(pkg, "findAndExecuteWork", sc.Crontab).
 			WithField("workerName", workerName).
@@ -58,9 +75,24 @@ func findAndExecuteWork(workerName string,
 	}
 	metrics.ScheduleExecutedCounts.WithLabelValues("success").Inc()
 
-	// Schedule a job to run immediately and update the cronjob.
-	scheduleDelay := time.Now().UTC().Sub(sc.Crontab.Next) / time.Millisecond
-	newNext := c.Next(sc.Crontab.Next)
+	// Schedule a job to run immediately and update the cronjob. The next time is calculated
+	// in the schedule's timezone, skipping any missed runs if the misfire policy says so.
+	now := time.Now().UTC()
+	scheduleDelay := now.Sub(sc.Crontab.Next) / time.Millisecond
+	fire, newNext, skipped := misfire(c, sc.Schedule.MisfirePolicy, sc.Crontab.Next, now, misfireThreshold)
+
+	if !fire {
+		err = crontabSkipper(sc.Crontab.CrontabID, sc.CrontabLeaseID, newNext)
+		if err != nil {
+			logger.WithCrontab(pkg, "findAndExecuteWork", sc.Crontab).WithField("workerName", workerName).WithError(err).Error("failed to skip missed runs")
+			metrics.ScheduleJobStartedCounts.WithLabelValues("error").Inc()
+			workDone = true
+			return
+		}
+		recordSkipped(workerName, sc, skipped)
+		workDone = true
+		return
+	}
 
 	scheduledJobStartTime := time.Now()
 	jobID, err := scheduledJobStarter(sc.Crontab.CrontabID, sc.Schedule.ScheduleID, sc.CrontabLeaseID, newNext)
@@ -72,9 +104,80 @@ func findAndExecuteWork(workerName string,
 		workDone = true
 		return
 	}
+	recordSkipped(workerName, sc, skipped)
 	metrics.ScheduleExecutedDelay.Observe(float64(scheduleDelay))
 	metrics.ScheduleJobStartedCounts.WithLabelValues("success").Inc()
 	metrics.ScheduleJobStartedDurations.WithLabelValues("success").Observe(float64(scheduledJobStartDuration))
 	workDone = true
+
+	// Deactivate the schedule straight away if that was its last run. If the schedule is past its until
+	// time, it's deactivated the next time that one of its crontabs is due.
+	if sc.Schedule.MaxRuns > 0 && sc.Schedule.Runs+1 >= sc.Schedule.MaxRuns {
+		err = deactivate(workerName, scheduleDeactivator, sc, reasonMaxRuns)
+	}
 	return
 }
+
+// misfire applies the misfire policy to a crontab which was due at next, returning whether a job should be started,
+// the new Next value of the crontab, and the number of runs which were skipped.
+func misfire(c crontab.Schedule, policy string, next, now time.Time, threshold time.Duration) (fire bool, newNext time.Time, skipped int) {
+	newNext = c.Next(next)
+	if policy != data.MisfirePolicyFireOnce && policy != data.MisfirePolicySkip {
+		// Fire all missed runs, one at a time.
+		return true, newNext, 0
+	}
+	// Skip over any other runs which are also due.
+	for !newNext.IsZero() && !newNext.After(now) {
+		newNext = c.Next(newNext)
+		skipped++
+	}
+	if policy == data.MisfirePolicySkip && now.Sub(next) > threshold {
+		return false, newNext, skipped + 1
+	}
+	return true, newNext, skipped
+}
+
+func recordSkipped(workerName string, sc data.ScheduleCrontab, skipped int) {
+	if skipped == 0 {
+		return
+	}
+	logger.WithCrontab(pkg, "recordSkipped", sc.Crontab).
+		WithField("workerName", workerName).
+		WithField("misfirePolicy", sc.Schedule.MisfirePolicy).
+		WithField("skipped", skipped).
+		Warn("skipped missed runs")
+	metrics.ScheduleSkippedCounts.WithLabelValues(sc.Schedule.MisfirePolicy).Add(float64(skipped))
+}
+
+const reasonUntil = "until"
+const reasonMaxRuns = "max_runs"
+
+// endReason returns the reason that the schedule shouldn't start a job at the given time, after the given number of runs.
+func endReason(s data.Schedule, runs int, at time.Time) (reason string, ended bool) {
+	if s.MaxRuns > 0 && runs >= s.MaxRuns {
+		return reasonMaxRuns, true
+	}
+	if !s.Until.IsZero() && at.After(s.Until) {
+		return reasonUntil, true
+	}
+	return "", false
+}
+
+func deactivate(workerName string, scheduleDeactivator data.ScheduleDeactivator, sc data.ScheduleCrontab, reason string) error {
+	_, err := scheduleDeactivator(sc.Schedule.ScheduleID)
+	if err != nil {
+		logger.WithCrontab(pkg, "deactivate", sc.Crontab).
+			WithField("workerName", workerName).
+			WithField("reason", reason).
+			WithError(err).
+			Error("failed to deactivate schedule")
+		metrics.ScheduleDeactivatedCounts.WithLabelValues(reason, "error").Inc()
+		return err
+	}
+	logger.WithCrontab(pkg, "deactivate", sc.Crontab).
+		WithField("workerName", workerName).
+		WithField("reason", reason).
+		Info("schedule has ended, deactivated")
+	metrics.ScheduleDeactivatedCounts.WithLabelValues(reason, "success").Inc()
+	return nil
+}
@@ -21,13 +21,24 @@ func TestThatErrorsRetrievingSchedulesAreReturned(t *testing.T) {
 		return
 	}
 
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
 	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
 		actual.JobStarted = true
 		actual.NextTime = newNext
 		return 1, nil
 	}
 
-	w := NewScheduleWorker(nodeName, lockExpiryMins, scheduleGetter, scheduledJobStarter)
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -53,13 +64,24 @@ func TestThatIfNoJobsAreFoundNoUpdatesAreMade(t *testing.T) {
 		return
 	}
 
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
 	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
 		actual.JobStarted = true
 		actual.NextTime = newNext
 		return 1, nil
 	}
 
-	w := NewScheduleWorker(nodeName, lockExpiryMins, scheduleGetter, scheduledJobStarter)
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -108,13 +130,24 @@ func TestThatExpiredSchedulesStartNewJobs(t *testing.T) {
 		return
 	}
 
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
 	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
 		actual.JobStarted = true
 		actual.NextTime = newNext
 		return 1, nil
 	}
 
-	w := NewScheduleWorker(nodeName, lockExpiryMins, scheduleGetter, scheduledJobStarter)
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -131,6 +164,74 @@ func TestThatExpiredSchedulesStartNewJobs(t *testing.T) {
 	expected.Assert(t, actual)
 }
 
+func TestThatTheNextTimeIsCalculatedInTheScheduleTimezone(t *testing.T) {
+	actual := Values{}
+
+	// 9am on the Friday before the UK moves to BST.
+	next := time.Date(2018, time.March, 23, 9, 0, 0, 0, time.UTC)
+
+	scheduleGetter := func(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+		actual.ScheduleRetrieved = true
+		sc = data.ScheduleCrontab{
+			Schedule: data.Schedule{
+				ScheduleID:      1,
+				Active:          true,
+				ARN:             "testarn",
+				By:              "scheduleworker.main_test",
+				Created:         time.Now().UTC(),
+				DeactivatedDate: time.Time{},
+				ExternalID:      "externalid",
+				Payload:         "testpayload",
+				Timezone:        "Europe/London",
+			},
+			Crontab: data.Crontab{
+				Crontab:     "0 9 * * MON-FRI", // 9am every weekday
+				CrontabID:   1,
+				LastUpdated: next,
+				Next:        next,
+				Previous:    next.Add(-24 * time.Hour),
+				ScheduleID:  1,
+			},
+			CrontabLeaseID: 1,
+		}
+		ok = true
+		return
+	}
+
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
+	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
+		actual.JobStarted = true
+		actual.NextTime = newNext
+		return 1, nil
+	}
+
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
+
+	var err error
+	actual.WorkDone, err = w()
+	actual.ErrorOccurred = err != nil
+
+	expected := Values{
+		WorkDone:          true,
+		ScheduleRetrieved: true,
+		JobStarted:        true,
+		NextTime:          time.Date(2018, time.March, 26, 8, 0, 0, 0, time.UTC), // 9am BST
+		ErrorOccurred:     false,
+	}
+
+	expected.Assert(t, actual)
+}
+
 func TestThatErrorsParsingCronStatementsAreTracked(t *testing.T) {
 	actual := Values{}
 
@@ -163,13 +264,24 @@ func TestThatErrorsParsingCronStatementsAreTracked(t *testing.T) {
 		return
 	}
 
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
 	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
 		actual.JobStarted = true
 		actual.NextTime = newNext
 		return 1, nil
 	}
 
-	w := NewScheduleWorker(nodeName, lockExpiryMins, scheduleGetter, scheduledJobStarter)
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -218,11 +330,22 @@ func TestThatErrorsStartingWorkDoNotBlock(t *testing.T) {
 		return
 	}
 
+	scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+		actual.ScheduleDeactivated = true
+		return true, nil
+	}
+
 	scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
 		return 0, errors.New("this is a failure")
 	}
 
-	w := NewScheduleWorker(nodeName, lockExpiryMins, scheduleGetter, scheduledJobStarter)
+	crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+		actual.CrontabSkipped = true
+		actual.NextTime = newNext
+		return nil
+	}
+
+	w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
 
 	var err error
 	actual.WorkDone, err = w()
@@ -239,12 +362,280 @@ func TestThatErrorsStartingWorkDoNotBlock(t *testing.T) {
 	expected.Assert(t, actual)
 }
 
+func TestThatSchedulesAreDeactivatedWhenTheyEnd(t *testing.T) {
+	now := time.Now().UTC()
+	nextHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location())
+
+	tests := []struct {
+		name           string
+		until          time.Time
+		maxRuns        int
+		runs           int
+		deactivatorErr error
+		expected       Values
+	}{
+		{
+			name:    "no end",
+			until:   time.Time{},
+			maxRuns: 0,
+			runs:    100,
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          nextHour,
+			},
+		},
+		{
+			name:  "before the until time",
+			until: now,
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          nextHour,
+			},
+		},
+		{
+			name:  "after the until time",
+			until: now.Add(-time.Minute),
+			expected: Values{
+				WorkDone:            true,
+				ScheduleRetrieved:   true,
+				ScheduleDeactivated: true,
+			},
+		},
+		{
+			name:    "before the last run",
+			maxRuns: 3,
+			runs:    1,
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          nextHour,
+			},
+		},
+		{
+			name:    "the last run",
+			maxRuns: 3,
+			runs:    2,
+			expected: Values{
+				WorkDone:            true,
+				ScheduleRetrieved:   true,
+				JobStarted:          true,
+				NextTime:            nextHour,
+				ScheduleDeactivated: true,
+			},
+		},
+		{
+			name:    "after the last run",
+			maxRuns: 3,
+			runs:    3,
+			expected: Values{
+				WorkDone:            true,
+				ScheduleRetrieved:   true,
+				ScheduleDeactivated: true,
+			},
+		},
+		{
+			name:           "failure to deactivate",
+			maxRuns:        3,
+			runs:           3,
+			deactivatorErr: errors.New("failed to deactivate"),
+			expected: Values{
+				WorkDone:            true,
+				ScheduleRetrieved:   true,
+				ScheduleDeactivated: true,
+				ErrorOccurred:       true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := Values{}
+
+			scheduleGetter := func(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+				actual.ScheduleRetrieved = true
+				sc = data.ScheduleCrontab{
+					Schedule: data.Schedule{
+						ScheduleID: 1,
+						Active:     true,
+						ARN:        "testarn",
+						Until:      test.until,
+						MaxRuns:    test.maxRuns,
+						Runs:       test.runs,
+					},
+					Crontab: data.Crontab{
+						Crontab:    "0 * * * *", // once per hour
+						CrontabID:  1,
+						Next:       now,
+						ScheduleID: 1,
+					},
+					CrontabLeaseID: 1,
+				}
+				ok = true
+				return
+			}
+
+			scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+				actual.ScheduleDeactivated = true
+				return test.deactivatorErr == nil, test.deactivatorErr
+			}
+
+			scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
+				actual.JobStarted = true
+				actual.NextTime = newNext
+				return 1, nil
+			}
+
+			crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+				actual.CrontabSkipped = true
+				actual.NextTime = newNext
+				return nil
+			}
+
+			w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
+
+			var err error
+			actual.WorkDone, err = w()
+			actual.ErrorOccurred = err != nil
+
+			test.expected.Assert(t, actual)
+		})
+	}
+}
+
+func TestMisfirePolicies(t *testing.T) {
+	now := time.Now().UTC()
+	thisHour := now.Truncate(time.Hour)
+	nextHour := thisHour.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		policy   string
+		next     time.Time
+		expected Values
+	}{
+		{
+			name:   "fire all starts each missed run in turn",
+			policy: data.MisfirePolicyFireAll,
+			next:   thisHour.Add(-3 * time.Hour),
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          thisHour.Add(-2 * time.Hour),
+			},
+		},
+		{
+			name:   "the default is to fire all",
+			policy: "",
+			next:   thisHour.Add(-3 * time.Hour),
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          thisHour.Add(-2 * time.Hour),
+			},
+		},
+		{
+			name:   "fire once starts one job and skips the other missed runs",
+			policy: data.MisfirePolicyFireOnce,
+			next:   thisHour.Add(-3 * time.Hour),
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          nextHour,
+			},
+		},
+		{
+			name:   "skip doesn't start a job for missed runs",
+			policy: data.MisfirePolicySkip,
+			next:   thisHour.Add(-3 * time.Hour),
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				CrontabSkipped:    true,
+				NextTime:          nextHour,
+			},
+		},
+		{
+			name:   "skip starts a job for a run which is within the threshold",
+			policy: data.MisfirePolicySkip,
+			next:   now.Add(-time.Second),
+			expected: Values{
+				WorkDone:          true,
+				ScheduleRetrieved: true,
+				JobStarted:        true,
+				NextTime:          now.Add(-time.Second).Truncate(time.Hour).Add(time.Hour),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := Values{}
+
+			scheduleGetter := func(lockedBy string, lockExpiryMinutes int) (sc data.ScheduleCrontab, ok bool, err error) {
+				actual.ScheduleRetrieved = true
+				sc = data.ScheduleCrontab{
+					Schedule: data.Schedule{
+						ScheduleID:    1,
+						Active:        true,
+						ARN:           "testarn",
+						MisfirePolicy: test.policy,
+					},
+					Crontab: data.Crontab{
+						Crontab:    "0 * * * *", // once per hour
+						CrontabID:  1,
+						Next:       test.next,
+						ScheduleID: 1,
+					},
+					CrontabLeaseID: 1,
+				}
+				ok = true
+				return
+			}
+
+			scheduleDeactivator := func(scheduleID int64) (ok bool, err error) {
+				actual.ScheduleDeactivated = true
+				return true, nil
+			}
+
+			scheduledJobStarter := func(crontabID, scheduleID, crontabLeaseID int64, newNext time.Time) (jobID int64, err error) {
+				actual.JobStarted = true
+				actual.NextTime = newNext
+				return 1, nil
+			}
+
+			crontabSkipper := func(crontabID, crontabLeaseID int64, newNext time.Time) (err error) {
+				actual.CrontabSkipped = true
+				actual.NextTime = newNext
+				return nil
+			}
+
+			w := NewScheduleWorker(nodeName, lockExpiryMins, DefaultMisfireThreshold, scheduleGetter, scheduledJobStarter, crontabSkipper, scheduleDeactivator)
+
+			var err error
+			actual.WorkDone, err = w()
+			actual.ErrorOccurred = err != nil
+
+			test.expected.Assert(t, actual)
+		})
+	}
+}
+
 type Values struct {
-	WorkDone          bool
-	ScheduleRetrieved bool
-	JobStarted        bool
-	NextTime          time.Time
-	ErrorOccurred     bool
+	WorkDone            bool
+	ScheduleRetrieved   bool
+	JobStarted          bool
+	NextTime            time.Time
+	CrontabSkipped      bool
+	ScheduleDeactivated bool
+	ErrorOccurred       bool
 }
 
 func (expected Values) Assert(t *testing.T, actual Values) {
@@ -263,4 +654,10 @@ func (expected Values) Assert(t *testing.T, actual Values) {
 	if !expected.NextTime.Equal(actual.NextTime) {
 		t.Errorf("expected next time of crontab to be %v, but was %v", expected.NextTime, actual.NextTime)
 	}
+	if expected.CrontabSkipped != actual.CrontabSkipped {
+		t.Errorf("expected crontab skipped=%v, but got %v", expected.CrontabSkipped, actual.CrontabSkipped)
+	}
+	if expected.ScheduleDeactivated != actual.ScheduleDeactivated {
+		t.Errorf("expected schedule deactivated=%v, but got %v", expected.ScheduleDeactivated, actual.ScheduleDeactivated)
+	}
 }
@@ -0,0 +1,51 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultKey is the name of the key used when neither the job nor the destination host has a key.
+const DefaultKey = "default"
+
+// A Keyring maps key names to their active secrets, newest first. Keys can be named after
+// destination hosts (e.g. "example.com") to be used for every delivery to that host.
+type Keyring map[string][]string
+
+// ParseKeyring parses a JSON object of key names to secrets, e.g. {"default":["new","old"]}.
+func ParseKeyring(s string) (Keyring, error) {
+	k := Keyring{}
+	if s == "" {
+		return k, nil
+	}
+	if err := json.Unmarshal([]byte(s), &k); err != nil {
+		return nil, fmt.Errorf("signature: failed to parse keyring: %v", err)
+	}
+	for name, secrets := range k {
+		if len(secrets) == 0 || len(secrets) > MaxSecrets {
+			return nil, fmt.Errorf("signature: key '%v' must have between 1 and %v secrets", name, MaxSecrets)
+		}
+		for _, secret := range secrets {
+			if secret == "" {
+				return nil, fmt.Errorf("signature: key '%v' has an empty secret", name)
+			}
+		}
+	}
+	return k, nil
+}
+
+// Secrets finds the secrets to sign a delivery with. If a key name is provided, it must exist.
+// Otherwise, the key for the destination host is used, falling back to the default key.
+func (k Keyring) Secrets(name, host string) (secrets []string, ok bool, err error) {
+	if name != "" {
+		if secrets, ok = k[name]; !ok {
+			err = fmt.Errorf("signature: key '%v' not found", name)
+		}
+		return
+	}
+	if secrets, ok = k[host]; ok {
+		return
+	}
+	secrets, ok = k[DefaultKey]
+	return
+}
@@ -0,0 +1,104 @@
+// Package signature signs and verifies the webhook deliveries made by callme.
+//
+// Each delivery carries a timestamp header and a signature header. The signature is a
+// HMAC-SHA256 of the timestamp and the body, made with every active secret of the signing
+// key, so that receivers can continue to verify requests while secrets are being rotated.
+//
+// Receivers can verify deliveries with the Verify function:
+//
+//	body, err := signature.Verify(r, []string{os.Getenv("CALLME_SECRET")}, 5*time.Minute)
+package signature
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampHeader is the header which contains the Unix time that the request was signed.
+const TimestampHeader = "X-Callme-Timestamp"
+
+// SignatureHeader is the header which contains the comma separated signatures of the request.
+const SignatureHeader = "X-Callme-Signature"
+
+// MaxSecrets is the maximum number of active secrets for a key, i.e. the current and previous secret.
+const MaxSecrets = 2
+
+const prefix = "sha256="
+
+// ErrMissingHeaders is returned when the timestamp or signature headers are missing.
+var ErrMissingHeaders = errors.New("signature: missing timestamp or signature header")
+
+// ErrTimestampOutOfRange is returned when the timestamp is outside of the allowed tolerance.
+var ErrTimestampOutOfRange = errors.New("signature: timestamp out of range")
+
+// ErrInvalidSignature is returned when none of the signatures match.
+var ErrInvalidSignature = errors.New("signature: invalid signature")
+
+// Compute returns the hex encoded HMAC-SHA256 of the timestamp and body.
+func Compute(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign adds the timestamp and signature headers to the request, signing the body with each secret.
+func Sign(r *http.Request, body []byte, secrets []string, now time.Time) {
+	timestamp := now.Unix()
+	signatures := make([]string, len(secrets))
+	for i, secret := range secrets {
+		signatures[i] = prefix + Compute(secret, timestamp, body)
+	}
+	r.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	r.Header.Set(SignatureHeader, strings.Join(signatures, ","))
+}
+
+// Verify reads the request body and checks that it was signed by any of the secrets within the
+// tolerance of the current time. The body is returned, and replaced on the request so that it
+// can be read again.
+func Verify(r *http.Request, secrets []string, tolerance time.Duration) (body []byte, err error) {
+	body, err = ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	err = VerifyBody(r.Header, body, secrets, tolerance, time.Now())
+	return
+}
+
+// VerifyBody checks that the headers contain a valid signature of the body by any of the secrets,
+// and that the timestamp is within the tolerance of now.
+func VerifyBody(h http.Header, body []byte, secrets []string, tolerance time.Duration, now time.Time) error {
+	ts, sigs := h.Get(TimestampHeader), h.Get(SignatureHeader)
+	if ts == "" || sigs == "" {
+		return ErrMissingHeaders
+	}
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("signature: invalid timestamp: %v", err)
+	}
+	delta := now.Sub(time.Unix(timestamp, 0))
+	if delta > tolerance || delta < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+	for _, secret := range secrets {
+		expected := []byte(prefix + Compute(secret, timestamp, body))
+		for _, sig := range strings.Split(sigs, ",") {
+			if hmac.Equal(expected, []byte(strings.TrimSpace(sig))) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,164 @@
+package signature
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThatSignedRequestsCanBeVerified(t *testing.T) {
+	now := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		signWith        []string
+		verifyWith      []string
+		verifyAt        time.Time
+		tamper          bool
+		expectedErr     error
+		expectedHeaders int
+	}{
+		{
+			name:       "single secret",
+			signWith:   []string{"secret"},
+			verifyWith: []string{"secret"},
+			verifyAt:   now,
+		},
+		{
+			name:       "rotation: receiver has the new secret",
+			signWith:   []string{"new", "old"},
+			verifyWith: []string{"new"},
+			verifyAt:   now,
+		},
+		{
+			name:       "rotation: receiver still has the old secret",
+			signWith:   []string{"new", "old"},
+			verifyWith: []string{"old"},
+			verifyAt:   now,
+		},
+		{
+			name:        "wrong secret",
+			signWith:    []string{"secret"},
+			verifyWith:  []string{"other"},
+			verifyAt:    now,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "tampered body",
+			signWith:    []string{"secret"},
+			verifyWith:  []string{"secret"},
+			verifyAt:    now,
+			tamper:      true,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "too old",
+			signWith:    []string{"secret"},
+			verifyWith:  []string{"secret"},
+			verifyAt:    now.Add(time.Minute * 6),
+			expectedErr: ErrTimestampOutOfRange,
+		},
+		{
+			name:        "unsigned",
+			signWith:    nil,
+			verifyWith:  []string{"secret"},
+			verifyAt:    now,
+			expectedErr: ErrMissingHeaders,
+		},
+	}
+
+	for _, test := range tests {
+		body := []byte(`{"test":true}`)
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		if test.signWith != nil {
+			Sign(r, body, test.signWith, now)
+		}
+		if test.tamper {
+			body = []byte(`{"test":false}`)
+		}
+		err := VerifyBody(r.Header, body, test.verifyWith, time.Minute*5, test.verifyAt)
+		if err != test.expectedErr {
+			t.Errorf("%s: expected error '%v', got '%v'", test.name, test.expectedErr, err)
+		}
+	}
+}
+
+func TestThatVerifyRestoresTheBody(t *testing.T) {
+	body := `{"test":true}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	Sign(r, []byte(body), []string{"secret"}, time.Now())
+
+	actual, err := Verify(r, []string{"secret"}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(actual) != body {
+		t.Errorf("expected body '%v', got '%v'", body, string(actual))
+	}
+	again, _ := ioutil.ReadAll(r.Body)
+	if string(again) != body {
+		t.Errorf("expected body to be readable again, got '%v'", string(again))
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		expectedErr bool
+	}{
+		{name: "empty", s: ""},
+		{name: "valid", s: `{"default":["a"],"example.com":["new","old"]}`},
+		{name: "invalid JSON", s: `nonsense`, expectedErr: true},
+		{name: "no secrets", s: `{"default":[]}`, expectedErr: true},
+		{name: "too many secrets", s: `{"default":["a","b","c"]}`, expectedErr: true},
+		{name: "empty secret", s: `{"default":[""]}`, expectedErr: true},
+	}
+
+	for _, test := range tests {
+		_, err := ParseKeyring(test.s)
+		if test.expectedErr != (err != nil) {
+			t.Errorf("%s: expected error=%v, got '%v'", test.name, test.expectedErr, err)
+		}
+	}
+}
+
+func TestKeyringSecrets(t *testing.T) {
+	k := Keyring{
+		"default":     []string{"default_secret"},
+		"example.com": []string{"host_secret"},
+		"named":       []string{"named_secret"},
+	}
+	tests := []struct {
+		name            string
+		key             string
+		host            string
+		expectedSecrets string
+		expectedOK      bool
+		expectedErr     bool
+	}{
+		{name: "named key", key: "named", host: "example.com", expectedSecrets: "named_secret", expectedOK: true},
+		{name: "missing named key", key: "missing", host: "example.com", expectedErr: true},
+		{name: "host key", host: "example.com", expectedSecrets: "host_secret", expectedOK: true},
+		{name: "default key", host: "other.com", expectedSecrets: "default_secret", expectedOK: true},
+	}
+
+	for _, test := range tests {
+		secrets, ok, err := k.Secrets(test.key, test.host)
+		if test.expectedErr != (err != nil) {
+			t.Errorf("%s: expected error=%v, got '%v'", test.name, test.expectedErr, err)
+		}
+		if ok != test.expectedOK {
+			t.Errorf("%s: expected ok=%v, got %v", test.name, test.expectedOK, ok)
+		}
+		if strings.Join(secrets, ",") != test.expectedSecrets {
+			t.Errorf("%s: expected secrets '%v', got '%v'", test.name, test.expectedSecrets, secrets)
+		}
+	}
+
+	if _, ok, _ := (Keyring{}).Secrets("", "example.com"); ok {
+		t.Errorf("empty keyring: expected no secrets to be found")
+	}
+}
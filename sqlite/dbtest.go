@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+// CreateTestDatabase creates a test database file for use with integration tests.
+func CreateTestDatabase() (connectionString, fileName string, err error) {
+	f, err := ioutil.TempFile("", "callme_test_")
+	if err != nil {
+		return
+	}
+	fileName = f.Name()
+	if err = f.Close(); err != nil {
+		return
+	}
+
+	connectionString = DSN(fileName)
+
+	// Fill it with schema.
+	testDB, err := Open(connectionString)
+	if err != nil {
+		return
+	}
+	defer testDB.Close()
+	err = NewMigrationManager(testDB).UpdateSchema()
+
+	return
+}
+
+// DropTestDatabase deletes a database file, along with its write-ahead log - for use with integration tests.
+func DropTestDatabase(fileName string) (err error) {
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err = os.Remove(fileName + suffix); err != nil && !os.IsNotExist(err) {
+			return
+		}
+	}
+	return os.Remove(fileName)
+}
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// dedupe starts a job and holds its dedupe key in the same transaction, unless a pending job was started with the key
+// and the key's window hasn't ended, in which case the ID of that job is returned.
+func dedupe(db *sql.DB, dedupeKey string, dedupeWindow time.Duration, start func(tx *sql.Tx) (jobID int64, err error)) (jobID int64, duplicate bool, err error) {
+	if jobID, duplicate, err = startWithDedupeKey(db, dedupeKey, dedupeWindow, start); err == nil {
+		return
+	}
+	// A concurrent request with the same key may have committed first, in which case the unique index rejected this
+	// one, and the job started by the other request is returned instead.
+	if otherID, found, findErr := findDedupeKey(db, dedupeKey); findErr != nil || found {
+		return otherID, found, findErr
+	}
+	return 0, false, err
+}
+
+func startWithDedupeKey(db *sql.DB, dedupeKey string, dedupeWindow time.Duration, start func(tx *sql.Tx) (jobID int64, err error)) (jobID int64, duplicate bool, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if jobID, duplicate, err = startJobWithDedupeKey(tx, dedupeKey, dedupeWindow, start); err != nil || duplicate {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// startJobWithDedupeKey starts a job and holds its dedupe key within the transaction, unless the key is held by
+// another job.
+func startJobWithDedupeKey(tx *sql.Tx, dedupeKey string, dedupeWindow time.Duration, start func(tx *sql.Tx) (jobID int64, err error)) (jobID int64, duplicate bool, err error) {
+	if jobID, duplicate, err = findDedupeKey(tx, dedupeKey); err != nil || duplicate {
+		return
+	}
+	// The key's window has ended, or its job has been completed or deleted, so the key can be taken.
+	if _, err = tx.Exec(`DELETE FROM jobdedupe WHERE dedupekey = ?`, dedupeKey); err != nil {
+		return
+	}
+	if jobID, err = start(tx); err != nil {
+		return
+	}
+	_, err = tx.Exec(`INSERT INTO jobdedupe (dedupekey, idjob, expires) VALUES (?, ?, ?)`,
+		dedupeKey, jobID, now().Add(dedupeWindow))
+	return
+}
+
+// findDedupeKey returns the ID of the job which holds the dedupe key, if the key's window hasn't ended and the job is
+// still pending.
+func findDedupeKey(q rowQueryer, dedupeKey string) (jobID int64, found bool, err error) {
+	err = q.QueryRow(`SELECT d.idjob FROM jobdedupe d `+
+		`WHERE d.dedupekey = ? AND d.expires > ? AND EXISTS (SELECT 1 FROM job j WHERE j.idjob = d.idjob) `+
+		`AND NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = d.idjob)`, dedupeKey, now()).
+		Scan(&jobID)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return jobID, err == nil, err
+}
+
+// anyDedupeKeyHeld returns true if any of the jobs' dedupe keys is held by a pending job.
+func anyDedupeKeyHeld(q rowQueryer, jobs []data.Job) (held bool, err error) {
+	for _, j := range jobs {
+		if j.DedupeKey == "" {
+			continue
+		}
+		if _, held, err = findDedupeKey(q, j.DedupeKey); err != nil || held {
+			return
+		}
+	}
+	return
+}
+
+// releaseDedupeKey releases the dedupe key held by a job which has been completed, so that the key can start a new job.
+func releaseDedupeKey(tx *sql.Tx, jobID int64) error {
+	_, err := tx.Exec(`DELETE FROM jobdedupe WHERE idjob = ?`, jobID)
+	return err
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"strings"
+	"time"
+)
+
+// DSN returns the data source name for the database file at path, with the options which callme relies on.
+// Transactions take the write lock when they begin, so that two workers can't read the same job or crontab before
+// either has leased it, and workers wait for each other rather than failing when the database is busy.
+func DSN(path string) string {
+	options := "_txlock=immediate&_busy_timeout=10000&_foreign_keys=1&_journal_mode=WAL&_loc=UTC"
+	if strings.Contains(path, "?") {
+		return "file:" + path + "&" + options
+	}
+	return "file:" + path + "?" + options
+}
+
+// now returns the current time. SQLite has no time type, so times are stored as text by the driver, and
+// they're always UTC so that the text sorts in time order.
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// nullTime converts a zero time to NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// timeOrZero converts a NULL time to the zero time.
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/welldigital/callme/data"
+)
+
+// marshalHTTPRequest converts a HTTPRequest into JSON for storage, a nil HTTPRequest is stored as NULL.
+func marshalHTTPRequest(r *data.HTTPRequest) (s sql.NullString, err error) {
+	if r == nil {
+		return
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	s.String = string(b)
+	s.Valid = true
+	return
+}
+
+// unmarshalHTTPRequest converts stored JSON back into a HTTPRequest, NULL values return nil.
+func unmarshalHTTPRequest(s sql.NullString) (r *data.HTTPRequest, err error) {
+	if !s.Valid || s.String == "" {
+		return
+	}
+	r = &data.HTTPRequest{}
+	err = json.Unmarshal([]byte(s.String), r)
+	return
+}
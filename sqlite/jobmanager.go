@@ -0,0 +1,1012 @@
+package sqlite
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// JobManager provides features to manage jobs using SQLite.
+type JobManager struct {
+	DB *sql.DB
+}
+
+// NewJobManager creates a new JobManager.
+func NewJobManager(db *sql.DB) JobManager {
+	return JobManager{
+		DB: db,
+	}
+}
+
+// StartJob schedules a job to start in the future, unless a job was started with the dedupe key within its dedupe
+// window, in which case that job is returned.
+func (m JobManager) StartJob(when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64, dedupeKey string, dedupeWindow time.Duration) (j data.Job, duplicate bool, err error) {
+	if dedupeKey == "" {
+		j, err = startJob(m.DB, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return
+	}
+	jobID, duplicate, err := dedupe(m.DB, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, scheduleID)
+		return j.JobID, err
+	})
+	if err != nil || !duplicate {
+		j.DedupeKey = dedupeKey
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	return j, true, err
+}
+
+// StartJobIdempotently starts a job, unless a job was already started with the idempotency key, in which case that
+// job is returned.
+func (m JobManager) StartJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	j, existing, duplicate, err = m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+	if err == nil || dedupeKey == "" {
+		return
+	}
+	// A concurrent request with the same dedupe key may have committed first, in which case the unique index rejected
+	// this one. Starting the job again finds the other request's job, and records the key against it.
+	if _, held, findErr := findDedupeKey(m.DB, dedupeKey); findErr != nil || !held {
+		return
+	}
+	return m.startJobIdempotently(key, when, arn, payload, httpRequest, retryPolicy, dedupeKey, dedupeWindow)
+}
+
+func (m JobManager) startJobIdempotently(key data.IdempotencyKey, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, dedupeKey string, dedupeWindow time.Duration) (j data.Job, existing, duplicate bool, err error) {
+	jobID, existing, err := idempotently(m.DB, idempotencyResourceJob, key, func(tx *sql.Tx) (int64, error) {
+		if dedupeKey == "" {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		}
+		var jobID int64
+		jobID, duplicate, err = startJobWithDedupeKey(tx, dedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+			j, err = startJob(tx, when, arn, payload, httpRequest, retryPolicy, nil)
+			return j.JobID, err
+		})
+		j.DedupeKey = dedupeKey
+		return jobID, err
+	})
+	if err != nil || (!existing && !duplicate) {
+		return
+	}
+	j, err = m.getStartedJob(jobID)
+	// If the job was started by a concurrent request with the same idempotency key, it's not a duplicate.
+	return j, existing, duplicate && !existing, err
+}
+
+// StartJobs starts a batch of jobs in a single transaction, so either all of the jobs are started, or none are. Jobs
+// without a dedupe key are inserted with a single statement, jobs with a dedupe key are started one at a time, so that
+// each key is checked against the jobs started before it.
+func (m JobManager) StartJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	if started, err = m.startJobs(jobs, dedupeWindow); err == nil {
+		return
+	}
+	// A concurrent request may have taken one of the batch's dedupe keys first, in which case the unique index
+	// rejected this batch. Starting the batch again finds the other request's job, and returns it as a duplicate.
+	if held, findErr := anyDedupeKeyHeld(m.DB, jobs); findErr != nil || !held {
+		return nil, err
+	}
+	return m.startJobs(jobs, dedupeWindow)
+}
+
+func (m JobManager) startJobs(jobs []data.Job, dedupeWindow time.Duration) (started []data.StartedJob, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	started = make([]data.StartedJob, len(jobs))
+	var undeduped []data.Job
+	var undedupedIndexes []int
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			undeduped = append(undeduped, j)
+			undedupedIndexes = append(undedupedIndexes, i)
+		}
+	}
+	inserted, err := insertJobs(tx, undeduped)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := range inserted {
+		started[undedupedIndexes[i]].Job = j
+	}
+	for i, j := range jobs {
+		if j.DedupeKey == "" {
+			continue
+		}
+		if started[i], err = startDedupedJob(tx, j, dedupeWindow); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	// Duplicates are returned as they are now, which may be a job started earlier in the batch.
+	for i := range started {
+		if !started[i].Duplicate {
+			continue
+		}
+		if started[i].Job, err = m.getStartedJob(started[i].Job.JobID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func startDedupedJob(tx *sql.Tx, j data.Job, dedupeWindow time.Duration) (s data.StartedJob, err error) {
+	jobID, duplicate, err := startJobWithDedupeKey(tx, j.DedupeKey, dedupeWindow, func(tx *sql.Tx) (int64, error) {
+		s.Job, err = startJob(tx, j.When, j.ARN, j.Payload, j.HTTPRequest, j.RetryPolicy, j.ScheduleID)
+		return s.Job.JobID, err
+	})
+	if duplicate {
+		return data.StartedJob{Job: data.Job{JobID: jobID}, Duplicate: true}, err
+	}
+	s.Job.DedupeKey = j.DedupeKey
+	return s, err
+}
+
+// jobsPerInsert keeps each INSERT within SQLite's default limit of 999 variables.
+const jobsPerInsert = 999 / 6
+
+// insertJobs inserts the jobs with as few statements as SQLite's variable limit allows, and returns them with their
+// IDs. The transaction holds the write lock, so the rows of each INSERT are given consecutive IDs, the last of which
+// is last_insert_rowid().
+func insertJobs(tx *sql.Tx, jobs []data.Job) (inserted []data.Job, err error) {
+	inserted = make([]data.Job, len(jobs))
+	for start := 0; start < len(jobs); start += jobsPerInsert {
+		end := start + jobsPerInsert
+		if end > len(jobs) {
+			end = len(jobs)
+		}
+		var valuesSQL string
+		var valuesArgs []interface{}
+		for i := start; i < end; i++ {
+			j := jobs[i]
+			inserted[i] = data.Job{
+				ARN:         j.ARN,
+				Payload:     j.Payload,
+				HTTPRequest: j.HTTPRequest,
+				RetryPolicy: j.RetryPolicy,
+				ScheduleID:  j.ScheduleID,
+				When:        j.When,
+			}
+			httpRequestJSON, err := marshalHTTPRequest(j.HTTPRequest)
+			if err != nil {
+				return nil, err
+			}
+			retryPolicyJSON, err := marshalRetryPolicy(j.RetryPolicy)
+			if err != nil {
+				return nil, err
+			}
+			if i == start {
+				valuesSQL = `(?, ?, ?, ?, ?, ?)`
+			} else {
+				valuesSQL += `, (?, ?, ?, ?, ?, ?)`
+			}
+			valuesArgs = append(valuesArgs, j.ARN, j.Payload, httpRequestJSON, retryPolicyJSON, j.ScheduleID, j.When.UTC())
+		}
+		result, err := tx.Exec(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") VALUES `+valuesSQL, valuesArgs...)
+		if err != nil {
+			return nil, err
+		}
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		for i := start; i < end; i++ {
+			inserted[i].JobID = lastID - int64(end-1-i)
+		}
+	}
+	return
+}
+
+// getStartedJob gets a job which was started by an earlier request.
+func (m JobManager) getStartedJob(jobID int64) (j data.Job, err error) {
+	j, _, ok, _, err := m.GetJobResponse(jobID)
+	if !ok {
+		// The job has been deleted since it was started.
+		j = data.Job{JobID: jobID}
+	}
+	return j, err
+}
+
+// startJob inserts a job using either the *sql.DB or a *sql.Tx.
+func startJob(e execer, when time.Time, arn string, payload string, httpRequest *data.HTTPRequest, retryPolicy *data.RetryPolicy, scheduleID *int64) (data.Job, error) {
+	j := data.Job{
+		ARN:         arn,
+		Payload:     payload,
+		HTTPRequest: httpRequest,
+		RetryPolicy: retryPolicy,
+		ScheduleID:  scheduleID,
+		When:        when,
+	}
+
+	httpRequestJSON, err := marshalHTTPRequest(j.HTTPRequest)
+	if err != nil {
+		return j, err
+	}
+	retryPolicyJSON, err := marshalRetryPolicy(j.RetryPolicy)
+	if err != nil {
+		return j, err
+	}
+
+	res, err := e.Exec(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") VALUES (?, ?, ?, ?, ?, ?)`,
+		j.ARN, j.Payload, httpRequestJSON, retryPolicyJSON, j.ScheduleID, j.When.UTC())
+	if err != nil {
+		return j, err
+	}
+	j.JobID, err = res.LastInsertId()
+	return j, err
+}
+
+// GetAvailableJobCount returns the number of jobs present in the DB to process, i.e. where they have no job response and
+// they're ready to process.
+func (m JobManager) GetAvailableJobCount() (count int, err error) {
+	err = m.DB.QueryRow(`SELECT COUNT(*) FROM job j `+
+		`WHERE j."when" <= ? AND NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob)`, now()).Scan(&count)
+	return
+}
+
+// GetJob leases a job that's ready to run from the queue. The transaction holds the database's write lock from
+// the start, so that only one worker at a time can select a job and lease it.
+func (m JobManager) GetJob(lockedBy string, lockExpiryMinutes int) (lj data.LeasedJob, ok bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	j := &lj.Job
+	// A job can have several leases once it has been retried, so only jobs without a current lease are available.
+	at := now()
+	var httpRequestJSON, retryPolicyJSON sql.NullString
+	err = tx.QueryRow(`SELECT j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM job j `+
+		`WHERE `+
+		`j."when" <= ?1 AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= ?1) `+
+		`ORDER BY j."when" ASC `+
+		`LIMIT 1`, at).
+		Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+	if err != nil {
+		return
+	}
+	j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+	if err != nil {
+		return
+	}
+
+	res, err := tx.Exec(`INSERT INTO joblease (idjob, lockedby, "at", "until") VALUES (?, ?, ?, ?)`,
+		j.JobID, lockedBy, at, at.Add(time.Duration(lockExpiryMinutes)*time.Minute))
+	if err != nil {
+		return
+	}
+	if lj.JobLeaseID, err = res.LastInsertId(); err != nil {
+		return
+	}
+	err = tx.Commit()
+	ok = err == nil
+	return
+}
+
+// GetJobs leases up to limit jobs that are ready to run from the queue, in the order they're due. Like GetJob, the
+// transaction holds the write lock from the start, so concurrent workers lease different jobs.
+func (m JobManager) GetJobs(lockedBy string, lockExpiryMinutes int, limit int) (jobs []data.LeasedJob, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	at := now()
+	rows, err := tx.Query(`SELECT j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM job j `+
+		`WHERE `+
+		`j."when" <= ?1 AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= ?1) `+
+		`ORDER BY j."when" ASC `+
+		`LIMIT ?2`, at, limit)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var j data.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, data.LeasedJob{Job: j})
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+
+	until := at.Add(time.Duration(lockExpiryMinutes) * time.Minute)
+	for i := range jobs {
+		res, err := tx.Exec(`INSERT INTO joblease (idjob, lockedby, "at", "until") VALUES (?, ?, ?, ?)`, jobs[i].Job.JobID, lockedBy, at, until)
+		if err != nil {
+			return nil, err
+		}
+		if jobs[i].JobLeaseID, err = res.LastInsertId(); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetJobResponse retrieves a completed job's data.
+func (m JobManager) GetJobResponse(jobID int64) (j data.Job, r data.JobResponse, jobOK, responseOK bool, err error) {
+	var jrID, jrJobID sql.NullInt64
+	var jrTime *time.Time
+	var jrResp, jrError sql.NullString
+	var jrIsError sql.NullBool
+	var httpRequestJSON, retryPolicyJSON, dedupeKey sql.NullString
+	err = m.DB.QueryRow(`SELECT `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount, `+
+		`(SELECT d.dedupekey FROM jobdedupe d WHERE d.idjob = j.idjob) AS dedupekey, `+
+		`jr.idjobresponse, jr.idjob, jr."time", jr.response, jr.iserror, jr.error `+
+		`FROM job j `+
+		`LEFT JOIN jobresponse jr ON jr.idjob = j.idjob `+
+		`WHERE j.idjob = ?`, jobID).
+		Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &dedupeKey,
+			&jrID, &jrJobID, &jrTime, &jrResp, &jrIsError, &jrError)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	jobOK = true
+	j.DedupeKey = dedupeKey.String
+	j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+	if err != nil {
+		return
+	}
+	j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+
+	if jrID.Int64 > 0 {
+		r.JobResponseID = jrID.Int64
+		r.JobID = jrJobID.Int64
+		r.Time = timeOrZero(jrTime)
+		r.Response = jrResp.String
+		r.IsError = jrIsError.Bool
+		r.Error = jrError.String
+	}
+	return j, r, jobOK, r.JobResponseID > 0, err
+}
+
+// insertAttempt records an attempt at a job, numbering it after the job's previous attempts.
+func insertAttempt(tx *sql.Tx, jobID int64, resp string, isError bool, errorString string, retryAt *time.Time) error {
+	_, err := tx.Exec(`INSERT INTO jobattempt (idjob, attempt, "time", response, iserror, error, retryat) `+
+		`SELECT ?1, COUNT(*) + 1, ?2, ?3, ?4, ?5, ?6 FROM jobattempt ja WHERE ja.idjob = ?1`,
+		jobID, now(), resp, isError, errorString, retryAt)
+	return err
+}
+
+// currentLease returns whether the lease is current. A lease is current if it's the job's latest lease and the job
+// hasn't been completed. The transaction holds the write lock, so the lease can't change until it ends.
+func currentLease(tx *sql.Tx, jobID, jobLeaseID int64) (current bool, err error) {
+	err = tx.QueryRow(`SELECT `+
+		`COALESCE((SELECT MAX(jl.idjoblease) FROM joblease jl WHERE jl.idjob = j.idjob) = ?2, 0) AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = j.idjob) `+
+		`FROM job j WHERE j.idjob = ?1`, jobID, jobLeaseID).Scan(&current)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return
+}
+
+// insertDuplicate records an execution which was completed using a lease that wasn't current.
+func insertDuplicate(tx *sql.Tx, jobID, jobLeaseID int64, resp string, isError bool, errorString string) error {
+	_, err := tx.Exec(`INSERT INTO jobduplicate (idjob, idjoblease, "time", response, iserror, error) VALUES (?, ?, ?, ?, ?, ?)`,
+		jobID, jobLeaseID, now(), resp, isError, errorString)
+	return err
+}
+
+// CompleteJob marks a job as complete if the lease is current, otherwise the response is recorded as a duplicate.
+func (m JobManager) CompleteJob(jobID, jobLeaseID int64, resp string, jobError error) (duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if duplicate, err = completeJob(tx, jobID, jobLeaseID, resp, jobError); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// completeJob completes a job, or records a duplicate, within the transaction.
+func completeJob(tx *sql.Tx, jobID, jobLeaseID int64, resp string, jobError error) (duplicate bool, err error) {
+	current, err := currentLease(tx, jobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		return true, insertDuplicate(tx, jobID, jobLeaseID, resp, jobError != nil, errorString(jobError))
+	}
+	if err = insertAttempt(tx, jobID, resp, jobError != nil, errorString(jobError), nil); err != nil {
+		return
+	}
+	_, err = tx.Exec(`INSERT INTO jobresponse (idjob, "time", response, iserror, error) VALUES (?, ?, ?, ?, ?)`,
+		jobID, now(), resp, jobError != nil, errorString(jobError))
+	if err != nil {
+		return
+	}
+	err = releaseDedupeKey(tx, jobID)
+	return
+}
+
+// CompleteJobs marks a batch of jobs as complete in a single transaction. SQLite runs in the process, so the
+// statements don't need to be combined to save round trips, it's the commit which is expensive. Completions whose
+// leases aren't current are recorded as duplicates.
+func (m JobManager) CompleteJobs(completions []data.JobCompletion) (duplicateJobIDs []int64, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	for _, c := range completions {
+		var duplicate bool
+		if duplicate, err = completeJob(tx, c.JobID, c.JobLeaseID, c.Response, c.Err); err != nil {
+			return nil, err
+		}
+		if duplicate {
+			duplicateJobIDs = append(duplicateJobIDs, c.JobID)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RenewJobLease extends a lease on a job, so that it expires lockExpiryMinutes from now. It returns false if the
+// lease has expired, been released, or been taken over by another worker.
+func (m JobManager) RenewJobLease(jobID, jobLeaseID int64, lockExpiryMinutes int) (ok bool, err error) {
+	at := now()
+	result, err := m.DB.Exec(`UPDATE joblease SET "until" = ?1 `+
+		`WHERE idjoblease = ?3 AND idjob = ?2 AND `+
+		`idjoblease = (SELECT MAX(idjoblease) FROM joblease WHERE idjob = ?2) AND `+
+		`"until" >= ?4`, at.Add(time.Duration(lockExpiryMinutes)*time.Minute), jobID, jobLeaseID, at)
+	if err != nil {
+		return
+	}
+	renewed, err := result.RowsAffected()
+	ok = renewed > 0
+	return
+}
+
+// RetryJob records a failed attempt at a job and reschedules it to run again at the retryAt time, releasing
+// the lease so that any worker can pick it up. If the lease isn't current, the attempt is recorded as a duplicate.
+func (m JobManager) RetryJob(jobID, jobLeaseID int64, resp string, jobError error, retryAt time.Time) (duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := currentLease(tx, jobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		if err = insertDuplicate(tx, jobID, jobLeaseID, resp, true, errorString(jobError)); err != nil {
+			return
+		}
+		return true, tx.Commit()
+	}
+	retryAt = retryAt.UTC()
+	if err = insertAttempt(tx, jobID, resp, true, errorString(jobError), &retryAt); err != nil {
+		return
+	}
+	if _, err = tx.Exec(`UPDATE job SET "when" = ? WHERE idjob = ?`, retryAt, jobID); err != nil {
+		return
+	}
+	// Release the lease so that any worker can pick up the job when it's due.
+	at := now()
+	_, err = tx.Exec(`UPDATE joblease SET "until" = ? WHERE idjoblease = ? AND "until" > ?`, at.Add(-time.Second), jobLeaseID, at)
+	if err != nil {
+		return
+	}
+	return false, tx.Commit()
+}
+
+// GetJobDuplicates gets the duplicate executions of a job, in order.
+func (m JobManager) GetJobDuplicates(jobID int64) (duplicates []data.JobDuplicate, err error) {
+	rows, err := m.DB.Query(`SELECT jd.idjobduplicate, jd.idjob, jd.idjoblease, jd."time", jd.response, jd.iserror, jd.error `+
+		`FROM jobduplicate jd WHERE jd.idjob = ? ORDER BY jd.idjobduplicate ASC`, jobID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	duplicates = make([]data.JobDuplicate, 0)
+	for rows.Next() {
+		var d data.JobDuplicate
+		err = rows.Scan(&d.JobDuplicateID, &d.JobID, &d.JobLeaseID, &d.Time, &d.Response, &d.IsError, &d.Error)
+		if err != nil {
+			return
+		}
+		duplicates = append(duplicates, d)
+	}
+	err = rows.Err()
+	return
+}
+
+// GetJobAttempts gets the attempts made to execute a job, in order.
+func (m JobManager) GetJobAttempts(jobID int64) (attempts []data.JobAttempt, err error) {
+	rows, err := m.DB.Query(`SELECT ja.idjobattempt, ja.idjob, ja.attempt, ja."time", ja.response, ja.iserror, ja.error, ja.retryat `+
+		`FROM jobattempt ja WHERE ja.idjob = ? ORDER BY ja.attempt ASC`, jobID)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	attempts = make([]data.JobAttempt, 0)
+	for rows.Next() {
+		var a data.JobAttempt
+		var retryAt *time.Time
+		err = rows.Scan(&a.JobAttemptID, &a.JobID, &a.Attempt, &a.Time, &a.Response, &a.IsError, &a.Error, &retryAt)
+		if err != nil {
+			return
+		}
+		a.RetryAt = timeOrZero(retryAt)
+		attempts = append(attempts, a)
+	}
+	err = rows.Err()
+	return
+}
+
+// ListJobs lists the jobs which match the filter in ID order, starting after the afterID.
+func (m JobManager) ListJobs(filter data.JobFilter, afterID int64, limit int) (jobs []data.JobSummary, err error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "?" + strconv.Itoa(len(args))
+	}
+	leased := `EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = j.idjob AND jl."until" >= ` + arg(now()) + `)`
+	where := []string{"j.idjob > " + arg(afterID)}
+	if filter.ScheduleID != nil {
+		where = append(where, "j.idschedule = "+arg(*filter.ScheduleID))
+	}
+	switch filter.State {
+	case data.JobStatePending:
+		where = append(where, "jr.idjob IS NULL AND NOT "+leased)
+	case data.JobStateLeased:
+		where = append(where, "jr.idjob IS NULL AND "+leased)
+	case data.JobStateSucceeded:
+		where = append(where, "NOT jr.iserror")
+	case data.JobStateFailed:
+		where = append(where, "jr.iserror")
+	}
+	if !filter.WhenFrom.IsZero() {
+		where = append(where, `j."when" >= `+arg(filter.WhenFrom.UTC()))
+	}
+	if !filter.WhenTo.IsZero() {
+		where = append(where, `j."when" < `+arg(filter.WhenTo.UTC()))
+	}
+	if filter.ARNPrefix != "" {
+		where = append(where, "j.arn GLOB "+arg(globPrefix(filter.ARNPrefix)))
+	}
+	if filter.IsError != nil {
+		where = append(where, "EXISTS (SELECT 1 FROM jobattempt ja WHERE ja.idjob = j.idjob AND ja.iserror) = "+arg(*filter.IsError))
+	}
+	rows, err := m.DB.Query(`SELECT `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount, `+
+		`CASE WHEN jr.iserror THEN 'failed' WHEN jr.idjob IS NOT NULL THEN 'succeeded' `+
+		`WHEN `+leased+` THEN 'leased' ELSE 'pending' END AS state `+
+		`FROM job j `+
+		`LEFT JOIN jobresponse jr ON jr.idjob = j.idjob `+
+		`WHERE `+strings.Join(where, " AND ")+` `+
+		`ORDER BY j.idjob ASC `+
+		`LIMIT `+arg(limit), args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	jobs = make([]data.JobSummary, 0)
+	for rows.Next() {
+		var js data.JobSummary
+		j := &js.Job
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&j.JobID, &j.ScheduleID, &j.When, &j.ARN, &j.Payload, &httpRequestJSON, &retryPolicyJSON, &j.AttemptCount, &js.State)
+		if err != nil {
+			return
+		}
+		j.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		j.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		jobs = append(jobs, js)
+	}
+	err = rows.Err()
+	return
+}
+
+// globPrefix returns a GLOB pattern which matches strings that start with the prefix. GLOB is used instead of LIKE
+// because it's case sensitive, so it can use the index on the column.
+func globPrefix(prefix string) string {
+	return globEscaper.Replace(prefix) + "*"
+}
+
+var globEscaper = strings.NewReplacer("*", "[*]", "?", "[?]", "[", "[[]")
+
+// DeleteJob deletes a job which hasn't been started.
+func (m JobManager) DeleteJob(jobID int64) (ok bool, err error) {
+	result, err := m.DB.Exec(`DELETE FROM job WHERE idjob = ? AND `+
+		`NOT EXISTS (SELECT 1 FROM joblease jl WHERE jl.idjob = job.idjob) AND `+
+		`NOT EXISTS (SELECT 1 FROM jobresponse jr WHERE jr.idjob = job.idjob)`, jobID)
+	if err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	ok = affected > 0
+	return
+}
+
+// DeadLetterJob records the final failed attempt at a job, completes it with an error and marks it as a dead letter.
+// If the lease isn't current, the attempt is recorded as a duplicate instead.
+func (m JobManager) DeadLetterJob(j data.Job, jobLeaseID int64, resp string, jobError error) (deadLetterID int64, duplicate bool, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	current, err := currentLease(tx, j.JobID, jobLeaseID)
+	if err != nil {
+		return
+	}
+	if !current {
+		if err = insertDuplicate(tx, j.JobID, jobLeaseID, resp, true, errorString(jobError)); err != nil {
+			return
+		}
+		return 0, true, tx.Commit()
+	}
+	if err = insertAttempt(tx, j.JobID, resp, true, errorString(jobError), nil); err != nil {
+		return
+	}
+	at := now()
+	_, err = tx.Exec(`INSERT INTO jobresponse (idjob, "time", response, iserror, error) VALUES (?, ?, ?, 1, ?)`,
+		j.JobID, at, resp, errorString(jobError))
+	if err != nil {
+		return
+	}
+	if err = releaseDedupeKey(tx, j.JobID); err != nil {
+		return
+	}
+	res, err := tx.Exec(`INSERT INTO deadletter (idjob, "time", error) VALUES (?, ?, ?)`, j.JobID, at, errorString(jobError))
+	if err != nil {
+		return
+	}
+	if deadLetterID, err = res.LastInsertId(); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// RecordDeadLetterForward records the result of forwarding a dead letter to the dead letter ARN.
+func (m JobManager) RecordDeadLetterForward(deadLetterID int64, forwardedTo string, forwardError error) error {
+	var errorString sql.NullString
+	if forwardError != nil {
+		errorString.String = forwardError.Error()
+		errorString.Valid = true
+	}
+	_, err := m.DB.Exec(`UPDATE deadletter SET forwardedto = ?, forwarderror = ? WHERE iddeadletter = ?`,
+		forwardedTo, errorString, deadLetterID)
+	return err
+}
+
+// GetDeadLetters lists dead letters in ID order, starting after the afterID.
+func (m JobManager) GetDeadLetters(requeued bool, afterID int64, limit int) (dls []data.DeadLetter, err error) {
+	rows, err := m.DB.Query(`SELECT `+
+		`dl.iddeadletter, dl."time", dl.error, dl.forwardedto, dl.forwarderror, dl.idrequeuedjob, dl.requeueddate, `+
+		`j.idjob, j.idschedule, j."when", j.arn, j.payload, j.httprequest, j.retrypolicy, `+
+		`(SELECT COUNT(*) FROM jobattempt ja WHERE ja.idjob = j.idjob) AS attemptcount `+
+		`FROM deadletter dl `+
+		`INNER JOIN job j ON j.idjob = dl.idjob `+
+		`WHERE dl.iddeadletter > ? AND (dl.idrequeuedjob IS NOT NULL) = ? `+
+		`ORDER BY dl.iddeadletter ASC `+
+		`LIMIT ?`, afterID, requeued, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	dls = make([]data.DeadLetter, 0)
+	for rows.Next() {
+		var dl data.DeadLetter
+		var forwardedTo, forwardError sql.NullString
+		var requeuedJobID sql.NullInt64
+		var requeuedDate *time.Time
+		var httpRequestJSON, retryPolicyJSON sql.NullString
+		err = rows.Scan(&dl.DeadLetterID, &dl.Time, &dl.Error, &forwardedTo, &forwardError, &requeuedJobID, &requeuedDate,
+			&dl.Job.JobID, &dl.Job.ScheduleID, &dl.Job.When, &dl.Job.ARN, &dl.Job.Payload, &httpRequestJSON, &retryPolicyJSON, &dl.Job.AttemptCount)
+		if err != nil {
+			return
+		}
+		dl.ForwardedTo = forwardedTo.String
+		dl.ForwardError = forwardError.String
+		if requeuedJobID.Valid {
+			dl.RequeuedJobID = &requeuedJobID.Int64
+		}
+		dl.RequeuedDate = timeOrZero(requeuedDate)
+		dl.Job.HTTPRequest, err = unmarshalHTTPRequest(httpRequestJSON)
+		if err != nil {
+			return
+		}
+		dl.Job.RetryPolicy, err = unmarshalRetryPolicy(retryPolicyJSON)
+		if err != nil {
+			return
+		}
+		dls = append(dls, dl)
+	}
+	err = rows.Err()
+	return
+}
+
+// RequeueDeadLetters creates a new job for each dead letter, returning a map of dead letter ID to new job ID.
+func (m JobManager) RequeueDeadLetters(deadLetterIDs []int64) (jobIDs map[int64]int64, err error) {
+	jobIDs = make(map[int64]int64)
+	for _, id := range deadLetterIDs {
+		var jobID int64
+		var ok bool
+		jobID, ok, err = requeueDeadLetter(m.DB, id)
+		if err != nil {
+			return
+		}
+		if ok {
+			jobIDs[id] = jobID
+		}
+	}
+	return
+}
+
+// requeueDeadLetter creates a new job with the same data as the dead job, so that the history of the dead job is kept.
+func requeueDeadLetter(db *sql.DB, deadLetterID int64) (jobID int64, ok bool, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var deadJobID int64
+	err = tx.QueryRow(`SELECT dl.idjob FROM deadletter dl WHERE dl.iddeadletter = ? AND dl.idrequeuedjob IS NULL`,
+		deadLetterID).Scan(&deadJobID)
+	if err == sql.ErrNoRows {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	at := now()
+	res, err := tx.Exec(`INSERT INTO job (arn, payload, httprequest, retrypolicy, idschedule, "when") `+
+		`SELECT j.arn, j.payload, j.httprequest, j.retrypolicy, j.idschedule, ? FROM job j WHERE j.idjob = ?`, at, deadJobID)
+	if err != nil {
+		return
+	}
+	if jobID, err = res.LastInsertId(); err != nil {
+		return
+	}
+	_, err = tx.Exec(`UPDATE deadletter SET idrequeuedjob = ?, requeueddate = ? WHERE iddeadletter = ?`,
+		jobID, at, deadLetterID)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	ok = err == nil
+	return
+}
+
+// PurgeJobs deletes up to limit jobs which were completed before the cutoff, along with their responses, attempts,
+// duplicates and leases. Jobs which have been dead lettered are kept.
+func (m JobManager) PurgeJobs(completedBefore time.Time, limit int) (purged int, err error) {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	jobIDs, err := queryJobIDs(tx, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr."time" < ? AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) `+
+		`ORDER BY jr."time" ASC LIMIT ?`, completedBefore.UTC(), limit)
+	if err != nil || len(jobIDs) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, jobIDs); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(jobIDs), nil
+}
+
+// GetCompletedJobs gets up to limit jobs which were completed before the cutoff, oldest first, with their responses,
+// attempts and duplicates. Jobs which have been dead lettered aren't included.
+func (m JobManager) GetCompletedJobs(completedBefore time.Time, limit int) (jrs []data.JobAndResponse, err error) {
+	jobIDs, err := queryJobIDs(m.DB, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr."time" < ? AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob) `+
+		`ORDER BY jr."time" ASC LIMIT ?`, completedBefore.UTC(), limit)
+	if err != nil {
+		return
+	}
+	jrs = make([]data.JobAndResponse, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		var jr data.JobAndResponse
+		var jobOK bool
+		jr.Job, jr.JobResponse, jobOK, jr.HasJobResponse, err = m.GetJobResponse(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if !jobOK {
+			// The job was purged in the meantime.
+			continue
+		}
+		if jr.Attempts, err = m.GetJobAttempts(jobID); err != nil {
+			return nil, err
+		}
+		if jr.Duplicates, err = m.GetJobDuplicates(jobID); err != nil {
+			return nil, err
+		}
+		jrs = append(jrs, jr)
+	}
+	return
+}
+
+// DeleteCompletedJobs deletes the jobs, along with their responses, attempts, duplicates and leases. Jobs which
+// haven't been completed, or have been dead lettered, are kept.
+func (m JobManager) DeleteCompletedJobs(jobIDs []int64) (deleted int, err error) {
+	if len(jobIDs) == 0 {
+		return
+	}
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	completed, err := queryJobIDs(tx, `SELECT jr.idjob FROM jobresponse jr `+
+		`WHERE jr.idjob IN `+in(len(jobIDs))+` AND NOT EXISTS (SELECT 1 FROM deadletter dl WHERE dl.idjob = jr.idjob)`, args(jobIDs)...)
+	if err != nil || len(completed) == 0 {
+		return
+	}
+	if err = deleteJobs(tx, completed); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+	return len(completed), nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// rowQueryer is implemented by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// queryJobIDs runs a query which returns job IDs.
+func queryJobIDs(q queryer, query string, args ...interface{}) (jobIDs []int64, err error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobID int64
+		if err = rows.Scan(&jobID); err != nil {
+			return
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+	return jobIDs, rows.Err()
+}
+
+// deleteJobs deletes the jobs, and the rows which reference them before the jobs themselves.
+func deleteJobs(tx *sql.Tx, jobIDs []int64) error {
+	for _, table := range []string{"jobattempt", "jobduplicate", "joblease", "jobresponse", "job"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE idjob IN `+in(len(jobIDs)), args(jobIDs)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// in returns a list of n placeholders for an IN clause, e.g. (?, ?).
+func in(n int) string {
+	return "(?" + strings.Repeat(", ?", n-1) + ")"
+}
+
+// args converts IDs to query arguments.
+func args(ids []int64) []interface{} {
+	a := make([]interface{}, len(ids))
+	for i, id := range ids {
+		a[i] = id
+	}
+	return a
+}
+
+// PurgeJobLeases deletes up to limit job leases which expired before the cutoff.
+func (m JobManager) PurgeJobLeases(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM joblease WHERE idjoblease IN `+
+		`(SELECT idjoblease FROM joblease WHERE "until" < ? ORDER BY "until" ASC LIMIT ?)`, expiredBefore, limit)
+}
+
+// PurgeIdempotencyKeys deletes up to limit idempotency keys which were created before the cutoff.
+func (m JobManager) PurgeIdempotencyKeys(createdBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM idempotencykey WHERE ididempotencykey IN `+
+		`(SELECT ididempotencykey FROM idempotencykey WHERE created < ? ORDER BY created ASC LIMIT ?)`, createdBefore, limit)
+}
+
+// PurgeDedupeKeys deletes up to limit dedupe keys whose windows ended before the cutoff.
+func (m JobManager) PurgeDedupeKeys(expiredBefore time.Time, limit int) (purged int, err error) {
+	return purge(m.DB, `DELETE FROM jobdedupe WHERE idjobdedupe IN `+
+		`(SELECT idjobdedupe FROM jobdedupe WHERE expires < ? ORDER BY expires ASC LIMIT ?)`, expiredBefore, limit)
+}
+
+// purge runs a DELETE statement which takes a cutoff time and a limit, and returns the number of rows deleted.
+// The rows to delete are selected with a subquery, since DELETE ... LIMIT is an optional feature of SQLite.
+func purge(db *sql.DB, query string, before time.Time, limit int) (purged int, err error) {
+	res, err := db.Exec(query, before.UTC(), limit)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
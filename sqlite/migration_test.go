@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/sqlite/migrations"
+)
+
+func TestThatBinDataIsPresent(t *testing.T) {
+	names := migrations.AssetNames()
+	if len(names) == 0 {
+		t.Errorf("expected more than 0 names, but got %v", len(names))
+	}
+}
+
+func TestThatBinDataCanBeAccessed(t *testing.T) {
+	name := migrations.AssetNames()[0]
+	_, err := migrations.Asset(name)
+	if err != nil {
+		t.Errorf("couldn't load asset '%v' with error '%v'", name, err)
+	}
+}
+
+func TestMigrationsCanBeLoaded(t *testing.T) {
+	driver, err := LoadMigrations()
+	if err != nil {
+		t.Errorf("failed to load migrations: %v", err)
+	}
+	defer driver.Close()
+
+	v, err := driver.First()
+	if err != nil {
+		t.Errorf("failed to find any migrations: %v", err)
+	}
+	if v == 0 {
+		t.Error("got version 0, should have been different")
+	}
+}
+
+func TestThatEveryMigrationCanBeReverted(t *testing.T) {
+	names := map[string]bool{}
+	for _, name := range migrations.AssetNames() {
+		names[name] = true
+	}
+	for name := range names {
+		if strings.HasSuffix(name, ".up.sql") && !names[strings.TrimSuffix(name, ".up.sql")+".down.sql"] {
+			t.Errorf("migration '%v' doesn't have a down migration", name)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	first, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	defer first.Close()
+	v, err := first.First()
+	if err != nil {
+		t.Fatalf("failed to find any migrations: %v", err)
+	}
+
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatalf("failed to get the latest version: %v", err)
+	}
+	if latest <= v {
+		t.Errorf("expected the latest version to be after the first version %v, got %v", v, latest)
+	}
+	if _, _, err = first.ReadUp(latest); err != nil {
+		t.Errorf("expected to be able to read the latest migration %v: %v", latest, err)
+	}
+}
+
+func TestMigrationManager(t *testing.T) {
+	dsn, dbName, err := CreateTestDatabase()
+	if err != nil {
+		t.Fatalf("failed to create test database with error: %v", err)
+	}
+	defer DropTestDatabase(dbName)
+	db, err := Open(dsn)
+	if err != nil {
+		t.Fatalf("failed to open test database with error: %v", err)
+	}
+	defer db.Close()
+	m := NewMigrationManager(db)
+
+	latest, err := m.LatestVersion()
+	if err != nil {
+		t.Fatalf("failed to get the latest version: %v", err)
+	}
+	assertVersion := func(step string, expected uint) {
+		v, dirty, err := m.Version()
+		if err != nil {
+			t.Fatalf("%s: failed to get the version: %v", step, err)
+		}
+		if v != expected || dirty {
+			t.Errorf("%s: expected version %v, got %v (dirty: %v)", step, expected, v, dirty)
+		}
+	}
+	assertVersion("created", latest)
+
+	if err = m.Down(1); err != nil {
+		t.Fatalf("failed to revert a migration: %v", err)
+	}
+	if _, err = NewJobManager(db).PurgeJobLeases(time.Now(), 1); err != nil {
+		t.Errorf("expected the tables from the first migration to still exist, got %v", err)
+	}
+	first, err := LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	defer first.Close()
+	previous, err := first.Prev(latest)
+	if err != nil {
+		t.Fatalf("failed to find the previous migration: %v", err)
+	}
+	assertVersion("down", previous)
+
+	if err = m.Goto(latest); err != nil {
+		t.Fatalf("failed to migrate to the latest version: %v", err)
+	}
+	assertVersion("goto", latest)
+	if err = m.Goto(latest); err != nil {
+		t.Errorf("expected migrating to the current version to do nothing, got %v", err)
+	}
+	if err = m.UpdateSchema(); err != nil {
+		t.Errorf("expected updating an up to date schema to do nothing, got %v", err)
+	}
+
+	if err = m.Force(int(previous)); err != nil {
+		t.Fatalf("failed to force the version: %v", err)
+	}
+	assertVersion("force", previous)
+}
@@ -0,0 +1,104 @@
+CREATE TABLE schedule (
+  idschedule INTEGER PRIMARY KEY AUTOINCREMENT,
+  externalid VARCHAR(256) NOT NULL,
+  "by" VARCHAR(256) NOT NULL,
+  arn VARCHAR(2048) NOT NULL,
+  payload TEXT NOT NULL,
+  httprequest TEXT NULL,
+  retrypolicy TEXT NULL,
+  timezone VARCHAR(64) NOT NULL DEFAULT '',
+  "until" DATETIME NULL,
+  maxruns INT NOT NULL DEFAULT 0,
+  runs INT NOT NULL DEFAULT 0,
+  misfirepolicy VARCHAR(16) NOT NULL DEFAULT '',
+  created DATETIME NOT NULL,
+  active BOOLEAN NOT NULL,
+  deactivateddate DATETIME NULL,
+  paused BOOLEAN NOT NULL DEFAULT 0);
+
+CREATE TABLE crontab (
+  idcrontab INTEGER PRIMARY KEY AUTOINCREMENT,
+  idschedule INTEGER NOT NULL REFERENCES schedule(idschedule),
+  crontab VARCHAR(256) NOT NULL,
+  previous DATETIME NOT NULL,
+  "next" DATETIME NOT NULL,
+  lastupdated DATETIME NOT NULL);
+
+CREATE INDEX idx_crontab_next ON crontab ("next");
+
+CREATE TABLE crontablease (
+  idcrontablease INTEGER PRIMARY KEY AUTOINCREMENT,
+  idcrontab INTEGER NOT NULL REFERENCES crontab(idcrontab),
+  lockedby VARCHAR(256) NOT NULL,
+  "at" DATETIME NOT NULL,
+  "until" DATETIME NOT NULL,
+  rescinded BOOLEAN NOT NULL DEFAULT 0);
+
+CREATE INDEX idx_crontablease_idcrontab_until ON crontablease (idcrontab, "until");
+
+CREATE TABLE schedulepause (
+  idschedulepause INTEGER PRIMARY KEY AUTOINCREMENT,
+  idschedule INTEGER NOT NULL REFERENCES schedule(idschedule),
+  pauseddate DATETIME NOT NULL,
+  pausedby VARCHAR(256) NOT NULL,
+  resumeddate DATETIME NULL,
+  resumedby VARCHAR(256) NOT NULL,
+  catchup BOOLEAN NOT NULL);
+
+CREATE INDEX idx_schedulepause_idschedule ON schedulepause (idschedule, idschedulepause);
+
+CREATE TABLE job (
+  idjob INTEGER PRIMARY KEY AUTOINCREMENT,
+  idschedule INTEGER NULL REFERENCES schedule(idschedule),
+  "when" DATETIME NOT NULL,
+  arn VARCHAR(2048) NULL,
+  payload TEXT NULL,
+  httprequest TEXT NULL,
+  retrypolicy TEXT NULL);
+
+CREATE INDEX idx_job_when ON job ("when");
+
+CREATE TABLE joblease (
+  idjoblease INTEGER PRIMARY KEY AUTOINCREMENT,
+  idjob INTEGER NOT NULL REFERENCES job(idjob),
+  lockedby VARCHAR(256) NOT NULL,
+  "at" DATETIME NOT NULL,
+  "until" DATETIME NOT NULL);
+
+CREATE INDEX idx_joblease_idjob_until ON joblease (idjob, "until");
+
+CREATE TABLE jobresponse (
+  idjobresponse INTEGER PRIMARY KEY AUTOINCREMENT,
+  idjob INTEGER NOT NULL REFERENCES job(idjob),
+  "time" DATETIME NOT NULL,
+  response TEXT NOT NULL,
+  iserror BOOLEAN NOT NULL,
+  error TEXT NOT NULL);
+
+CREATE UNIQUE INDEX idx_jobresponse_idjob ON jobresponse (idjob);
+
+CREATE TABLE jobattempt (
+  idjobattempt INTEGER PRIMARY KEY AUTOINCREMENT,
+  idjob INTEGER NOT NULL REFERENCES job(idjob),
+  attempt INT NOT NULL,
+  "time" DATETIME NOT NULL,
+  response TEXT NOT NULL,
+  iserror BOOLEAN NOT NULL,
+  error TEXT NOT NULL,
+  retryat DATETIME NULL);
+
+CREATE INDEX idx_jobattempt_idjob ON jobattempt (idjob, attempt);
+
+CREATE TABLE deadletter (
+  iddeadletter INTEGER PRIMARY KEY AUTOINCREMENT,
+  idjob INTEGER NOT NULL REFERENCES job(idjob),
+  "time" DATETIME NOT NULL,
+  error TEXT NOT NULL,
+  forwardedto VARCHAR(2048) NULL,
+  forwarderror TEXT NULL,
+  idrequeuedjob INT NULL,
+  requeueddate DATETIME NULL);
+
+CREATE UNIQUE INDEX idx_deadletter_idjob ON deadletter (idjob);
+
+CREATE INDEX idx_deadletter_idrequeuedjob ON deadletter (idrequeuedjob, iddeadletter);
@@ -0,0 +1,4 @@
+-- Indexes used to filter the jobs listed by the API. ARN prefixes are matched with GLOB, which can use the index.
+CREATE INDEX idx_job_idschedule ON job (idschedule, idjob);
+
+CREATE INDEX idx_job_arn ON job (arn);
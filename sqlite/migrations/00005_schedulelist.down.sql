@@ -0,0 +1,5 @@
+DROP INDEX idx_crontab_idschedule;
+DROP INDEX idx_schedule_created;
+DROP INDEX idx_schedule_arn;
+DROP INDEX idx_schedule_by;
+DROP INDEX idx_schedule_externalid;
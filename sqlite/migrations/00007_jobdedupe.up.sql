@@ -0,0 +1,14 @@
+-- The dedupe keys of jobs. A key is held by a job until its dedupe window ends, after which it can be taken by a new
+-- job. There's no reference to the job, because a job can be deleted or purged while its key is held, which releases
+-- the key.
+CREATE TABLE jobdedupe (
+  idjobdedupe INTEGER PRIMARY KEY AUTOINCREMENT,
+  dedupekey VARCHAR(256) NOT NULL,
+  idjob INTEGER NOT NULL,
+  expires DATETIME NOT NULL);
+
+CREATE UNIQUE INDEX idx_jobdedupe_dedupekey ON jobdedupe (dedupekey);
+
+CREATE INDEX idx_jobdedupe_idjob ON jobdedupe (idjob);
+
+CREATE INDEX idx_jobdedupe_expires ON jobdedupe (expires);
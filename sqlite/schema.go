@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"database/sql"
+	"os"
+
+	"github.com/mattes/migrate"
+	"github.com/mattes/migrate/database/sqlite3"
+	"github.com/mattes/migrate/source"
+	"github.com/welldigital/callme/logger"
+	"github.com/welldigital/callme/sqlite/migrations"
+
+	bindata "github.com/mattes/migrate/source/go-bindata"
+	_ "github.com/mattn/go-sqlite3" // Requires SQLite
+)
+
+// Open opens a pool of connections to the SQLite database. The pool is safe for concurrent use, so a
+// single pool should be shared by the managers, and closed when the process exits.
+func Open(connectionString string) (*sql.DB, error) {
+	return sql.Open("sqlite3", connectionString)
+}
+
+// MigrationManager provides features to manage the database schema using SQLite.
+type MigrationManager struct {
+	DB *sql.DB
+}
+
+// NewMigrationManager creates a new MigrationManager.
+func NewMigrationManager(db *sql.DB) MigrationManager {
+	return MigrationManager{
+		DB: db,
+	}
+}
+
+// UpdateSchema updates the database schema to match.
+func (m MigrationManager) UpdateSchema() error {
+	r, err := m.migrator()
+	if err != nil {
+		return err
+	}
+	err = r.Up()
+	// Don't error on no changes.
+	if err == migrate.ErrNoChange {
+		err = nil
+	}
+	return err
+}
+
+// Version returns the version of the database schema, and whether a migration failed part way through,
+// leaving the schema dirty. The version is 0 if no migrations have been run.
+func (m MigrationManager) Version() (version uint, dirty bool, err error) {
+	r, err := m.migrator()
+	if err != nil {
+		return
+	}
+	version, dirty, err = r.Version()
+	if err == migrate.ErrNilVersion {
+		err = nil
+	}
+	return
+}
+
+// LatestVersion returns the version of the last migration included with the program.
+func (m MigrationManager) LatestVersion() (uint, error) {
+	return latestVersion()
+}
+
+// Down reverts the last n migrations.
+func (m MigrationManager) Down(n int) error {
+	r, err := m.migrator()
+	if err != nil {
+		return err
+	}
+	return r.Steps(-n)
+}
+
+// Goto migrates the database schema up or down to the version.
+func (m MigrationManager) Goto(version uint) error {
+	r, err := m.migrator()
+	if err != nil {
+		return err
+	}
+	err = r.Migrate(version)
+	if err == migrate.ErrNoChange {
+		err = nil
+	}
+	return err
+}
+
+// Force sets the version of the database schema without running any migrations, and clears the dirty flag.
+// It's used to recover after a migration has failed part way through and the database has been fixed by hand.
+func (m MigrationManager) Force(version int) error {
+	r, err := m.migrator()
+	if err != nil {
+		return err
+	}
+	return r.Force(version)
+}
+
+// migrator doesn't need to be closed, closing it would close the database which is shared with the managers.
+func (m MigrationManager) migrator() (*migrate.Migrate, error) {
+	driver, err := sqlite3.WithInstance(m.DB, &sqlite3.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	migrationSource, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := migrate.NewWithInstance("go-bindata", migrationSource, "sqlite3", driver)
+	if err != nil {
+		return nil, err
+	}
+	r.Log = MigrationLogger{}
+	return r, nil
+}
+
+// MigrationLogger provides a logger which the migration system can use.
+type MigrationLogger struct {
+}
+
+// Printf provides a printf function.
+func (ml MigrationLogger) Printf(format string, v ...interface{}) {
+	logger.For("github.com/welldigital/callme/sqlite/migrations", "sqlite").Infof(format, v...)
+}
+
+// Verbose always returns true.
+func (ml MigrationLogger) Verbose() bool {
+	return true
+}
+
+// LoadMigrations loads database migrations from the bindata included with the program.
+func LoadMigrations() (source.Driver, error) {
+	s := bindata.Resource(migrations.AssetNames(),
+		func(name string) ([]byte, error) {
+			return migrations.Asset(name)
+		})
+
+	return bindata.WithInstance(s)
+}
+
+func latestVersion() (version uint, err error) {
+	migrationSource, err := LoadMigrations()
+	if err != nil {
+		return
+	}
+	defer migrationSource.Close()
+	next, err := migrationSource.First()
+	for err == nil {
+		version = next
+		next, err = migrationSource.Next(version)
+	}
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
@@ -0,0 +1,47 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/welldigital/callme/sqlite"
+	"github.com/welldigital/callme/storage"
+	"github.com/welldigital/callme/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) (storage.Store, func()) {
+		_, fileName, err := sqlite.CreateTestDatabase()
+		if err != nil {
+			t.Fatalf("failed to create test database with error: %v", err)
+		}
+		s, err := storage.Open("sqlite://"+fileName, storage.PoolOptions{})
+		if err != nil {
+			sqlite.DropTestDatabase(fileName)
+			t.Fatalf("failed to open test database with error: %v", err)
+		}
+		return s, func() {
+			s.Close()
+			sqlite.DropTestDatabase(fileName)
+		}
+	})
+}
+
+func TestStartJobsInFewStatements(t *testing.T) {
+	connectionString, fileName, err := sqlite.CreateTestDatabase()
+	if err != nil {
+		t.Fatalf("failed to create test database with error: %v", err)
+	}
+	defer sqlite.DropTestDatabase(fileName)
+	counter := &storagetest.StatementCounter{Driver: &sqlite3.SQLiteDriver{}}
+	sql.Register("sqlite3-counting", counter)
+	db, err := sql.Open("sqlite3-counting", connectionString)
+	if err != nil {
+		t.Fatalf("failed to open test database with error: %v", err)
+	}
+	defer db.Close()
+
+	jm := sqlite.NewJobManager(db)
+	storagetest.StartsJobsInFewStatements(t, jm.StartJobs, jm.GetJobResponse, counter)
+}
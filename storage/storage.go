@@ -0,0 +1,382 @@
+// Package storage provides the database that callme keeps its jobs and schedules in. The backend is chosen
+// from the connection string: postgres:// and postgresql:// URLs use PostgreSQL, sqlite:// followed by a file path
+// uses a SQLite database file, memory:// keeps everything in the memory of the process, while anything else is
+// treated as a MySQL DSN, optionally prefixed with mysql://.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/memory"
+	"github.com/welldigital/callme/mysql"
+	"github.com/welldigital/callme/postgres"
+	"github.com/welldigital/callme/sqlite"
+)
+
+// Backend names.
+const (
+	MySQL      = "mysql"
+	PostgreSQL = "postgres"
+	SQLite     = "sqlite"
+	Memory     = "memory"
+)
+
+// PoolOptions configures the pool of database connections which the functions of a Store share.
+type PoolOptions struct {
+	// MaxOpenConnections limits the number of connections which are open at once, 0 means no limit.
+	MaxOpenConnections int
+	// MaxIdleConnections is the number of connections which are kept open between calls, 0 means none.
+	MaxIdleConnections int
+	// ConnectionMaxLifetime is how long a connection can be reused for, 0 means forever.
+	ConnectionMaxLifetime time.Duration
+}
+
+func (o PoolOptions) apply(db *sql.DB) {
+	db.SetMaxOpenConns(o.MaxOpenConnections)
+	db.SetMaxIdleConns(o.MaxIdleConnections)
+	db.SetConnMaxLifetime(o.ConnectionMaxLifetime)
+}
+
+// Store groups the functions which a storage backend provides.
+type Store struct {
+	// Backend is the name of the backend, e.g. "mysql".
+	Backend string
+	// DB is the pool of connections which the functions share, it's nil for the memory backend.
+	DB *sql.DB
+	// UpdateSchema runs the backend's migrations.
+	UpdateSchema func() error
+	// Migrator manages the version of the database schema, it's nil for the memory backend.
+	Migrator Migrator
+
+	JobStarter                data.JobStarter
+	IdempotentJobStarter      data.IdempotentJobStarter
+	JobsStarter               data.JobsStarter
+	JobGetter                 data.JobGetter
+	JobsGetter                data.JobsGetter
+	JobLeaseRenewer           data.JobLeaseRenewer
+	JobCompleter              data.JobCompleter
+	JobsCompleter             data.JobsCompleter
+	JobRetrier                data.JobRetrier
+	JobDeadLetterer           data.JobDeadLetterer
+	JobAttemptsGetter         data.JobAttemptsGetter
+	JobDuplicatesGetter       data.JobDuplicatesGetter
+	JobAndResponseByIDGetter  data.JobAndResponseByIDGetter
+	JobsLister                data.JobsLister
+	JobDeleter                data.JobDeleter
+	AvailableJobCounter       func() (int, error)
+	DeadLettersGetter         data.DeadLettersGetter
+	DeadLetterRequeuer        data.DeadLetterRequeuer
+	DeadLetterForwardRecorder data.DeadLetterForwardRecorder
+	JobsPurger                data.JobsPurger
+	CompletedJobsGetter       data.CompletedJobsGetter
+	CompletedJobsDeleter      data.CompletedJobsDeleter
+	JobLeasesPurger           data.JobLeasesPurger
+	IdempotencyKeysPurger     data.IdempotencyKeysPurger
+	DedupeKeysPurger          data.DedupeKeysPurger
+
+	ScheduleCreator           data.ScheduleCreator
+	IdempotentScheduleCreator data.IdempotentScheduleCreator
+	ScheduleUpdater           data.ScheduleUpdater
+	ScheduleByIDGetter        data.ScheduleByIDGetter
+	SchedulesLister           data.SchedulesLister
+	ScheduleDeactivator       data.ScheduleDeactivator
+	SchedulePauser            data.SchedulePauser
+	ScheduleResumer           data.ScheduleResumer
+	ScheduleGetter            data.ScheduleGetter
+	ScheduledJobStarter       data.ScheduledJobStarter
+	CrontabSkipper            data.CrontabSkipper
+	CrontabLeasesPurger       data.CrontabLeasesPurger
+}
+
+// Migrator manages the version of a SQL backend's database schema, using the migrations which are included
+// with the program.
+type Migrator interface {
+	// UpdateSchema runs all of the migrations which haven't been run yet.
+	UpdateSchema() error
+	// Version returns the version of the database schema, 0 if no migrations have been run.
+	Version() (version uint, dirty bool, err error)
+	// LatestVersion returns the version of the last migration included with the program.
+	LatestVersion() (uint, error)
+	// Down reverts the last n migrations.
+	Down(n int) error
+	// Goto migrates the database schema up or down to the version.
+	Goto(version uint) error
+	// Force sets the version without running any migrations, and clears the dirty flag.
+	Force(version int) error
+}
+
+// Open returns the Store for the connection string. SQL backends share a pool of connections, which is
+// configured by the options.
+func Open(connectionString string, options PoolOptions) (s Store, err error) {
+	switch BackendFor(connectionString) {
+	case PostgreSQL:
+		s, err = newPostgreSQL(connectionString)
+	case SQLite:
+		s, err = newSQLite(sqlite.DSN(strings.TrimPrefix(connectionString, "sqlite://")))
+	case Memory:
+		s = newMemory()
+	default:
+		s, err = newMySQL(strings.TrimPrefix(connectionString, "mysql://"))
+	}
+	if err != nil || s.DB == nil {
+		return
+	}
+	options.apply(s.DB)
+	return
+}
+
+// Close closes the pool of connections.
+func (s Store) Close() error {
+	if s.DB == nil {
+		return nil
+	}
+	return s.DB.Close()
+}
+
+// CheckSchema returns an error if the database schema is behind the migrations included with the program, or
+// if a migration failed part way through. The memory backend's schema is always up to date.
+func (s Store) CheckSchema() error {
+	if s.Migrator == nil {
+		return nil
+	}
+	version, dirty, err := s.Migrator.Version()
+	if err != nil {
+		return fmt.Errorf("failed to get the schema version: %v", err)
+	}
+	if dirty {
+		return fmt.Errorf("the schema is dirty at version %v, fix the database and then force the version", version)
+	}
+	latest, err := s.Migrator.LatestVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get the latest schema version: %v", err)
+	}
+	if version < latest {
+		return fmt.Errorf("the schema is at version %v, but version %v is required", version, latest)
+	}
+	return nil
+}
+
+// BackendFor returns the name of the backend which is used for the connection string.
+func BackendFor(connectionString string) string {
+	if strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://") {
+		return PostgreSQL
+	}
+	if strings.HasPrefix(connectionString, "sqlite://") {
+		return SQLite
+	}
+	if strings.HasPrefix(connectionString, "memory://") {
+		return Memory
+	}
+	return MySQL
+}
+
+func newMySQL(connectionString string) (Store, error) {
+	db, err := mysql.Open(connectionString)
+	if err != nil {
+		return Store{}, err
+	}
+	jm := mysql.NewJobManager(db)
+	sm := mysql.NewScheduleManager(db)
+	mm := mysql.NewMigrationManager(db)
+	return Store{
+		Backend:                   MySQL,
+		DB:                        db,
+		UpdateSchema:              mm.UpdateSchema,
+		Migrator:                  mm,
+		JobStarter:                jm.StartJob,
+		IdempotentJobStarter:      jm.StartJobIdempotently,
+		JobsStarter:               jm.StartJobs,
+		JobGetter:                 jm.GetJob,
+		JobsGetter:                jm.GetJobs,
+		JobLeaseRenewer:           jm.RenewJobLease,
+		JobCompleter:              jm.CompleteJob,
+		JobsCompleter:             jm.CompleteJobs,
+		JobRetrier:                jm.RetryJob,
+		JobDeadLetterer:           jm.DeadLetterJob,
+		JobAttemptsGetter:         jm.GetJobAttempts,
+		JobDuplicatesGetter:       jm.GetJobDuplicates,
+		JobAndResponseByIDGetter:  jm.GetJobResponse,
+		JobsLister:                jm.ListJobs,
+		JobDeleter:                jm.DeleteJob,
+		AvailableJobCounter:       jm.GetAvailableJobCount,
+		DeadLettersGetter:         jm.GetDeadLetters,
+		DeadLetterRequeuer:        jm.RequeueDeadLetters,
+		DeadLetterForwardRecorder: jm.RecordDeadLetterForward,
+		JobsPurger:                jm.PurgeJobs,
+		CompletedJobsGetter:       jm.GetCompletedJobs,
+		CompletedJobsDeleter:      jm.DeleteCompletedJobs,
+		JobLeasesPurger:           jm.PurgeJobLeases,
+		IdempotencyKeysPurger:     jm.PurgeIdempotencyKeys,
+		DedupeKeysPurger:          jm.PurgeDedupeKeys,
+		ScheduleCreator:           sm.Create,
+		IdempotentScheduleCreator: sm.CreateIdempotently,
+		ScheduleUpdater:           sm.Update,
+		ScheduleByIDGetter:        sm.GetScheduleByID,
+		SchedulesLister:           sm.ListSchedules,
+		ScheduleDeactivator:       sm.Deactivate,
+		SchedulePauser:            sm.Pause,
+		ScheduleResumer:           sm.Resume,
+		ScheduleGetter:            sm.GetSchedule,
+		ScheduledJobStarter:       sm.StartJobAndUpdateCron,
+		CrontabSkipper:            sm.SkipCrontab,
+		CrontabLeasesPurger:       sm.PurgeCrontabLeases,
+	}, nil
+}
+
+func newPostgreSQL(connectionString string) (Store, error) {
+	db, err := postgres.Open(connectionString)
+	if err != nil {
+		return Store{}, err
+	}
+	jm := postgres.NewJobManager(db)
+	sm := postgres.NewScheduleManager(db)
+	mm := postgres.NewMigrationManager(db)
+	return Store{
+		Backend:                   PostgreSQL,
+		DB:                        db,
+		UpdateSchema:              mm.UpdateSchema,
+		Migrator:                  mm,
+		JobStarter:                jm.StartJob,
+		IdempotentJobStarter:      jm.StartJobIdempotently,
+		JobsStarter:               jm.StartJobs,
+		JobGetter:                 jm.GetJob,
+		JobsGetter:                jm.GetJobs,
+		JobLeaseRenewer:           jm.RenewJobLease,
+		JobCompleter:              jm.CompleteJob,
+		JobsCompleter:             jm.CompleteJobs,
+		JobRetrier:                jm.RetryJob,
+		JobDeadLetterer:           jm.DeadLetterJob,
+		JobAttemptsGetter:         jm.GetJobAttempts,
+		JobDuplicatesGetter:       jm.GetJobDuplicates,
+		JobAndResponseByIDGetter:  jm.GetJobResponse,
+		JobsLister:                jm.ListJobs,
+		JobDeleter:                jm.DeleteJob,
+		AvailableJobCounter:       jm.GetAvailableJobCount,
+		DeadLettersGetter:         jm.GetDeadLetters,
+		DeadLetterRequeuer:        jm.RequeueDeadLetters,
+		DeadLetterForwardRecorder: jm.RecordDeadLetterForward,
+		JobsPurger:                jm.PurgeJobs,
+		CompletedJobsGetter:       jm.GetCompletedJobs,
+		CompletedJobsDeleter:      jm.DeleteCompletedJobs,
+		JobLeasesPurger:           jm.PurgeJobLeases,
+		IdempotencyKeysPurger:     jm.PurgeIdempotencyKeys,
+		DedupeKeysPurger:          jm.PurgeDedupeKeys,
+		ScheduleCreator:           sm.Create,
+		IdempotentScheduleCreator: sm.CreateIdempotently,
+		ScheduleUpdater:           sm.Update,
+		ScheduleByIDGetter:        sm.GetScheduleByID,
+		SchedulesLister:           sm.ListSchedules,
+		ScheduleDeactivator:       sm.Deactivate,
+		SchedulePauser:            sm.Pause,
+		ScheduleResumer:           sm.Resume,
+		ScheduleGetter:            sm.GetSchedule,
+		ScheduledJobStarter:       sm.StartJobAndUpdateCron,
+		CrontabSkipper:            sm.SkipCrontab,
+		CrontabLeasesPurger:       sm.PurgeCrontabLeases,
+	}, nil
+}
+
+func newSQLite(connectionString string) (Store, error) {
+	db, err := sqlite.Open(connectionString)
+	if err != nil {
+		return Store{}, err
+	}
+	jm := sqlite.NewJobManager(db)
+	sm := sqlite.NewScheduleManager(db)
+	mm := sqlite.NewMigrationManager(db)
+	return Store{
+		Backend:                   SQLite,
+		DB:                        db,
+		UpdateSchema:              mm.UpdateSchema,
+		Migrator:                  mm,
+		JobStarter:                jm.StartJob,
+		IdempotentJobStarter:      jm.StartJobIdempotently,
+		JobsStarter:               jm.StartJobs,
+		JobGetter:                 jm.GetJob,
+		JobsGetter:                jm.GetJobs,
+		JobLeaseRenewer:           jm.RenewJobLease,
+		JobCompleter:              jm.CompleteJob,
+		JobsCompleter:             jm.CompleteJobs,
+		JobRetrier:                jm.RetryJob,
+		JobDeadLetterer:           jm.DeadLetterJob,
+		JobAttemptsGetter:         jm.GetJobAttempts,
+		JobDuplicatesGetter:       jm.GetJobDuplicates,
+		JobAndResponseByIDGetter:  jm.GetJobResponse,
+		JobsLister:                jm.ListJobs,
+		JobDeleter:                jm.DeleteJob,
+		AvailableJobCounter:       jm.GetAvailableJobCount,
+		DeadLettersGetter:         jm.GetDeadLetters,
+		DeadLetterRequeuer:        jm.RequeueDeadLetters,
+		DeadLetterForwardRecorder: jm.RecordDeadLetterForward,
+		JobsPurger:                jm.PurgeJobs,
+		CompletedJobsGetter:       jm.GetCompletedJobs,
+		CompletedJobsDeleter:      jm.DeleteCompletedJobs,
+		JobLeasesPurger:           jm.PurgeJobLeases,
+		IdempotencyKeysPurger:     jm.PurgeIdempotencyKeys,
+		DedupeKeysPurger:          jm.PurgeDedupeKeys,
+		ScheduleCreator:           sm.Create,
+		IdempotentScheduleCreator: sm.CreateIdempotently,
+		ScheduleUpdater:           sm.Update,
+		ScheduleByIDGetter:        sm.GetScheduleByID,
+		SchedulesLister:           sm.ListSchedules,
+		ScheduleDeactivator:       sm.Deactivate,
+		SchedulePauser:            sm.Pause,
+		ScheduleResumer:           sm.Resume,
+		ScheduleGetter:            sm.GetSchedule,
+		ScheduledJobStarter:       sm.StartJobAndUpdateCron,
+		CrontabSkipper:            sm.SkipCrontab,
+		CrontabLeasesPurger:       sm.PurgeCrontabLeases,
+	}, nil
+}
+
+// newMemory creates a Store with a new, empty in-memory database. Each call returns a separate database.
+func newMemory() Store {
+	db := memory.NewDatabase()
+	jm := memory.NewJobManager(db)
+	sm := memory.NewScheduleManager(db)
+	return Store{
+		Backend:                   Memory,
+		UpdateSchema:              db.UpdateSchema,
+		JobStarter:                jm.StartJob,
+		IdempotentJobStarter:      jm.StartJobIdempotently,
+		JobsStarter:               jm.StartJobs,
+		JobGetter:                 jm.GetJob,
+		JobsGetter:                jm.GetJobs,
+		JobLeaseRenewer:           jm.RenewJobLease,
+		JobCompleter:              jm.CompleteJob,
+		JobsCompleter:             jm.CompleteJobs,
+		JobRetrier:                jm.RetryJob,
+		JobDeadLetterer:           jm.DeadLetterJob,
+		JobAttemptsGetter:         jm.GetJobAttempts,
+		JobDuplicatesGetter:       jm.GetJobDuplicates,
+		JobAndResponseByIDGetter:  jm.GetJobResponse,
+		JobsLister:                jm.ListJobs,
+		JobDeleter:                jm.DeleteJob,
+		AvailableJobCounter:       jm.GetAvailableJobCount,
+		DeadLettersGetter:         jm.GetDeadLetters,
+		DeadLetterRequeuer:        jm.RequeueDeadLetters,
+		DeadLetterForwardRecorder: jm.RecordDeadLetterForward,
+		JobsPurger:                jm.PurgeJobs,
+		CompletedJobsGetter:       jm.GetCompletedJobs,
+		CompletedJobsDeleter:      jm.DeleteCompletedJobs,
+		JobLeasesPurger:           jm.PurgeJobLeases,
+		IdempotencyKeysPurger:     jm.PurgeIdempotencyKeys,
+		DedupeKeysPurger:          jm.PurgeDedupeKeys,
+		ScheduleCreator:           sm.Create,
+		IdempotentScheduleCreator: sm.CreateIdempotently,
+		ScheduleUpdater:           sm.Update,
+		ScheduleByIDGetter:        sm.GetScheduleByID,
+		SchedulesLister:           sm.ListSchedules,
+		ScheduleDeactivator:       sm.Deactivate,
+		SchedulePauser:            sm.Pause,
+		ScheduleResumer:           sm.Resume,
+		ScheduleGetter:            sm.GetSchedule,
+		ScheduledJobStarter:       sm.StartJobAndUpdateCron,
+		CrontabSkipper:            sm.SkipCrontab,
+		CrontabLeasesPurger:       sm.PurgeCrontabLeases,
+	}
+}
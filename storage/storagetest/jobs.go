@@ -0,0 +1,941 @@
+package storagetest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/storage"
+)
+
+func testJobManager(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(-5 * time.Second).Truncate(time.Second)
+
+	job1 := data.Job{
+		JobID:   1,
+		ARN:     "testarn",
+		When:    when,
+		Payload: "testpayload",
+		HTTPRequest: &data.HTTPRequest{
+			Method:      "PUT",
+			Headers:     map[string]string{"Authorization": "Bearer token"},
+			ContentType: "text/plain",
+		},
+		RetryPolicy: &data.RetryPolicy{
+			MaxAttempts:            3,
+			InitialIntervalSeconds: 30,
+		},
+		ScheduleID: nil,
+	}
+
+	// Start job without a schedule.
+	actualJob1, _, err := s.JobStarter(job1.When, job1.ARN, job1.Payload, job1.HTTPRequest, job1.RetryPolicy, job1.ScheduleID, "", 0)
+	if err != nil {
+		t.Fatalf("without schedule: error starting job: %v", err)
+	}
+	assertJob(t, "without schedule", job1, actualJob1)
+
+	// Start job with valid schedule.
+	// Create a schedule.
+	scheduleID, err := s.ScheduleCreator(time.Now().UTC(), "testarn", `{ nonsense: "payload" }`, nil, nil, []string{"* * * *"}, "", time.Time{}, 0, "", "externalid", "jobmanager_test")
+	if err != nil {
+		t.Fatalf("failed to create schedule with error: %v", err)
+	}
+
+	job2 := data.Job{
+		JobID:      2,
+		ARN:        "testarn2",
+		When:       when,
+		Payload:    "testpayload2",
+		ScheduleID: &scheduleID,
+	}
+
+	actualJob2, _, err := s.JobStarter(job2.When, job2.ARN, job2.Payload, job2.HTTPRequest, job2.RetryPolicy, job2.ScheduleID, "", 0)
+	if err != nil {
+		t.Fatalf("with schedule: error starting job: %v", err)
+	}
+	assertJob(t, "with schedule", job2, actualJob2)
+
+	// Attempt to start a job with invalid schedule.
+	invalidSchedule := int64(-1)
+	_, _, err = s.JobStarter(when, "testarn", "testpayload", nil, nil, &invalidSchedule, "", 0)
+	if err == nil {
+		t.Errorf("invalid schedule: expected error, because it's not possible to start a job associated with an invalid schedule ID")
+	}
+
+	// Start a job in the future.
+	_, _, err = s.JobStarter(time.Now().Add(time.Hour*24*365), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Errorf("in the future: got error starting job in the future: %v", err)
+	}
+
+	// Check that only two jobs are available, one is happening next year.
+	jobCount, err := s.AvailableJobCounter()
+	if err != nil {
+		t.Errorf("failed to get available job count with error: %v", err)
+	}
+	if jobCount != 2 {
+		t.Errorf("expected two jobs to be available, but got %v", jobCount)
+	}
+
+	// Pull a job from the database.
+	leasedJob1, job1OK, err := s.JobGetter("jobmanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("error getting job1: %v", err)
+	}
+	if !job1OK {
+		t.Fatalf("expected to get a job, but didn't")
+	}
+	assertJob(t, "get job 1", job1, leasedJob1.Job)
+
+	// Complete the job.
+	_, err = s.JobCompleter(job1.JobID, leasedJob1.JobLeaseID, "response", errors.New("just a test"))
+	if err != nil {
+		t.Errorf("got an error completing the job: %v", err)
+	}
+
+	// Check that only one job is now available.
+	jobCount, err = s.AvailableJobCounter()
+	if err != nil {
+		t.Errorf("failed to get available job count with error: %v", err)
+	}
+	if jobCount != 1 {
+		t.Errorf("expected one job to be available, but got %v", jobCount)
+	}
+
+	// Pull the second job.
+	leasedJob2, job2OK, err := s.JobGetter("jobmanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("error getting job (after completion): %v", err)
+	}
+	if !job2OK {
+		t.Errorf("job 2 should be available, but no job was retrieved")
+	}
+	assertJob(t, "get job 2", job2, leasedJob2.Job)
+
+	// Retry the second job, it should be released and become available again at the retry time.
+	retryAt := time.Now().UTC().Add(-1 * time.Second).Truncate(time.Second)
+	_, err = s.JobRetrier(job2.JobID, leasedJob2.JobLeaseID, "retry response", errors.New("retry error"), retryAt)
+	if err != nil {
+		t.Fatalf("error retrying job 2: %v", err)
+	}
+	leasedJob2, job2OK, err = s.JobGetter("jobmanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("error getting job 2 (after retry): %v", err)
+	}
+	if !job2OK {
+		t.Fatalf("job 2 should be available after being retried, but no job was retrieved")
+	}
+	if leasedJob2.Job.JobID != job2.JobID {
+		t.Errorf("expected job 2 to be retrieved after retry, but got job %v", leasedJob2.Job.JobID)
+	}
+	if !leasedJob2.Job.When.Equal(retryAt) {
+		t.Errorf("expected job 2 to be rescheduled to %v, but was %v", retryAt, leasedJob2.Job.When)
+	}
+	if leasedJob2.Job.AttemptCount != 1 {
+		t.Errorf("expected job 2 to have 1 attempt, but got %v", leasedJob2.Job.AttemptCount)
+	}
+	_, err = s.JobCompleter(job2.JobID, leasedJob2.JobLeaseID, "response", nil)
+	if err != nil {
+		t.Errorf("got an error completing job 2: %v", err)
+	}
+	attempts, err := s.JobAttemptsGetter(job2.JobID)
+	if err != nil {
+		t.Fatalf("error getting job 2 attempts: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("expected job 2 to have 2 attempts, but got %v", len(attempts))
+	}
+	if attempts[0].Attempt != 1 || !attempts[0].IsError || attempts[0].Error != "retry error" || attempts[0].Response != "retry response" || !attempts[0].RetryAt.Equal(retryAt) {
+		t.Errorf("unexpected first attempt for job 2: %+v", attempts[0])
+	}
+	if attempts[1].Attempt != 2 || attempts[1].IsError || attempts[1].Response != "response" || !attempts[1].RetryAt.IsZero() {
+		t.Errorf("unexpected second attempt for job 2: %+v", attempts[1])
+	}
+
+	// Check that it's possible to get the job response for ID 1, but not 2.
+	j1, r, jOK, rOK, err := s.JobAndResponseByIDGetter(1)
+	if err != nil {
+		t.Errorf("failed to get job repsonse 1 with error: %v", err)
+	}
+	if !jOK {
+		t.Errorf("failed to get job 1 from database without throwing an error")
+	}
+	if !rOK {
+		t.Errorf("failed to get response 1 from database without throwing an error")
+	}
+	// Test that the job is correct.
+	assertJob(t, "get job response 1", job1, j1)
+	if !r.IsError {
+		t.Errorf("for job response 1, expected IsError=true, but was false")
+	}
+	if r.Error != "just a test" {
+		t.Errorf("expected error message: 'just a test', but got '%v'", r.Error)
+	}
+	if r.JobID != 1 {
+		t.Errorf("expected JobID=1, but got %v", r.JobID)
+	}
+	if r.JobResponseID != 1 {
+		t.Errorf("expected JobResponseID=1, but got %v", r.JobResponseID)
+	}
+
+	// Create a job, then delete it.
+	job3, _, err := s.JobStarter(time.Now().UTC().Add(time.Hour-24), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Errorf("expected to be able to start job3, but got err: %v", err)
+	}
+	ok, err := s.JobDeleter(job3.JobID)
+	if err != nil {
+		t.Errorf("expected to be able to delete job3, but got err: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected to be able to delete job3, but was unable to")
+	}
+
+	// Create a job, start it, then check we're unable to delete it.
+	job4, _, err := s.JobStarter(time.Now().UTC().Add(time.Hour*-24), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Errorf("expected to be able to start job4, but got err: %v", err)
+	}
+	leasedJob4, ok, err := s.JobGetter("jobmanagertest_lock", 60)
+	if err != nil {
+		t.Errorf("expected to be able to get job4, but got err: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected to be able to lock job4, but was unable to")
+	}
+	_, err = s.JobCompleter(job4.JobID, leasedJob4.JobLeaseID, "testresp", nil)
+	if err != nil {
+		t.Errorf("expected to be able to complete job4, but got err: %v", err)
+	}
+	ok, err = s.JobDeleter(job4.JobID)
+	if err != nil {
+		t.Errorf("expected to be able to delete job4 with an error, but got err: %v", err)
+	}
+	if ok {
+		t.Errorf("expected to be unable to delete job4 (because it's complete), but was able to")
+	}
+
+	// Create a job, start it, then mark it as dead.
+	job5, _, err := s.JobStarter(time.Now().UTC().Add(time.Hour*-24), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Errorf("expected to be able to start job5, but got err: %v", err)
+	}
+	leasedJob5, ok, err := s.JobGetter("jobmanagertest_lock", 60)
+	if err != nil || !ok {
+		t.Fatalf("expected to be able to lock job5, but got ok: %v, err: %v", ok, err)
+	}
+	deadLetterID, _, err := s.JobDeadLetterer(leasedJob5.Job, leasedJob5.JobLeaseID, "testresp", errors.New("dead"))
+	if err != nil {
+		t.Fatalf("expected to be able to mark job5 as dead, but got err: %v", err)
+	}
+	err = s.DeadLetterForwardRecorder(deadLetterID, "https://example.com/dead", errors.New("forward failed"))
+	if err != nil {
+		t.Errorf("expected to be able to record the dead letter forward, but got err: %v", err)
+	}
+	dls, err := s.DeadLettersGetter(false, 0, 10)
+	if err != nil {
+		t.Fatalf("expected to be able to get dead letters, but got err: %v", err)
+	}
+	if len(dls) != 1 {
+		t.Fatalf("expected 1 dead letter, but got %v", len(dls))
+	}
+	if dls[0].DeadLetterID != deadLetterID || dls[0].Job.JobID != job5.JobID || dls[0].Error != "dead" ||
+		dls[0].ForwardedTo != "https://example.com/dead" || dls[0].ForwardError != "forward failed" || dls[0].RequeuedJobID != nil {
+		t.Errorf("unexpected dead letter: %+v", dls[0])
+	}
+	_, _, _, responseOK, err := s.JobAndResponseByIDGetter(job5.JobID)
+	if err != nil || !responseOK {
+		t.Errorf("expected job5 to be complete, but got ok: %v, err: %v", responseOK, err)
+	}
+
+	// Requeue the dead letter, only the first request should create a job.
+	jobIDs, err := s.DeadLetterRequeuer([]int64{deadLetterID, deadLetterID})
+	if err != nil {
+		t.Fatalf("expected to be able to requeue dead letters, but got err: %v", err)
+	}
+	if len(jobIDs) != 1 || jobIDs[deadLetterID] == 0 {
+		t.Fatalf("expected the dead letter to be requeued once, but got %v", jobIDs)
+	}
+	requeued, ok, err := s.JobGetter("jobmanagertest_lock", 60)
+	if err != nil || !ok {
+		t.Fatalf("expected to be able to lock the requeued job, but got ok: %v, err: %v", ok, err)
+	}
+	if requeued.Job.JobID != jobIDs[deadLetterID] || requeued.Job.Payload != job5.Payload || requeued.Job.AttemptCount != 0 {
+		t.Errorf("unexpected requeued job: %+v", requeued)
+	}
+	dls, err = s.DeadLettersGetter(true, 0, 10)
+	if err != nil {
+		t.Fatalf("expected to be able to get requeued dead letters, but got err: %v", err)
+	}
+	if len(dls) != 1 || dls[0].RequeuedJobID == nil || *dls[0].RequeuedJobID != requeued.Job.JobID {
+		t.Errorf("expected the dead letter to be marked as requeued, but got %+v", dls)
+	}
+}
+
+func testJobManagerLeasesEachJobOnce(t *testing.T, s storage.Store) {
+	const jobCount = 100
+	for i := 0; i < jobCount; i++ {
+		if _, _, err := s.JobStarter(time.Now().UTC().Add(-time.Second), "testarn", "testpayload", nil, nil, nil, "", 0); err != nil {
+			t.Fatalf("failed to start job: %v", err)
+		}
+	}
+
+	var m sync.Mutex
+	leased := make(map[int64]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				j, ok, err := s.JobGetter("jobmanager_test", lockExpiryMins)
+				if err != nil {
+					t.Errorf("failed to get job: %v", err)
+					return
+				}
+				if !ok {
+					return
+				}
+				m.Lock()
+				leased[j.Job.JobID]++
+				m.Unlock()
+			}
+		}()
+		// Lease some of the jobs in batches at the same time.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				jobs, err := s.JobsGetter("jobmanager_test", lockExpiryMins, 7)
+				if err != nil {
+					t.Errorf("failed to get jobs: %v", err)
+					return
+				}
+				if len(jobs) == 0 {
+					return
+				}
+				m.Lock()
+				for _, j := range jobs {
+					leased[j.Job.JobID]++
+				}
+				m.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(leased) != jobCount {
+		t.Errorf("expected %v jobs to be leased, but got %v", jobCount, len(leased))
+	}
+	for id, count := range leased {
+		if count != 1 {
+			t.Errorf("expected job %v to be leased once, but was leased %v times", id, count)
+		}
+	}
+}
+
+func testJobManagerRenewsLeases(t *testing.T, s storage.Store) {
+	j, _, err := s.JobStarter(time.Now().UTC().Add(-time.Minute), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Fatalf("failed to start job: %v", err)
+	}
+	leaseA, ok, err := s.JobGetter("worker_a", lockExpiryMins)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease the job, got ok=%v, err=%v", ok, err)
+	}
+
+	renew := func(step string, jobLeaseID int64, expected bool) {
+		ok, err := s.JobLeaseRenewer(j.JobID, jobLeaseID, lockExpiryMins)
+		if err != nil {
+			t.Fatalf("%s: failed to renew lease: %v", step, err)
+		}
+		if ok != expected {
+			t.Errorf("%s: expected renewal of lease %v to return %v, got %v", step, jobLeaseID, expected, ok)
+		}
+	}
+	renew("holder", leaseA.JobLeaseID, true)
+	renew("unknown lease", leaseA.JobLeaseID+1000, false)
+
+	// Releasing the lease to retry the job means that it can't be renewed.
+	if _, err = s.JobRetrier(j.JobID, leaseA.JobLeaseID, "", errors.New("failed"), time.Now().UTC().Add(-time.Second)); err != nil {
+		t.Fatalf("failed to retry job: %v", err)
+	}
+	renew("released", leaseA.JobLeaseID, false)
+
+	// Once another worker has taken over the job, only the new lease can be renewed.
+	leaseB, ok, err := s.JobGetter("worker_b", lockExpiryMins)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease the job again, got ok=%v, err=%v", ok, err)
+	}
+	renew("taken over", leaseA.JobLeaseID, false)
+	renew("new holder", leaseB.JobLeaseID, true)
+
+	if ok, err := s.JobLeaseRenewer(j.JobID+1000, leaseB.JobLeaseID, lockExpiryMins); err != nil || ok {
+		t.Errorf("expected a job which doesn't exist not to be renewed, got ok=%v, err=%v", ok, err)
+	}
+}
+
+func testJobManagerRecordsDuplicates(t *testing.T, s storage.Store) {
+	j, _, err := s.JobStarter(time.Now().UTC().Add(-time.Minute), "testarn", "testpayload", nil, nil, nil, "", 0)
+	if err != nil {
+		t.Fatalf("failed to start job: %v", err)
+	}
+	// Lease the job for no time at all, so that another worker can lease it while the first is still executing it.
+	stale, ok, err := s.JobGetter("worker_a", 0)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease the job, got ok=%v, err=%v", ok, err)
+	}
+	// MySQL's utc_timestamp() has second precision, so wait for the lease to expire.
+	time.Sleep(time.Second)
+	current, ok, err := s.JobGetter("worker_b", lockExpiryMins)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease the job again, got ok=%v, err=%v", ok, err)
+	}
+	if current.JobLeaseID == stale.JobLeaseID {
+		t.Fatalf("expected the job to have a new lease, but both leases were %v", current.JobLeaseID)
+	}
+
+	// The first worker's outcomes are recorded as duplicates, and leave the job alone.
+	duplicate, err := s.JobRetrier(j.JobID, stale.JobLeaseID, "retry", errors.New("retry"), time.Now().UTC().Add(time.Hour))
+	if err != nil || !duplicate {
+		t.Errorf("stale retry: expected a duplicate, got duplicate=%v, err=%v", duplicate, err)
+	}
+	deadLetterID, duplicate, err := s.JobDeadLetterer(stale.Job, stale.JobLeaseID, "dead", errors.New("dead"))
+	if err != nil || !duplicate || deadLetterID != 0 {
+		t.Errorf("stale dead letter: expected a duplicate, got deadLetterID=%v, duplicate=%v, err=%v", deadLetterID, duplicate, err)
+	}
+	duplicate, err = s.JobCompleter(j.JobID, stale.JobLeaseID, "stale", nil)
+	if err != nil || !duplicate {
+		t.Errorf("stale completion: expected a duplicate, got duplicate=%v, err=%v", duplicate, err)
+	}
+	if _, _, _, responseOK, err := s.JobAndResponseByIDGetter(j.JobID); err != nil || responseOK {
+		t.Errorf("expected the job not to have been completed, got responseOK=%v, err=%v", responseOK, err)
+	}
+	if ok, err := s.JobLeaseRenewer(j.JobID, current.JobLeaseID, lockExpiryMins); err != nil || !ok {
+		t.Errorf("expected the current lease to be kept, got ok=%v, err=%v", ok, err)
+	}
+
+	// The current lease completes the job, after which any completion is a duplicate, even with the same lease.
+	duplicate, err = s.JobCompleter(j.JobID, current.JobLeaseID, "current", nil)
+	if err != nil || duplicate {
+		t.Errorf("current completion: expected the job to be completed, got duplicate=%v, err=%v", duplicate, err)
+	}
+	duplicate, err = s.JobCompleter(j.JobID, current.JobLeaseID, "again", nil)
+	if err != nil || !duplicate {
+		t.Errorf("second completion: expected a duplicate, got duplicate=%v, err=%v", duplicate, err)
+	}
+	duplicateJobIDs, err := s.JobsCompleter([]data.JobCompletion{{JobID: j.JobID, JobLeaseID: current.JobLeaseID, Response: "batch"}})
+	if err != nil || !reflect.DeepEqual(duplicateJobIDs, []int64{j.JobID}) {
+		t.Errorf("batch completion: expected a duplicate, got %v, err=%v", duplicateJobIDs, err)
+	}
+
+	_, r, _, _, err := s.JobAndResponseByIDGetter(j.JobID)
+	if err != nil || r.Response != "current" {
+		t.Errorf("expected the job to be completed by the current lease, got %+v, err=%v", r, err)
+	}
+	attempts, err := s.JobAttemptsGetter(j.JobID)
+	if err != nil || len(attempts) != 1 {
+		t.Errorf("expected duplicates not to be recorded as attempts, got %+v, err=%v", attempts, err)
+	}
+	duplicates, err := s.JobDuplicatesGetter(j.JobID)
+	if err != nil {
+		t.Fatalf("failed to get duplicates: %v", err)
+	}
+	var responses []string
+	for _, d := range duplicates {
+		responses = append(responses, d.Response)
+	}
+	if !reflect.DeepEqual(responses, []string{"retry", "dead", "stale", "again", "batch"}) {
+		t.Errorf("expected each duplicate to be recorded in order, got %v", responses)
+	}
+	if len(duplicates) > 0 && (duplicates[0].JobID != j.JobID || duplicates[0].JobLeaseID != stale.JobLeaseID || !duplicates[0].IsError || duplicates[0].Error != "retry") {
+		t.Errorf("unexpected duplicate: %+v", duplicates[0])
+	}
+}
+
+func testJobManagerListsJobs(t *testing.T, s storage.Store) {
+	scheduleID, err := s.ScheduleCreator(time.Now().UTC(), "testarn", "testpayload", nil, nil, []string{"* * * *"}, "", time.Time{}, 0, "", "externalid", "jobmanager_test")
+	if err != nil {
+		t.Fatalf("failed to create schedule: %v", err)
+	}
+	now := time.Now().UTC()
+	start := func(when time.Time, arn string, scheduleID *int64) int64 {
+		j, _, err := s.JobStarter(when, arn, "testpayload", nil, nil, scheduleID, "", 0)
+		if err != nil {
+			t.Fatalf("failed to start job: %v", err)
+		}
+		return j.JobID
+	}
+	succeeded := start(now.Add(-time.Hour*3), "https://a_1/", &scheduleID)
+	failed := start(now.Add(-time.Hour*2), "https://a_1/x", nil)
+	leased := start(now.Add(-time.Hour), "https://ab1/", nil)
+	pending := start(now.Add(time.Hour), "sns:topic", nil)
+
+	// Jobs are leased in the order they're due.
+	lj, _, err := s.JobGetter("jobmanager_test", lockExpiryMins)
+	if err != nil || lj.Job.JobID != succeeded {
+		t.Fatalf("expected to lease job %v, got %v, err=%v", succeeded, lj.Job.JobID, err)
+	}
+	if _, err = s.JobCompleter(succeeded, lj.JobLeaseID, "ok", nil); err != nil {
+		t.Fatalf("failed to complete job: %v", err)
+	}
+	lj, _, err = s.JobGetter("jobmanager_test", lockExpiryMins)
+	if err != nil || lj.Job.JobID != failed {
+		t.Fatalf("expected to lease job %v, got %v, err=%v", failed, lj.Job.JobID, err)
+	}
+	if _, _, err = s.JobDeadLetterer(lj.Job, lj.JobLeaseID, "", errors.New("failed")); err != nil {
+		t.Fatalf("failed to dead letter job: %v", err)
+	}
+	if lj, _, err = s.JobGetter("jobmanager_test", lockExpiryMins); err != nil || lj.Job.JobID != leased {
+		t.Fatalf("expected to lease job %v, got %v, err=%v", leased, lj.Job.JobID, err)
+	}
+
+	isError, isNotError := true, false
+	tests := []struct {
+		name     string
+		filter   data.JobFilter
+		afterID  int64
+		limit    int
+		expected []int64
+	}{
+		{name: "all", limit: 10, expected: []int64{succeeded, failed, leased, pending}},
+		{name: "schedule", filter: data.JobFilter{ScheduleID: &scheduleID}, limit: 10, expected: []int64{succeeded}},
+		{name: "pending", filter: data.JobFilter{State: data.JobStatePending}, limit: 10, expected: []int64{pending}},
+		{name: "leased", filter: data.JobFilter{State: data.JobStateLeased}, limit: 10, expected: []int64{leased}},
+		{name: "succeeded", filter: data.JobFilter{State: data.JobStateSucceeded}, limit: 10, expected: []int64{succeeded}},
+		{name: "failed", filter: data.JobFilter{State: data.JobStateFailed}, limit: 10, expected: []int64{failed}},
+		{name: "when", filter: data.JobFilter{WhenFrom: now.Add(-time.Hour * 2), WhenTo: now}, limit: 10, expected: []int64{failed, leased}},
+		{name: "ARN prefix", filter: data.JobFilter{ARNPrefix: "https://a_1"}, limit: 10, expected: []int64{succeeded, failed}},
+		{name: "error", filter: data.JobFilter{IsError: &isError}, limit: 10, expected: []int64{failed}},
+		{name: "no error", filter: data.JobFilter{IsError: &isNotError}, limit: 10, expected: []int64{succeeded, leased, pending}},
+		{name: "combined", filter: data.JobFilter{State: data.JobStatePending, ARNPrefix: "https://"}, limit: 10, expected: []int64{}},
+		{name: "first page", limit: 2, expected: []int64{succeeded, failed}},
+		{name: "second page", afterID: failed, limit: 2, expected: []int64{leased, pending}},
+		{name: "last page", afterID: pending, limit: 2, expected: []int64{}},
+	}
+	for _, test := range tests {
+		jobs, err := s.JobsLister(test.filter, test.afterID, test.limit)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		actual := make([]int64, len(jobs))
+		for i, js := range jobs {
+			actual[i] = js.Job.JobID
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected jobs %v, got %v", test.name, test.expected, actual)
+		}
+	}
+
+	jobs, err := s.JobsLister(data.JobFilter{}, 0, 10)
+	if err != nil || len(jobs) != 4 {
+		t.Fatalf("expected to list 4 jobs, got %v, err=%v", len(jobs), err)
+	}
+	for i, state := range []string{data.JobStateSucceeded, data.JobStateFailed, data.JobStateLeased, data.JobStatePending} {
+		if jobs[i].State != state {
+			t.Errorf("expected job %v to be %v, got %v", jobs[i].Job.JobID, state, jobs[i].State)
+		}
+	}
+	if jobs[0].Job.ScheduleID == nil || *jobs[0].Job.ScheduleID != scheduleID || jobs[0].Job.ARN != "https://a_1/" || jobs[1].Job.AttemptCount != 1 {
+		t.Errorf("unexpected jobs: %+v, %+v", jobs[0].Job, jobs[1].Job)
+	}
+}
+
+func testJobManagerBatches(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(-time.Minute)
+	var started []int64
+	for i := 0; i < 5; i++ {
+		j, _, err := s.JobStarter(when.Add(time.Duration(i)*time.Second), "testarn", "testpayload", nil, nil, nil, "", 0)
+		if err != nil {
+			t.Fatalf("failed to start job: %v", err)
+		}
+		started = append(started, j.JobID)
+	}
+
+	// Lease the jobs in two batches, in the order they're due.
+	first, err := s.JobsGetter("jobmanager_test", lockExpiryMins, 3)
+	if err != nil {
+		t.Fatalf("failed to get jobs: %v", err)
+	}
+	if len(first) != 3 || first[0].Job.JobID != started[0] || first[2].Job.JobID != started[2] {
+		t.Fatalf("expected the first 3 jobs to be leased, got %+v", first)
+	}
+	second, err := s.JobsGetter("jobmanager_test", lockExpiryMins, 3)
+	if err != nil {
+		t.Fatalf("failed to get jobs: %v", err)
+	}
+	if len(second) != 2 || second[0].Job.JobID != started[3] || second[1].Job.JobID != started[4] {
+		t.Fatalf("expected the remaining 2 jobs to be leased, got %+v", second)
+	}
+	none, err := s.JobsGetter("jobmanager_test", lockExpiryMins, 3)
+	if err != nil || len(none) != 0 {
+		t.Fatalf("expected no jobs to be available, got %v jobs, err=%v", len(none), err)
+	}
+
+	// Complete the first batch together.
+	completions := []data.JobCompletion{
+		{JobID: first[0].Job.JobID, JobLeaseID: first[0].JobLeaseID, Response: "ok"},
+		{JobID: first[1].Job.JobID, JobLeaseID: first[1].JobLeaseID, Response: "ok"},
+		{JobID: first[2].Job.JobID, JobLeaseID: first[2].JobLeaseID, Response: "failed", Err: errors.New("failed")},
+	}
+	if _, err = s.JobsCompleter(completions); err != nil {
+		t.Fatalf("failed to complete jobs: %v", err)
+	}
+	for _, c := range completions {
+		_, r, _, responseOK, err := s.JobAndResponseByIDGetter(c.JobID)
+		if err != nil || !responseOK {
+			t.Fatalf("expected job %v to have a response, got ok=%v, err=%v", c.JobID, responseOK, err)
+		}
+		if r.Response != c.Response || r.IsError != (c.Err != nil) {
+			t.Errorf("job %v: expected response '%v' with isError=%v, got %+v", c.JobID, c.Response, c.Err != nil, r)
+		}
+		attempts, err := s.JobAttemptsGetter(c.JobID)
+		if err != nil || len(attempts) != 1 || attempts[0].Attempt != 1 {
+			t.Errorf("job %v: expected a single attempt, got %+v, err=%v", c.JobID, attempts, err)
+		}
+	}
+}
+
+func testJobManagerStartsJobsIdempotently(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(time.Hour)
+	key := data.IdempotencyKey{Key: "key_a", RequestHash: "hash_a"}
+	first, existing, _, err := s.IdempotentJobStarter(key, when, "testarn", "testpayload", nil, nil, "", 0)
+	if err != nil || existing {
+		t.Fatalf("expected a new job, got existing=%v, err=%v", existing, err)
+	}
+
+	// Repeating the request returns the first job.
+	again, existing, _, err := s.IdempotentJobStarter(key, when, "testarn", "testpayload", nil, nil, "", 0)
+	if err != nil || !existing || again.JobID != first.JobID || again.Payload != "testpayload" {
+		t.Errorf("expected job %v to be returned, got %+v, existing=%v, err=%v", first.JobID, again, existing, err)
+	}
+
+	// Reusing the key for a different request is a conflict.
+	changed := data.IdempotencyKey{Key: "key_a", RequestHash: "hash_b"}
+	if _, _, _, err = s.IdempotentJobStarter(changed, when, "testarn", "otherpayload", nil, nil, "", 0); err != data.ErrIdempotencyKeyConflict {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+
+	// Keys are scoped to the caller.
+	other, existing, _, err := s.IdempotentJobStarter(data.IdempotencyKey{Key: "key_a", Caller: "caller_b", RequestHash: "hash_b"}, when, "testarn", "otherpayload", nil, nil, "", 0)
+	if err != nil || existing || other.JobID == first.JobID {
+		t.Errorf("expected a new job for a different caller, got job %v, existing=%v, err=%v", other.JobID, existing, err)
+	}
+
+	// Once the keys are purged, the key can be used again.
+	purged, err := s.IdempotencyKeysPurger(time.Now().UTC().Add(time.Minute), 10)
+	if err != nil || purged != 2 {
+		t.Fatalf("expected 2 keys to be purged, got %v, err=%v", purged, err)
+	}
+	reused, existing, _, err := s.IdempotentJobStarter(changed, when, "testarn", "otherpayload", nil, nil, "", 0)
+	if err != nil || existing || reused.JobID == first.JobID || reused.JobID == other.JobID {
+		t.Errorf("expected a new job once the key was purged, got job %v, existing=%v, err=%v", reused.JobID, existing, err)
+	}
+}
+
+func testJobManagerDedupesJobs(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(time.Hour)
+	first, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_1", time.Hour)
+	if err != nil || duplicate || first.DedupeKey != "order_1" {
+		t.Fatalf("expected a new job with the dedupe key, got %+v, duplicate=%v, err=%v", first, duplicate, err)
+	}
+
+	// Starting the job again returns the first job.
+	again, duplicate, err := s.JobStarter(when, "testarn", "otherpayload", nil, nil, nil, "order_1", time.Hour)
+	if err != nil || !duplicate || again.JobID != first.JobID || again.Payload != "testpayload" || again.DedupeKey != "order_1" {
+		t.Errorf("expected job %v to be returned as a duplicate, got %+v, duplicate=%v, err=%v", first.JobID, again, duplicate, err)
+	}
+	j, _, _, _, err := s.JobAndResponseByIDGetter(first.JobID)
+	if err != nil || j.DedupeKey != "order_1" {
+		t.Errorf("expected the job to have its dedupe key, got '%v', err=%v", j.DedupeKey, err)
+	}
+	_, existing, duplicate, err := s.IdempotentJobStarter(data.IdempotencyKey{Key: "key_a", RequestHash: "hash_a"}, when, "testarn", "testpayload", nil, nil, "order_1", time.Hour)
+	if err != nil || existing || !duplicate {
+		t.Errorf("expected an idempotent request to return a duplicate, got existing=%v, duplicate=%v, err=%v", existing, duplicate, err)
+	}
+
+	// Other keys, and jobs without a key, aren't duplicates.
+	other, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_2", time.Hour)
+	if err != nil || duplicate || other.JobID == first.JobID {
+		t.Errorf("expected a new job for a different key, got job %v, duplicate=%v, err=%v", other.JobID, duplicate, err)
+	}
+	if _, duplicate, err = s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "", time.Hour); err != nil || duplicate {
+		t.Errorf("expected a new job without a key, got duplicate=%v, err=%v", duplicate, err)
+	}
+
+	// Once the window has ended, the key can be used again.
+	expired, _, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_3", 0)
+	if err != nil {
+		t.Fatalf("failed to start job: %v", err)
+	}
+	reused, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_3", 0)
+	if err != nil || duplicate || reused.JobID == expired.JobID {
+		t.Errorf("expected a new job once the window ended, got job %v, duplicate=%v, err=%v", reused.JobID, duplicate, err)
+	}
+
+	// Deleting a job releases its key.
+	if ok, err := s.JobDeleter(other.JobID); err != nil || !ok {
+		t.Fatalf("failed to delete job, ok=%v, err=%v", ok, err)
+	}
+	restarted, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_2", time.Hour)
+	if err != nil || duplicate || restarted.JobID == other.JobID {
+		t.Errorf("expected a new job once the job was deleted, got job %v, duplicate=%v, err=%v", restarted.JobID, duplicate, err)
+	}
+
+	// Completing a job releases its key.
+	due, _, err := s.JobStarter(time.Now().UTC().Add(-time.Second), "testarn", "testpayload", nil, nil, nil, "order_4", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to start job: %v", err)
+	}
+	lj, ok, err := s.JobGetter("testnode", 1)
+	if err != nil || !ok || lj.Job.JobID != due.JobID {
+		t.Fatalf("expected to lease job %v, got %+v, ok=%v, err=%v", due.JobID, lj, ok, err)
+	}
+	if _, duplicate, err = s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_4", time.Hour); err != nil || !duplicate {
+		t.Errorf("expected a leased job to hold its key, got duplicate=%v, err=%v", duplicate, err)
+	}
+	if _, err = s.JobCompleter(lj.Job.JobID, lj.JobLeaseID, "ok", nil); err != nil {
+		t.Fatalf("failed to complete job: %v", err)
+	}
+	next, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_4", time.Hour)
+	if err != nil || duplicate || next.JobID == due.JobID {
+		t.Errorf("expected a new job once the job completed, got job %v, duplicate=%v, err=%v", next.JobID, duplicate, err)
+	}
+
+	// Only the key whose window has ended is purged.
+	purged, err := s.DedupeKeysPurger(time.Now().UTC().Add(time.Minute), 10)
+	if err != nil || purged != 1 {
+		t.Errorf("expected 1 key to be purged, got %v, err=%v", purged, err)
+	}
+}
+
+func testJobManagerDedupesConcurrentJobs(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(time.Hour)
+
+	// Start the same dedupe key concurrently, with single jobs, batches and idempotent requests.
+	var m sync.Mutex
+	jobIDs := make(map[int64]bool)
+	var started int
+	var wg sync.WaitGroup
+	record := func(jobID int64, duplicate bool) {
+		m.Lock()
+		defer m.Unlock()
+		jobIDs[jobID] = true
+		if !duplicate {
+			started++
+		}
+	}
+	for i := 0; i < 5; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			j, duplicate, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "order_1", time.Hour)
+			if err != nil {
+				t.Errorf("failed to start job: %v", err)
+				return
+			}
+			record(j.JobID, duplicate)
+		}()
+		go func() {
+			defer wg.Done()
+			batch, err := s.JobsStarter([]data.Job{
+				{When: when, ARN: "testarn", Payload: "testpayload"},
+				{When: when, ARN: "testarn", Payload: "testpayload", DedupeKey: "order_1"},
+			}, time.Hour)
+			if err != nil {
+				t.Errorf("failed to start batch: %v", err)
+				return
+			}
+			record(batch[1].Job.JobID, batch[1].Duplicate)
+		}()
+		key := data.IdempotencyKey{Key: fmt.Sprintf("key_%d", i), RequestHash: "hash"}
+		go func() {
+			defer wg.Done()
+			j, _, duplicate, err := s.IdempotentJobStarter(key, when, "testarn", "testpayload", nil, nil, "order_1", time.Hour)
+			if err != nil {
+				t.Errorf("failed to start job idempotently: %v", err)
+				return
+			}
+			record(j.JobID, duplicate)
+		}()
+	}
+	wg.Wait()
+
+	if started != 1 {
+		t.Errorf("expected the dedupe key to start one job, but %v were started", started)
+	}
+	if len(jobIDs) != 1 {
+		t.Errorf("expected every request to return the same job, but got jobs %v", jobIDs)
+	}
+}
+
+func testJobManagerStartsJobsInBatches(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	// If any job fails, none are started.
+	missingScheduleID := int64(999999)
+	_, err := s.JobsStarter([]data.Job{
+		{When: when, ARN: "testarn", Payload: "testpayload"},
+		{When: when, ARN: "testarn", Payload: "testpayload", ScheduleID: &missingScheduleID},
+	}, time.Hour)
+	if err == nil {
+		t.Fatalf("expected an error starting a job for a schedule which doesn't exist")
+	}
+	jobs, err := s.JobsLister(data.JobFilter{}, 0, 10)
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("expected no jobs to be started, got %v, err=%v", len(jobs), err)
+	}
+
+	started, err := s.JobsStarter([]data.Job{
+		{When: when, ARN: "testarn", Payload: "payload_1"},
+		{When: when, ARN: "testarn", Payload: "payload_2", DedupeKey: "order_1"},
+		{When: when, ARN: "testarn", Payload: "payload_3", DedupeKey: "order_1"},
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to start jobs: %v", err)
+	}
+	if len(started) != 3 {
+		t.Fatalf("expected 3 started jobs, got %v", len(started))
+	}
+	if started[0].Duplicate || started[1].Duplicate || started[0].Job.JobID == started[1].Job.JobID || started[1].Job.DedupeKey != "order_1" {
+		t.Errorf("expected two new jobs, got %+v", started[:2])
+	}
+	if !started[2].Duplicate || started[2].Job.JobID != started[1].Job.JobID || started[2].Job.Payload != "payload_2" {
+		t.Errorf("expected the last job to be a duplicate of the second, got %+v", started[2])
+	}
+	for _, sj := range started[:2] {
+		j, _, ok, _, err := s.JobAndResponseByIDGetter(sj.Job.JobID)
+		if err != nil || !ok || j.Payload != sj.Job.Payload || !j.When.Equal(when) {
+			t.Errorf("expected job %v to be started, got %+v, ok=%v, err=%v", sj.Job.JobID, j, ok, err)
+		}
+	}
+
+	// Keys held by earlier jobs are respected.
+	started, err = s.JobsStarter([]data.Job{{When: when, ARN: "testarn", Payload: "payload_4", DedupeKey: "order_1"}}, time.Hour)
+	if err != nil || len(started) != 1 || !started[0].Duplicate || started[0].Job.Payload != "payload_2" {
+		t.Errorf("expected a duplicate of the earlier job, got %+v, err=%v", started, err)
+	}
+}
+
+func testJobManagerPurges(t *testing.T, s storage.Store) {
+	when := time.Now().UTC().Add(-time.Minute)
+	for i := 0; i < 4; i++ {
+		if _, _, err := s.JobStarter(when, "testarn", "testpayload", nil, nil, nil, "", 0); err != nil {
+			t.Fatalf("failed to start job: %v", err)
+		}
+	}
+
+	// Lease the jobs for no time at all, so that the leases have expired.
+	jobs, err := s.JobsGetter("jobmanager_test", 0, 4)
+	if err != nil || len(jobs) != 4 {
+		t.Fatalf("expected to lease 4 jobs, got %v jobs, err=%v", len(jobs), err)
+	}
+
+	// Complete two of the jobs, then complete the first again as a duplicate, dead letter one, and leave the last one
+	// incomplete.
+	_, err = s.JobsCompleter([]data.JobCompletion{{JobID: jobs[0].Job.JobID, JobLeaseID: jobs[0].JobLeaseID, Response: "ok"}, {JobID: jobs[1].Job.JobID, JobLeaseID: jobs[1].JobLeaseID, Response: "ok"}})
+	if err != nil {
+		t.Fatalf("failed to complete jobs: %v", err)
+	}
+	if duplicate, err := s.JobCompleter(jobs[0].Job.JobID, jobs[0].JobLeaseID, "again", nil); err != nil || !duplicate {
+		t.Fatalf("expected a duplicate completion, got duplicate=%v, err=%v", duplicate, err)
+	}
+	if _, _, err = s.JobDeadLetterer(jobs[2].Job, jobs[2].JobLeaseID, "failed", errors.New("failed")); err != nil {
+		t.Fatalf("failed to dead letter job: %v", err)
+	}
+
+	completed, err := s.CompletedJobsGetter(time.Now().UTC().Add(time.Minute), 10)
+	if err != nil || len(completed) != 2 {
+		t.Fatalf("expected the 2 completed jobs to be archivable, got %v, err=%v", len(completed), err)
+	}
+	for i, jr := range completed {
+		if jr.Job.JobID != jobs[i].Job.JobID || !jr.HasJobResponse || jr.JobResponse.Response != "ok" || len(jr.Attempts) != 1 {
+			t.Errorf("expected job %v with its response and attempt, got %+v", jobs[i].Job.JobID, jr)
+		}
+	}
+	if len(completed[0].Duplicates) != 1 || completed[0].Duplicates[0].Response != "again" {
+		t.Errorf("expected the duplicate to be archived with its job, got %+v", completed[0].Duplicates)
+	}
+	deleted, err := s.CompletedJobsDeleter([]int64{jobs[2].Job.JobID, jobs[3].Job.JobID})
+	if err != nil || deleted != 0 {
+		t.Errorf("expected the dead lettered and incomplete jobs to be kept, but deleted %v, err=%v", deleted, err)
+	}
+
+	purged, err := s.JobsPurger(when.Add(-time.Minute), 10)
+	if err != nil || purged != 0 {
+		t.Errorf("expected no jobs to have been completed before they started, but purged %v, err=%v", purged, err)
+	}
+	before := time.Now().UTC().Add(time.Minute)
+	purged, err = s.JobsPurger(before, 1)
+	if err != nil || purged != 1 {
+		t.Errorf("expected to purge a batch of 1 job, but purged %v, err=%v", purged, err)
+	}
+	purged, err = s.JobsPurger(before, 10)
+	if err != nil || purged != 1 {
+		t.Errorf("expected to purge the other completed job, and keep the dead lettered job, but purged %v, err=%v", purged, err)
+	}
+	for _, j := range jobs[:2] {
+		_, _, jobOK, _, err := s.JobAndResponseByIDGetter(j.Job.JobID)
+		if err != nil || jobOK {
+			t.Errorf("expected job %v to have been purged, but got ok=%v, err=%v", j.Job.JobID, jobOK, err)
+		}
+	}
+	_, _, jobOK, responseOK, err := s.JobAndResponseByIDGetter(jobs[2].Job.JobID)
+	if err != nil || !jobOK || !responseOK {
+		t.Errorf("expected the dead lettered job to be kept, but got jobOK=%v, responseOK=%v, err=%v", jobOK, responseOK, err)
+	}
+
+	// The purged jobs' leases were deleted with them, so only the leases of the other two jobs are left.
+	purged, err = s.JobLeasesPurger(before, 10)
+	if err != nil || purged != 2 {
+		t.Errorf("expected to purge 2 expired job leases, but purged %v, err=%v", purged, err)
+	}
+
+	// Leases which haven't expired are kept.
+	leased, err := s.JobsGetter("jobmanager_test", lockExpiryMins, 10)
+	if err != nil || len(leased) != 1 || leased[0].Job.JobID != jobs[3].Job.JobID {
+		t.Fatalf("expected the incomplete job to be leased, got %+v, err=%v", leased, err)
+	}
+	purged, err = s.JobLeasesPurger(time.Now().UTC(), 10)
+	if err != nil || purged != 0 {
+		t.Errorf("expected the current lease to be kept, but purged %v, err=%v", purged, err)
+	}
+}
+
+func assertJob(t *testing.T, testName string, expected, actual data.Job) {
+	if expected.JobID != actual.JobID {
+		t.Errorf("%v: expected JobID='%v', but was '%v'", testName, expected.JobID, actual.JobID)
+	}
+	if expected.ARN != actual.ARN {
+		t.Errorf("%v: expected ARN='%v', but was '%v'", testName, expected.ARN, actual.ARN)
+	}
+	if expected.Payload != actual.Payload {
+		t.Errorf("%v: expected Payload='%v', but was '%v'", testName, expected.Payload, actual.Payload)
+	}
+	if expected.ScheduleID == nil && actual.ScheduleID != nil {
+		t.Errorf("%v: expected ScheduleID to be nil, but was '%v'", testName, *actual.ScheduleID)
+	}
+	if expected.ScheduleID != nil && actual.ScheduleID == nil {
+		t.Errorf("%v: expected ScheduleID='%v', but was nil", testName, *expected.ScheduleID)
+	}
+	if expected.ScheduleID != nil && actual.ScheduleID != nil {
+		if *expected.ScheduleID != *actual.ScheduleID {
+			t.Errorf("%v: expected ScheduleID='%v', but was '%v'", testName, *expected.ScheduleID, *actual.ScheduleID)
+		}
+	}
+	if expected.When != actual.When {
+		t.Errorf("%v: expected When='%v', but was '%v'", testName, expected.When, actual.When)
+	}
+	if !reflect.DeepEqual(expected.HTTPRequest, actual.HTTPRequest) {
+		t.Errorf("%v: expected HTTPRequest='%v', but was '%v'", testName, expected.HTTPRequest, actual.HTTPRequest)
+	}
+	if !reflect.DeepEqual(expected.RetryPolicy, actual.RetryPolicy) {
+		t.Errorf("%v: expected RetryPolicy='%v', but was '%v'", testName, expected.RetryPolicy, actual.RetryPolicy)
+	}
+}
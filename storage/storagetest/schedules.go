@@ -0,0 +1,487 @@
+package storagetest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/storage"
+)
+
+func testScheduleManager(t *testing.T, s storage.Store) {
+	// Create a schedule.
+	var emptyTime time.Time
+	// Start in the past in case of clock skew.
+	from := time.Now().UTC().Add(-time.Minute)
+	expected := data.ScheduleCrontab{
+		Schedule: data.Schedule{
+			Active:          true,
+			ARN:             "testarn",
+			By:              "jobmanager_test",
+			Created:         time.Now().UTC(),
+			DeactivatedDate: emptyTime,
+			ExternalID:      "externalid",
+			Payload:         `{ nonsense: "payload" }`,
+			HTTPRequest:     &data.HTTPRequest{Method: "PUT"},
+			RetryPolicy:     &data.RetryPolicy{MaxAttempts: 5},
+			Timezone:        "Europe/London",
+			Until:           time.Now().UTC().Add(time.Hour * 24 * 365),
+			MaxRuns:         1,
+			MisfirePolicy:   data.MisfirePolicySkip,
+			ScheduleID:      1,
+		},
+		Crontab: data.Crontab{
+			Crontab:   "* * * *",
+			CrontabID: 1,
+			// The crontab is due straight away, either from the start time or from when it was created.
+			Next:       time.Now().UTC(),
+			ScheduleID: 1,
+		},
+	}
+
+	scheduleID, err := s.ScheduleCreator(from,
+		expected.Schedule.ARN,
+		expected.Schedule.Payload,
+		expected.Schedule.HTTPRequest,
+		expected.Schedule.RetryPolicy,
+		[]string{expected.Crontab.Crontab},
+		expected.Schedule.Timezone,
+		expected.Schedule.Until,
+		expected.Schedule.MaxRuns,
+		expected.Schedule.MisfirePolicy,
+		expected.Schedule.ExternalID,
+		expected.Schedule.By)
+	if err != nil {
+		t.Fatalf("failed to create schedule with error: %v", err)
+	}
+	if scheduleID == 0 {
+		t.Error("expected scheduleID > 0, but got 0")
+	}
+
+	// Start processing schedules, newly processed ones should appear in the list.
+	actual, ok, err := s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("faied to get schedules with error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected to retrieve a schedule crontab, but didn't")
+	}
+	assertSchedule(t, "get schedule", expected.Schedule, actual.Schedule)
+	// Some backends record the creation of a crontab as its first update, others leave the times empty.
+	expected.Crontab.LastUpdated, expected.Crontab.Previous = actual.Crontab.LastUpdated, actual.Crontab.Previous
+	assertCrontab(t, "get schedule", expected.Crontab, actual.Crontab)
+
+	// Update crontab to be checked again in the future.
+	newNext := time.Now().Add(time.Hour * 24)
+	jobID, err := s.ScheduledJobStarter(actual.Crontab.CrontabID, actual.Schedule.ScheduleID,
+		actual.CrontabLeaseID, newNext)
+	if err != nil {
+		t.Errorf("unexpected error starting job: %v", err)
+	}
+	if jobID == 0 {
+		t.Errorf("failed to start a new job while updating the cron, expected > 0, but got %v", jobID)
+	}
+
+	// Check it's gone from the list.
+	sc, ok, err := s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("faied to get schedules with error: %v", err)
+	}
+	if ok {
+		t.Errorf("expected not to retrieve any scheduled crontabs, but got %v", sc)
+	}
+
+	// Check that the run was counted, and that no more jobs can be started.
+	byID, _, err := s.ScheduleByIDGetter(scheduleID)
+	if err != nil {
+		t.Fatalf("failed to get schedule by ID with error: %v", err)
+	}
+	if byID.Schedule.Runs != 1 {
+		t.Errorf("expected the schedule to have 1 run, but got %v", byID.Schedule.Runs)
+	}
+	jobID, err = s.ScheduledJobStarter(actual.Crontab.CrontabID, actual.Schedule.ScheduleID,
+		actual.CrontabLeaseID, newNext)
+	if err != nil {
+		t.Errorf("unexpected error starting job after the maximum number of runs: %v", err)
+	}
+	if jobID != 0 {
+		t.Errorf("expected no job to be started after the maximum number of runs, but got job %v", jobID)
+	}
+}
+
+func testScheduleManagerPauseAndResume(t *testing.T, s storage.Store) {
+	from := time.Now().UTC().Add(time.Minute * -5)
+	scheduleID, err := s.ScheduleCreator(from, "testarn", "payload", nil, nil, []string{"0 * * * *"}, "", time.Time{}, 0, "", "externalid", "schedulemanager_test")
+	if err != nil {
+		t.Fatalf("failed to create schedule with error: %v", err)
+	}
+
+	// Pause the schedule, it should no longer be available to process.
+	ok, err := s.SchedulePauser(scheduleID, "pauser")
+	if err != nil || !ok {
+		t.Fatalf("expected to pause the schedule, got ok=%v, err=%v", ok, err)
+	}
+	ok, err = s.SchedulePauser(scheduleID, "pauser")
+	if err != nil || ok {
+		t.Errorf("expected pausing a paused schedule to do nothing, got ok=%v, err=%v", ok, err)
+	}
+	sc, ok, err := s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("failed to get schedules with error: %v", err)
+	}
+	if ok {
+		t.Errorf("expected not to retrieve a paused schedule, but got %v", sc)
+	}
+
+	// Resume with a catch up, so that the crontab is due immediately.
+	ok, err = s.ScheduleResumer(scheduleID, "resumer", true)
+	if err != nil || !ok {
+		t.Fatalf("expected to resume the schedule, got ok=%v, err=%v", ok, err)
+	}
+	ok, err = s.ScheduleResumer(scheduleID, "resumer", true)
+	if err != nil || ok {
+		t.Errorf("expected resuming an active schedule to do nothing, got ok=%v, err=%v", ok, err)
+	}
+	byID, _, err := s.ScheduleByIDGetter(scheduleID)
+	if err != nil {
+		t.Fatalf("failed to get schedule by ID with error: %v", err)
+	}
+	if byID.Schedule.Paused {
+		t.Errorf("expected the schedule not to be paused")
+	}
+	if len(byID.Pauses) != 1 {
+		t.Fatalf("expected 1 pause, got %v", len(byID.Pauses))
+	}
+	p := byID.Pauses[0]
+	if p.PausedBy != "pauser" || p.ResumedBy != "resumer" || p.ResumedDate.IsZero() || !p.CatchUp {
+		t.Errorf("unexpected pause history: %+v", p)
+	}
+	sc, ok, err = s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+	if err != nil {
+		t.Fatalf("failed to get schedules with error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected to retrieve the resumed schedule to catch up, but didn't")
+	}
+
+	// Skip the run, which moves the crontab on without starting a job.
+	newNext := time.Now().UTC().Add(time.Hour)
+	if err = s.CrontabSkipper(sc.Crontab.CrontabID, sc.CrontabLeaseID, newNext); err != nil {
+		t.Fatalf("failed to skip crontab with error: %v", err)
+	}
+	byID, _, err = s.ScheduleByIDGetter(scheduleID)
+	if err != nil {
+		t.Fatalf("failed to get schedule by ID with error: %v", err)
+	}
+	if byID.Schedule.Runs != 0 {
+		t.Errorf("expected a skipped run not to be counted, but got %v runs", byID.Schedule.Runs)
+	}
+	if !dateIsWithinRange(newNext, byID.Crontabs[0].Next, time.Second) {
+		t.Errorf("expected the skipped crontab to be next due at %v, but was %v", newNext, byID.Crontabs[0].Next)
+	}
+}
+
+func testScheduleManagerUpdate(t *testing.T, s storage.Store) {
+	from := time.Now().UTC().Add(time.Minute * -5)
+	scheduleID, err := s.ScheduleCreator(from, "testarn", "payload", nil, nil, []string{"0 * * * *", "0 9 * * *"}, "", time.Time{}, 0, "", "externalid", "schedulemanager_test")
+	if err != nil {
+		t.Fatalf("failed to create schedule with error: %v", err)
+	}
+
+	// Lease one of the crontabs, so that it can't be removed.
+	sc, ok, err := s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease a crontab, got ok=%v, err=%v", ok, err)
+	}
+	_, err = s.ScheduleUpdater(scheduleID, "newarn", "newpayload", nil, nil, []string{"30 12 * * *"}, "", time.Time{}, 0, "", "externalid", "updater")
+	if err != data.ErrCrontabLeased {
+		t.Fatalf("expected the update to fail because a crontab is leased, got %v", err)
+	}
+
+	// Keep the leased crontab, and replace the other one.
+	ok, err = s.ScheduleUpdater(scheduleID, "newarn", "newpayload", nil, nil, []string{sc.Crontab.Crontab, "30 12 * * *"}, "", time.Time{}, 10, data.MisfirePolicyFireOnce, "externalid", "updater")
+	if err != nil || !ok {
+		t.Fatalf("expected to update the schedule, got ok=%v, err=%v", ok, err)
+	}
+	byID, _, err := s.ScheduleByIDGetter(scheduleID)
+	if err != nil {
+		t.Fatalf("failed to get schedule by ID with error: %v", err)
+	}
+	if byID.Schedule.ARN != "newarn" || byID.Schedule.Payload != "newpayload" || byID.Schedule.MaxRuns != 10 ||
+		byID.Schedule.MisfirePolicy != data.MisfirePolicyFireOnce || byID.Schedule.By != "updater" {
+		t.Errorf("expected the schedule fields to be updated, got %+v", byID.Schedule)
+	}
+	if len(byID.Crontabs) != 2 {
+		t.Fatalf("expected 2 crontabs, got %v", len(byID.Crontabs))
+	}
+	for _, ct := range byID.Crontabs {
+		if ct.CrontabID == sc.Crontab.CrontabID {
+			if !dateIsWithinRange(sc.Crontab.Next, ct.Next, time.Second) {
+				t.Errorf("expected the unchanged crontab to keep its next run %v, but was %v", sc.Crontab.Next, ct.Next)
+			}
+			continue
+		}
+		if ct.Crontab != "30 12 * * *" || !ct.Next.After(time.Now().UTC()) {
+			t.Errorf("expected the new crontab to be due in the future, got %+v", ct)
+		}
+	}
+
+	// Deactivated schedules can't be updated.
+	if _, err = s.ScheduleDeactivator(scheduleID); err != nil {
+		t.Fatalf("failed to deactivate schedule with error: %v", err)
+	}
+	ok, err = s.ScheduleUpdater(scheduleID, "newarn", "newpayload", nil, nil, []string{"0 * * * *"}, "", time.Time{}, 0, "", "externalid", "updater")
+	if err != nil || ok {
+		t.Errorf("expected updating a deactivated schedule to do nothing, got ok=%v, err=%v", ok, err)
+	}
+}
+
+func testScheduleManagerLeasesEachCrontabOnce(t *testing.T, s storage.Store) {
+	const scheduleCount = 20
+	for i := 0; i < scheduleCount; i++ {
+		_, err := s.ScheduleCreator(time.Now().UTC(), "testarn", "payload", nil, nil, []string{"* * * * *"}, "", time.Time{}, 0, "", "externalid", "schedulemanager_test")
+		if err != nil {
+			t.Fatalf("failed to create schedule with error: %v", err)
+		}
+	}
+
+	var m sync.Mutex
+	leased := make(map[int64]int)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				sc, ok, err := s.ScheduleGetter("schedulemanager_test", lockExpiryMins)
+				if err != nil {
+					t.Errorf("failed to get schedule: %v", err)
+					return
+				}
+				if !ok {
+					return
+				}
+				m.Lock()
+				leased[sc.Crontab.CrontabID]++
+				m.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(leased) != scheduleCount {
+		t.Errorf("expected %v crontabs to be leased, but got %v", scheduleCount, len(leased))
+	}
+	for id, count := range leased {
+		if count != 1 {
+			t.Errorf("expected crontab %v to be leased once, but was leased %v times", id, count)
+		}
+	}
+}
+
+func assertCrontab(t *testing.T, testName string, expected, actual data.Crontab) {
+	if expected.Crontab != actual.Crontab {
+		t.Errorf("%v: expected crontab Crontab='%v', but was '%v'", testName, expected.Crontab, actual.Crontab)
+	}
+	if expected.CrontabID != actual.CrontabID {
+		t.Errorf("%v: expected crontab CrontabID='%v', but was '%v'", testName, expected.CrontabID, actual.CrontabID)
+	}
+	if !dateIsWithinRange(expected.LastUpdated, actual.LastUpdated, time.Minute*5) {
+		t.Errorf("%v: expected crontab LastUpdated='%v', but was '%v'", testName, expected.LastUpdated, actual.LastUpdated)
+	}
+	if !dateIsWithinRange(expected.Next, actual.Next, time.Minute*5) {
+		t.Errorf("%v: expected crontab Next='%v', but was '%v'", testName, expected.Next, actual.Next)
+	}
+	if !dateIsWithinRange(expected.Previous, actual.Previous, time.Minute*5) {
+		t.Errorf("%v: expected crontab Previous='%v', but was '%v'", testName, expected.Previous, actual.Previous)
+	}
+	if expected.ScheduleID != actual.ScheduleID {
+		t.Errorf("%v: expected crontab ScheduleID='%v', but was '%v'", testName, expected.ScheduleID, actual.ScheduleID)
+	}
+}
+
+func assertSchedule(t *testing.T, testName string, expected, actual data.Schedule) {
+	if expected.Active != actual.Active {
+		t.Errorf("%v: expected active Active='%v', but was '%v'", testName, expected.Active, actual.Active)
+	}
+	if expected.ARN != actual.ARN {
+		t.Errorf("%v: expected schedule ARN='%v', but was '%v'", testName, expected.ARN, actual.ARN)
+	}
+	if expected.By != actual.By {
+		t.Errorf("%v: expected schedule By='%v', but was '%v'", testName, expected.By, actual.By)
+	}
+	if !dateIsWithinRange(expected.Created, actual.Created, time.Minute*5) {
+		t.Errorf("%v: expected schedule Created='%v', but was '%v'", testName, expected.Created, actual.Created)
+	}
+	if !dateIsWithinRange(expected.DeactivatedDate, actual.DeactivatedDate, time.Minute*5) {
+		t.Errorf("%v: expected schedule DeactivatedDate='%v', but was '%v'", testName, expected.DeactivatedDate, actual.DeactivatedDate)
+	}
+	if expected.ExternalID != actual.ExternalID {
+		t.Errorf("%v: expected schedule ExternalID='%v', but was '%v'", testName, expected.ExternalID, actual.ExternalID)
+	}
+	if expected.Payload != actual.Payload {
+		t.Errorf("%v: expected schedule Payload='%v', but was '%v'", testName, expected.Payload, actual.Payload)
+	}
+	if expected.ScheduleID != actual.ScheduleID {
+		t.Errorf("%v: expected schedule ID='%v', but was '%v'", testName, expected.ScheduleID, actual.ScheduleID)
+	}
+	if !reflect.DeepEqual(expected.HTTPRequest, actual.HTTPRequest) {
+		t.Errorf("%v: expected schedule HTTPRequest='%v', but was '%v'", testName, expected.HTTPRequest, actual.HTTPRequest)
+	}
+	if !reflect.DeepEqual(expected.RetryPolicy, actual.RetryPolicy) {
+		t.Errorf("%v: expected schedule RetryPolicy='%v', but was '%v'", testName, expected.RetryPolicy, actual.RetryPolicy)
+	}
+	if !dateIsWithinRange(expected.Until, actual.Until, time.Minute*5) {
+		t.Errorf("%v: expected schedule Until='%v', but was '%v'", testName, expected.Until, actual.Until)
+	}
+	if expected.MaxRuns != actual.MaxRuns {
+		t.Errorf("%v: expected schedule MaxRuns='%v', but was '%v'", testName, expected.MaxRuns, actual.MaxRuns)
+	}
+	if expected.Runs != actual.Runs {
+		t.Errorf("%v: expected schedule Runs='%v', but was '%v'", testName, expected.Runs, actual.Runs)
+	}
+	if expected.MisfirePolicy != actual.MisfirePolicy {
+		t.Errorf("%v: expected schedule MisfirePolicy='%v', but was '%v'", testName, expected.MisfirePolicy, actual.MisfirePolicy)
+	}
+	if expected.Timezone != actual.Timezone {
+		t.Errorf("%v: expected schedule Timezone='%v', but was '%v'", testName, expected.Timezone, actual.Timezone)
+	}
+}
+
+func dateIsWithinRange(a, b time.Time, r time.Duration) bool {
+	if a.Equal(b) {
+		return true
+	}
+	if a.Before(b) {
+		if a.Add(r).After(b) {
+			return true
+		}
+	}
+	if b.Before(a) {
+		if b.Add(r).After(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func testScheduleManagerListsSchedules(t *testing.T, s storage.Store) {
+	from := time.Now().UTC().Add(time.Minute * -5)
+	create := func(arn string, crontabs []string, externalID, by string) int64 {
+		id, err := s.ScheduleCreator(from, arn, "payload", nil, nil, crontabs, "", time.Time{}, 0, "", externalID, by)
+		if err != nil {
+			t.Fatalf("failed to create schedule with error: %v", err)
+		}
+		return id
+	}
+	first := create("arn_a", []string{"0 * * * *", "0 9 * * *"}, "external_1", "system_x")
+	second := create("arn_b", []string{"0 * * * *"}, "external_2", "system_y")
+	third := create("arn_a", []string{"0 * * * *"}, "external_1", "system_x")
+	if _, err := s.ScheduleDeactivator(third); err != nil {
+		t.Fatalf("failed to deactivate schedule with error: %v", err)
+	}
+
+	active := true
+	tests := []struct {
+		name     string
+		filter   data.ScheduleFilter
+		afterID  int64
+		limit    int
+		expected []int64
+	}{
+		{name: "all", limit: 10, expected: []int64{first, second, third}},
+		{name: "externalId", filter: data.ScheduleFilter{ExternalID: "external_1"}, limit: 10, expected: []int64{first, third}},
+		{name: "externalId and active", filter: data.ScheduleFilter{ExternalID: "external_1", Active: &active}, limit: 10, expected: []int64{first}},
+		{name: "by", filter: data.ScheduleFilter{By: "system_y"}, limit: 10, expected: []int64{second}},
+		{name: "arn", filter: data.ScheduleFilter{ARN: "arn_a"}, limit: 10, expected: []int64{first, third}},
+		{name: "created in the future", filter: data.ScheduleFilter{CreatedFrom: time.Now().UTC().Add(time.Hour)}, limit: 10, expected: []int64{}},
+		{name: "created before the future", filter: data.ScheduleFilter{CreatedTo: time.Now().UTC().Add(time.Hour)}, limit: 10, expected: []int64{first, second, third}},
+		{name: "first page", limit: 2, expected: []int64{first, second}},
+		{name: "next page", afterID: second, limit: 2, expected: []int64{third}},
+	}
+	for _, test := range tests {
+		scs, err := s.SchedulesLister(test.filter, test.afterID, test.limit)
+		if err != nil {
+			t.Fatalf("%s: failed to list schedules with error: %v", test.name, err)
+		}
+		actual := make([]int64, len(scs))
+		for i, sc := range scs {
+			actual[i] = sc.Schedule.ScheduleID
+		}
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("%s: expected schedules %v, got %v", test.name, test.expected, actual)
+		}
+	}
+
+	// Each schedule is listed with its crontabs and when they're next due.
+	scs, err := s.SchedulesLister(data.ScheduleFilter{}, 0, 1)
+	if err != nil {
+		t.Fatalf("failed to list schedules with error: %v", err)
+	}
+	if len(scs) != 1 || len(scs[0].Crontabs) != 2 {
+		t.Fatalf("expected the first schedule to be listed with 2 crontabs, got %+v", scs)
+	}
+	for _, ct := range scs[0].Crontabs {
+		if ct.ScheduleID != first || ct.Next.IsZero() {
+			t.Errorf("expected a crontab of schedule %v with its next run, got %+v", first, ct)
+		}
+	}
+	if scs[0].Schedule.ARN != "arn_a" || scs[0].Schedule.ExternalID != "external_1" || !scs[0].Schedule.Active {
+		t.Errorf("unexpected schedule %+v", scs[0].Schedule)
+	}
+}
+
+func testScheduleManagerCreatesSchedulesIdempotently(t *testing.T, s storage.Store) {
+	from := time.Now().UTC().Add(time.Minute * -5)
+	create := func(key data.IdempotencyKey, payload string) (int64, bool, error) {
+		return s.IdempotentScheduleCreator(key, from, "arn_a", payload, nil, nil, []string{"0 * * * *"}, "", time.Time{}, 0, "", "external_1", "system_x")
+	}
+	key := data.IdempotencyKey{Key: "key_a", Caller: "system_x", RequestHash: "hash_a"}
+	first, existing, err := create(key, "payload")
+	if err != nil || existing {
+		t.Fatalf("expected a new schedule, got existing=%v, err=%v", existing, err)
+	}
+
+	// Repeating the request returns the first schedule, without creating another.
+	again, existing, err := create(key, "payload")
+	if err != nil || !existing || again != first {
+		t.Errorf("expected schedule %v to be returned, got %v, existing=%v, err=%v", first, again, existing, err)
+	}
+	schedules, err := s.SchedulesLister(data.ScheduleFilter{}, 0, 10)
+	if err != nil || len(schedules) != 1 {
+		t.Errorf("expected a single schedule, got %v, err=%v", len(schedules), err)
+	}
+
+	// Reusing the key for a different request is a conflict.
+	if _, _, err = create(data.IdempotencyKey{Key: "key_a", Caller: "system_x", RequestHash: "hash_b"}, "otherpayload"); err != data.ErrIdempotencyKeyConflict {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+
+	// Keys are scoped to the caller.
+	other, existing, err := create(data.IdempotencyKey{Key: "key_a", Caller: "system_y", RequestHash: "hash_a"}, "payload")
+	if err != nil || existing || other == first {
+		t.Errorf("expected a new schedule for a different caller, got %v, existing=%v, err=%v", other, existing, err)
+	}
+}
+
+func testScheduleManagerPurgesExpiredCrontabLeases(t *testing.T, s storage.Store) {
+	if _, err := s.ScheduleCreator(time.Now().UTC(), "testarn", "payload", nil, nil, []string{"* * * * *"}, "", time.Time{}, 0, "", "externalid", "schedulemanager_test"); err != nil {
+		t.Fatalf("failed to create schedule with error: %v", err)
+	}
+	// Lease the crontab for no time at all, so that the lease has expired.
+	_, ok, err := s.ScheduleGetter("schedulemanager_test", 0)
+	if err != nil || !ok {
+		t.Fatalf("expected to lease the crontab, got ok=%v, err=%v", ok, err)
+	}
+
+	purged, err := s.CrontabLeasesPurger(time.Now().UTC().Add(-time.Minute), 10)
+	if err != nil || purged != 0 {
+		t.Errorf("expected the lease to be kept until it's older than the cutoff, but purged %v, err=%v", purged, err)
+	}
+	purged, err = s.CrontabLeasesPurger(time.Now().UTC().Add(time.Minute), 10)
+	if err != nil || purged != 1 {
+		t.Errorf("expected to purge the expired crontab lease, but purged %v, err=%v", purged, err)
+	}
+}
@@ -0,0 +1,96 @@
+package storagetest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+)
+
+// StatementCounter wraps a database driver, and counts the statements which are made through the connections it
+// opens. It should be registered with sql.Register, and used to open the database under test.
+type StatementCounter struct {
+	Driver     driver.Driver
+	statements int64
+}
+
+// Open opens a connection with the wrapped driver.
+func (c *StatementCounter) Open(name string) (driver.Conn, error) {
+	conn, err := c.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return countingConn{Conn: conn, counter: c}, nil
+}
+
+// Statements returns the number of statements which have been made so far.
+func (c *StatementCounter) Statements() int64 {
+	return atomic.LoadInt64(&c.statements)
+}
+
+// countingConn only implements Prepare, so database/sql prepares every statement, and each is counted.
+type countingConn struct {
+	driver.Conn
+	counter *StatementCounter
+}
+
+func (c countingConn) Prepare(query string) (driver.Stmt, error) {
+	atomic.AddInt64(&c.counter.statements, 1)
+	return c.Conn.Prepare(query)
+}
+
+// StartsJobsInFewStatements checks that a batch of jobs is started with a handful of statements, rather than one for
+// each job. The jobs must be started and got from a database which was opened with the counter.
+func StartsJobsInFewStatements(t *testing.T, start data.JobsStarter, get data.JobAndResponseByIDGetter, counter *StatementCounter) {
+	when := time.Now().UTC().Truncate(time.Second)
+	jobs := make([]data.Job, 1000)
+	for i := range jobs {
+		jobs[i] = data.Job{
+			When:    when,
+			ARN:     "arn",
+			Payload: fmt.Sprintf("job %d", i),
+		}
+		if i%2 == 0 {
+			jobs[i].HTTPRequest = &data.HTTPRequest{Method: "PUT"}
+		}
+	}
+
+	before := counter.Statements()
+	started, err := start(jobs, 0)
+	if err != nil {
+		t.Fatalf("failed to start jobs with error: %v", err)
+	}
+	if statements := counter.Statements() - before; statements > 20 {
+		t.Errorf("expected a batch of %d jobs to be started with a handful of statements, but %d were made", len(jobs), statements)
+	}
+
+	if len(started) != len(jobs) {
+		t.Fatalf("expected %d started jobs, got %d", len(jobs), len(started))
+	}
+	jobIDs := make(map[int64]bool)
+	for i, s := range started {
+		if s.Duplicate {
+			t.Errorf("job %d: expected not to be a duplicate", i)
+		}
+		if jobIDs[s.Job.JobID] {
+			t.Errorf("job %d: the ID %d was returned for more than one job", i, s.Job.JobID)
+		}
+		jobIDs[s.Job.JobID] = true
+		if s.Job.Payload != jobs[i].Payload {
+			t.Errorf("job %d: expected payload %q, got %q", i, jobs[i].Payload, s.Job.Payload)
+		}
+		j, _, ok, _, err := get(s.Job.JobID)
+		if err != nil || !ok {
+			t.Fatalf("job %d: failed to get job %d, ok: %v, err: %v", i, s.Job.JobID, ok, err)
+		}
+		if j.Payload != jobs[i].Payload {
+			t.Errorf("job %d: expected job %d to have payload %q, got %q", i, s.Job.JobID, jobs[i].Payload, j.Payload)
+		}
+		if (j.HTTPRequest != nil) != (jobs[i].HTTPRequest != nil) {
+			t.Errorf("job %d: expected HTTP request %v, got %v", i, jobs[i].HTTPRequest, j.HTTPRequest)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package storagetest checks that a storage backend behaves like the others. Each backend's tests call Run with a
+// function which opens an empty Store, so that the same behaviour is tested against every backend.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/welldigital/callme/storage"
+)
+
+const lockExpiryMins = 5
+
+// Opener opens an empty Store for a test, along with a function which closes the Store and removes its database.
+type Opener func(t *testing.T) (s storage.Store, close func())
+
+var tests = []struct {
+	name string
+	test func(t *testing.T, s storage.Store)
+}{
+	{name: "JobManager", test: testJobManager},
+	{name: "JobManagerLeasesEachJobOnce", test: testJobManagerLeasesEachJobOnce},
+	{name: "JobManagerRenewsLeases", test: testJobManagerRenewsLeases},
+	{name: "JobManagerRecordsDuplicates", test: testJobManagerRecordsDuplicates},
+	{name: "JobManagerListsJobs", test: testJobManagerListsJobs},
+	{name: "JobManagerBatches", test: testJobManagerBatches},
+	{name: "JobManagerStartsJobsIdempotently", test: testJobManagerStartsJobsIdempotently},
+	{name: "JobManagerDedupesJobs", test: testJobManagerDedupesJobs},
+	{name: "JobManagerDedupesConcurrentJobs", test: testJobManagerDedupesConcurrentJobs},
+	{name: "JobManagerStartsJobsInBatches", test: testJobManagerStartsJobsInBatches},
+	{name: "JobManagerPurges", test: testJobManagerPurges},
+	{name: "ScheduleManager", test: testScheduleManager},
+	{name: "ScheduleManagerPauseAndResume", test: testScheduleManagerPauseAndResume},
+	{name: "ScheduleManagerUpdate", test: testScheduleManagerUpdate},
+	{name: "ScheduleManagerLeasesEachCrontabOnce", test: testScheduleManagerLeasesEachCrontabOnce},
+	{name: "ScheduleManagerListsSchedules", test: testScheduleManagerListsSchedules},
+	{name: "ScheduleManagerCreatesSchedulesIdempotently", test: testScheduleManagerCreatesSchedulesIdempotently},
+	{name: "ScheduleManagerPurgesExpiredCrontabLeases", test: testScheduleManagerPurgesExpiredCrontabLeases},
+}
+
+// Run runs every test against the backend, as a subtest of t. Each test uses its own Store, opened by open.
+func Run(t *testing.T, open Opener) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, close := open(t)
+			defer close()
+			test.test(t, s)
+		})
+	}
+}
@@ -1,16 +1,70 @@
 package web
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/signature"
 )
 
-// Execute assumes that the address provided is a HTTP endpoint and posts the payload to it.
-func Execute(address string, body string) (resp string, err error) {
-	buf := strings.NewReader(body)
-	response, err := http.Post(address, "application/json", buf)
+// DefaultMethod is the HTTP method used when the job doesn't specify one.
+const DefaultMethod = http.MethodPost
+
+// DefaultContentType is the Content-Type used when the job doesn't specify one.
+const DefaultContentType = "application/json"
+
+var validMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// Execute assumes that the job's ARN is a HTTP endpoint and sends the payload to it, using
+// the job's HTTPRequest to customise the method, headers and content type. The request is not signed, and is cancelled
+// if the context is cancelled.
+func Execute(ctx context.Context, j data.Job) (resp string, err error) {
+	return NewExecutor(nil)(ctx, j)
+}
+
+// NewExecutor creates an executor which sends jobs to HTTP endpoints in the same way as Execute, but
+// signs each request using the secrets in the keyring (see the signature package).
+func NewExecutor(keys signature.Keyring) func(ctx context.Context, j data.Job) (resp string, err error) {
+	return func(ctx context.Context, j data.Job) (resp string, err error) {
+		req, err := NewRequest(j)
+		if err != nil {
+			return "", err
+		}
+		req = req.WithContext(ctx)
+		if err = sign(req, j, keys, time.Now().UTC()); err != nil {
+			return "", err
+		}
+		return do(req)
+	}
+}
+
+func sign(req *http.Request, j data.Job, keys signature.Keyring, now time.Time) error {
+	var name string
+	if j.HTTPRequest != nil {
+		name = j.HTTPRequest.SigningKey
+	}
+	secrets, ok, err := keys.Secrets(name, req.URL.Hostname())
+	if err != nil || !ok {
+		return err
+	}
+	signature.Sign(req, []byte(j.Payload), secrets, now)
+	return nil
+}
+
+func do(req *http.Request) (resp string, err error) {
+	response, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -24,3 +78,92 @@ func Execute(address string, body string) (resp string, err error) {
 	}
 	return string(bytes), err
 }
+
+// NewRequest creates the HTTP request for a job.
+func NewRequest(j data.Job) (*http.Request, error) {
+	method := DefaultMethod
+	contentType := DefaultContentType
+	var headers map[string]string
+	if j.HTTPRequest != nil {
+		if j.HTTPRequest.Method != "" {
+			method = strings.ToUpper(j.HTTPRequest.Method)
+		}
+		if j.HTTPRequest.ContentType != "" {
+			contentType = j.HTTPRequest.ContentType
+		}
+		headers = j.HTTPRequest.Headers
+	}
+	req, err := http.NewRequest(method, j.ARN, strings.NewReader(j.Payload))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+// A RequestValidator checks that a HTTPRequest can be used with an ARN, returning an error if it can't.
+type RequestValidator func(arn string, r *data.HTTPRequest) error
+
+// ValidateRequest checks that the HTTPRequest can be used with the ARN. It doesn't check that the signing key exists,
+// because the keyring is only known to the worker, see NewRequestValidator.
+func ValidateRequest(arn string, r *data.HTTPRequest) error {
+	if r == nil {
+		return nil
+	}
+	if !strings.HasPrefix(strings.ToLower(arn), "http://") && !strings.HasPrefix(strings.ToLower(arn), "https://") {
+		return errors.New("httpRequest can only be used with http and https ARNs")
+	}
+	if r.Method != "" && !validMethods[strings.ToUpper(r.Method)] {
+		return fmt.Errorf("unsupported HTTP method '%v'", r.Method)
+	}
+	if len(r.ContentType) > 256 {
+		return errors.New("maximum length of the content type is 256 characters")
+	}
+	if len(r.SigningKey) > 256 {
+		return errors.New("maximum length of the signing key is 256 characters")
+	}
+	for k, v := range r.Headers {
+		if !isToken(k) {
+			return fmt.Errorf("invalid header name '%v'", k)
+		}
+		if strings.EqualFold(k, "Content-Type") {
+			return errors.New("use contentType to set the Content-Type header")
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("invalid value for header '%v'", k)
+		}
+	}
+	return nil
+}
+
+// NewRequestValidator creates a RequestValidator which checks requests in the same way as ValidateRequest, and also
+// checks that the signing key named by the request is in the keyring, so that the job doesn't fail when it's sent.
+func NewRequestValidator(keys signature.Keyring) RequestValidator {
+	return func(arn string, r *data.HTTPRequest) error {
+		if err := ValidateRequest(arn, r); err != nil {
+			return err
+		}
+		if r == nil || r.SigningKey == "" {
+			return nil
+		}
+		if _, _, err := keys.Secrets(r.SigningKey, ""); err != nil {
+			return fmt.Errorf("unknown signing key '%v'", r.SigningKey)
+		}
+		return nil
+	}
+}
+
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c <= ' ' || c >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/signature"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name                string
+		httpRequest         *data.HTTPRequest
+		expectedMethod      string
+		expectedContentType string
+		expectedHeaders     map[string]string
+	}{
+		{
+			name:                "defaults",
+			httpRequest:         nil,
+			expectedMethod:      http.MethodPost,
+			expectedContentType: "application/json",
+		},
+		{
+			name: "custom",
+			httpRequest: &data.HTTPRequest{
+				Method:      "put",
+				ContentType: "text/plain",
+				Headers: map[string]string{
+					"Authorization":    "Bearer token",
+					"X-Correlation-Id": "123",
+				},
+			},
+			expectedMethod:      http.MethodPut,
+			expectedContentType: "text/plain",
+			expectedHeaders: map[string]string{
+				"Authorization":    "Bearer token",
+				"X-Correlation-Id": "123",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var actual *http.Request
+		var actualBody string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actual = r
+			b, _ := ioutil.ReadAll(r.Body)
+			actualBody = string(b)
+			w.Write([]byte("ok"))
+		}))
+
+		resp, err := Execute(context.Background(), data.Job{ARN: s.URL, Payload: "payload", HTTPRequest: test.httpRequest})
+		s.Close()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if resp != "ok" {
+			t.Errorf("%s: expected response 'ok', got '%v'", test.name, resp)
+		}
+		if actual.Method != test.expectedMethod {
+			t.Errorf("%s: expected method '%v', got '%v'", test.name, test.expectedMethod, actual.Method)
+		}
+		if actual.Header.Get("Content-Type") != test.expectedContentType {
+			t.Errorf("%s: expected content type '%v', got '%v'", test.name, test.expectedContentType, actual.Header.Get("Content-Type"))
+		}
+		for k, v := range test.expectedHeaders {
+			if actual.Header.Get(k) != v {
+				t.Errorf("%s: expected header %v='%v', got '%v'", test.name, k, v, actual.Header.Get(k))
+			}
+		}
+		if actualBody != "payload" {
+			t.Errorf("%s: expected body 'payload', got '%v'", test.name, actualBody)
+		}
+	}
+}
+
+func TestThatRequestsAreSigned(t *testing.T) {
+	var actual *http.Request
+	var actualBody []byte
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual = r
+		actualBody, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer s.Close()
+
+	keys := signature.Keyring{
+		"default": []string{"default_secret"},
+		"named":   []string{"new_secret", "old_secret"},
+	}
+	e := NewExecutor(keys)
+
+	_, err := e(context.Background(), data.Job{ARN: s.URL, Payload: "payload"})
+	if err != nil {
+		t.Fatalf("default key: unexpected error: %v", err)
+	}
+	if err := signature.VerifyBody(actual.Header, actualBody, []string{"default_secret"}, time.Minute, time.Now()); err != nil {
+		t.Errorf("default key: expected request to be signed with the default key, got '%v'", err)
+	}
+
+	_, err = e(context.Background(), data.Job{ARN: s.URL, Payload: "payload", HTTPRequest: &data.HTTPRequest{SigningKey: "named"}})
+	if err != nil {
+		t.Fatalf("named key: unexpected error: %v", err)
+	}
+	if err := signature.VerifyBody(actual.Header, actualBody, []string{"old_secret"}, time.Minute, time.Now()); err != nil {
+		t.Errorf("named key: expected request to be signed with the old secret, got '%v'", err)
+	}
+	if err := signature.VerifyBody(actual.Header, actualBody, []string{"new_secret"}, time.Minute, time.Now()); err != nil {
+		t.Errorf("named key: expected request to be signed with the new secret, got '%v'", err)
+	}
+
+	_, err = e(context.Background(), data.Job{ARN: s.URL, Payload: "payload", HTTPRequest: &data.HTTPRequest{SigningKey: "missing"}})
+	if err == nil {
+		t.Errorf("missing key: expected an error, because the key doesn't exist")
+	}
+}
+
+func TestThatErrorStatusCodesAreReturnedAsErrors(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	_, err := Execute(context.Background(), data.Job{ARN: s.URL, Payload: "payload"})
+	if err == nil || err.Error() != "received status code: 500" {
+		t.Errorf("expected status code error, got '%v'", err)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		arn         string
+		r           *data.HTTPRequest
+		expectedErr string
+	}{
+		{name: "nil", arn: "arn:aws:sns:eu-west-2:123456789012:topic", r: nil},
+		{name: "valid", arn: "https://example.com", r: &data.HTTPRequest{Method: "PATCH", Headers: map[string]string{"Authorization": "Bearer x"}}},
+		{name: "sns", arn: "arn:aws:sns:eu-west-2:123456789012:topic", r: &data.HTTPRequest{}, expectedErr: "httpRequest can only be used with http and https ARNs"},
+		{name: "method", arn: "https://example.com", r: &data.HTTPRequest{Method: "TRACE"}, expectedErr: "unsupported HTTP method 'TRACE'"},
+		{name: "header name", arn: "https://example.com", r: &data.HTTPRequest{Headers: map[string]string{"Bad Header": "x"}}, expectedErr: "invalid header name 'Bad Header'"},
+		{name: "header value", arn: "https://example.com", r: &data.HTTPRequest{Headers: map[string]string{"X-Test": "a\r\nb"}}, expectedErr: "invalid value for header 'X-Test'"},
+		{name: "content type header", arn: "https://example.com", r: &data.HTTPRequest{Headers: map[string]string{"content-type": "text/plain"}}, expectedErr: "use contentType to set the Content-Type header"},
+	}
+
+	for _, test := range tests {
+		err := ValidateRequest(test.arn, test.r)
+		var actualErr string
+		if err != nil {
+			actualErr = err.Error()
+		}
+		if actualErr != test.expectedErr {
+			t.Errorf("%s: expected error '%v', got '%v'", test.name, test.expectedErr, actualErr)
+		}
+	}
+}
+
+func TestNewRequestValidator(t *testing.T) {
+	validate := NewRequestValidator(signature.Keyring{"partner": {"secret"}})
+	tests := []struct {
+		name        string
+		arn         string
+		r           *data.HTTPRequest
+		expectedErr string
+	}{
+		{name: "nil", arn: "https://example.com", r: nil},
+		{name: "no signing key", arn: "https://example.com", r: &data.HTTPRequest{}},
+		{name: "known signing key", arn: "https://example.com", r: &data.HTTPRequest{SigningKey: "partner"}},
+		{name: "unknown signing key", arn: "https://example.com", r: &data.HTTPRequest{SigningKey: "other"}, expectedErr: "unknown signing key 'other'"},
+		{name: "invalid request", arn: "https://example.com", r: &data.HTTPRequest{Method: "TRACE", SigningKey: "partner"}, expectedErr: "unsupported HTTP method 'TRACE'"},
+	}
+
+	for _, test := range tests {
+		err := validate(test.arn, test.r)
+		var actualErr string
+		if err != nil {
+			actualErr = err.Error()
+		}
+		if actualErr != test.expectedErr {
+			t.Errorf("%s: expected error '%v', got '%v'", test.name, test.expectedErr, actualErr)
+		}
+	}
+}
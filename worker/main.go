@@ -10,8 +10,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/welldigital/callme/api/job"
+	"github.com/welldigital/callme/api/routes"
+	"github.com/welldigital/callme/archive"
+	"github.com/welldigital/callme/data"
+	"github.com/welldigital/callme/deadletter"
+	"github.com/welldigital/callme/executor"
 	"github.com/welldigital/callme/metrics"
 	"github.com/welldigital/callme/repetitive"
+	"github.com/welldigital/callme/retention"
+	"github.com/welldigital/callme/signature"
 	"github.com/welldigital/callme/web"
 	"github.com/cenkalti/backoff"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,7 +29,7 @@ import (
 	"github.com/welldigital/callme/sns"
 
 	"github.com/welldigital/callme/logger"
-	"github.com/welldigital/callme/mysql"
+	"github.com/welldigital/callme/storage"
 )
 
 const pkg = "github.com/welldigital/callme/worker"
@@ -34,13 +42,23 @@ func init() {
 	prometheus.MustRegister(metrics.JobExecutedDurations)
 	prometheus.MustRegister(metrics.JobLeaseCounts)
 	prometheus.MustRegister(metrics.JobLeaseDurations)
+	prometheus.MustRegister(metrics.JobLeaseBatchSizes)
+	prometheus.MustRegister(metrics.JobLeaseRenewalCounts)
+	prometheus.MustRegister(metrics.DeadLetterForwardedCounts)
 
+	prometheus.MustRegister(metrics.ScheduleDeactivatedCounts)
 	prometheus.MustRegister(metrics.ScheduleExecutedCounts)
 	prometheus.MustRegister(metrics.ScheduleExecutedDelay)
 	prometheus.MustRegister(metrics.ScheduleJobStartedCounts)
 	prometheus.MustRegister(metrics.ScheduleJobStartedDurations)
 	prometheus.MustRegister(metrics.ScheduleLeaseCounts)
 	prometheus.MustRegister(metrics.ScheduleLeaseDurations)
+	prometheus.MustRegister(metrics.ScheduleSkippedCounts)
+
+	prometheus.MustRegister(metrics.RetentionPurgedCounts)
+	prometheus.MustRegister(metrics.RetentionPurgeDurations)
+	prometheus.MustRegister(metrics.ArchiveWrittenCounts)
+	prometheus.MustRegister(metrics.ArchivedJobCounts)
 }
 
 func main() {
@@ -51,25 +69,61 @@ func main() {
 	}
 	scheduleWorkerCount := getIntegerSetting("CALLME_SCHEDULE_WORKER_COUNT", 1)
 	jobWorkerCount := getIntegerSetting("CALLME_JOB_WORKER_COUNT", 1)
+	jobBatchSize := getIntegerSetting("CALLME_JOB_BATCH_SIZE", 1)
 	lockExpiryMinutes := getIntegerSetting("CALLME_LOCK_EXPIRY_MINUTES", 30)
+	misfireThreshold := time.Second * time.Duration(getIntegerSetting("CALLME_MISFIRE_THRESHOLD_SECONDS", int(scheduleworker.DefaultMisfireThreshold/time.Second)))
 	prometheusPort := getIntegerSetting("CALLME_PROMETHEUS_PORT", 6666)
+	apiPort := getIntegerSetting("CALLME_API_PORT", 0)
+	dedupeWindow := time.Minute * time.Duration(getIntegerSetting("CALLME_DEDUPE_WINDOW_MINUTES", int(job.DefaultDedupeWindow/time.Minute)))
+	// Set to 0 to manage the schema with `callme migrate` instead, so that workers refuse to start until it's up to date.
+	autoMigrate := getIntegerSetting("CALLME_AUTO_MIGRATE", 1) != 0
+	// Completed jobs are kept forever unless a retention period is set, while expired leases are only used for locking.
+	const day = time.Hour * 24
+	jobRetention := day * time.Duration(getIntegerSetting("CALLME_RETAIN_JOB_DAYS", 0))
+	jobLeaseRetention := day * time.Duration(getIntegerSetting("CALLME_RETAIN_JOB_LEASE_DAYS", 1))
+	crontabLeaseRetention := day * time.Duration(getIntegerSetting("CALLME_RETAIN_CRONTAB_LEASE_DAYS", 1))
+	idempotencyKeyRetention := day * time.Duration(getIntegerSetting("CALLME_RETAIN_IDEMPOTENCY_KEY_DAYS", 1))
+	dedupeKeyRetention := day * time.Duration(getIntegerSetting("CALLME_RETAIN_DEDUPE_KEY_DAYS", 1))
+	retentionBatchSize := getIntegerSetting("CALLME_RETAIN_BATCH_SIZE", retention.DefaultBatchSize)
+	archiveURL := os.Getenv("CALLME_ARCHIVE_URL")
+	retentionWorkerCount := 0
+	if jobRetention > 0 || jobLeaseRetention > 0 || crontabLeaseRetention > 0 || idempotencyKeyRetention > 0 || dedupeKeyRetention > 0 {
+		retentionWorkerCount = 1
+	}
+	// Keep a connection open for each routine, so that polling doesn't reconnect to the database.
+	poolOptions := storage.PoolOptions{
+		MaxOpenConnections:    getIntegerSetting("CALLME_DB_MAX_OPEN_CONNECTIONS", 0),
+		MaxIdleConnections:    getIntegerSetting("CALLME_DB_MAX_IDLE_CONNECTIONS", scheduleWorkerCount+jobWorkerCount),
+		ConnectionMaxLifetime: time.Second * time.Duration(getIntegerSetting("CALLME_DB_MAX_LIFETIME_SECONDS", 300)),
+	}
 
-	var executor jobworker.Executor
-	switch os.Getenv("CALLME_MODE") {
-	case "web":
-		logger.For(pkg, "main").Info("using web execution mode")
-		executor = web.Execute
-	case "sns":
-	default:
-		logger.For(pkg, "main").Info("using SNS (default) execution mode")
-		executor = sns.Execute
+	signingKeys, err := signature.ParseKeyring(os.Getenv("CALLME_SIGNING_KEYS"))
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Error("invalid signing keys environment variable (CALLME_SIGNING_KEYS)")
+		os.Exit(-1)
 	}
 
-	totalProcesses := scheduleWorkerCount + jobWorkerCount
+	// Route each job to an executor based on the scheme of its ARN.
+	webExecutor := web.NewExecutor(signingKeys)
+	executors := executor.NewRegistry()
+	executors.Register(executor.SchemeSNS, sns.Execute)
+	executors.Register(executor.SchemeHTTP, webExecutor)
+	executors.Register(executor.SchemeHTTPS, webExecutor)
+
+	deadLetterARN := os.Getenv("CALLME_DEAD_LETTER_ARN")
+	if deadLetterARN != "" {
+		if err := executors.Validate(deadLetterARN); err != nil {
+			logger.For(pkg, "main").WithError(err).Error("invalid dead letter ARN environment variable (CALLME_DEAD_LETTER_ARN)")
+			os.Exit(-1)
+		}
+	}
+
+	totalProcesses := scheduleWorkerCount + jobWorkerCount + retentionWorkerCount
 	logger.For(pkg, "main").
 		WithField("totalProcessCount", totalProcesses).
 		WithField("scheduleWorkerCount", scheduleWorkerCount).
 		WithField("jobWorkerCount", jobWorkerCount).
+		WithField("retentionWorkerCount", retentionWorkerCount).
 		Info("starting processes")
 
 	// Start serving metrics.
@@ -90,25 +144,62 @@ func main() {
 		}
 	}()
 
-	schemaUpdate := func() error {
-		mm := mysql.NewMigrationManager(connectionString)
-		err := mm.UpdateSchema()
-		if err != nil {
-			logger.For(pkg, "main").WithError(err).Warn("failed to update schema, but will retry again")
-		}
-		return err
+	store, err := storage.Open(connectionString, poolOptions)
+	if err != nil {
+		logger.For(pkg, "main").WithError(err).Error("failed to open the database")
+		os.Exit(-1)
+	}
+	defer store.Close()
+	logger.For(pkg, "main").WithField("backend", store.Backend).Info("using storage backend")
+	if store.DB != nil {
+		prometheus.MustRegister(metrics.NewDatabaseStatsCollector(store.DB))
 	}
 
-	logger.For(pkg, "main").Info("checking database version and upgrading")
+	if autoMigrate {
+		schemaUpdate := func() error {
+			err := store.UpdateSchema()
+			if err != nil {
+				logger.For(pkg, "main").WithError(err).Warn("failed to update schema, but will retry again")
+			}
+			return err
+		}
 
-	bo := backoff.NewExponentialBackOff()
-	bo.MaxElapsedTime = time.Minute * 5
-	executionError := backoff.Retry(schemaUpdate, bo)
-	if executionError != nil {
-		logger.For(pkg, "main").WithError(executionError).Warn("update schema retry timeout exceeded")
-		os.Exit(-1)
+		logger.For(pkg, "main").Info("checking database version and upgrading")
+
+		bo := backoff.NewExponentialBackOff()
+		bo.MaxElapsedTime = time.Minute * 5
+		executionError := backoff.Retry(schemaUpdate, bo)
+		if executionError != nil {
+			logger.For(pkg, "main").WithError(executionError).Warn("update schema retry timeout exceeded")
+			os.Exit(-1)
+		}
+		logger.For(pkg, "main").Info("updated schema, continuing")
+	} else {
+		logger.For(pkg, "main").Info("checking database version")
+		if err := store.CheckSchema(); err != nil {
+			logger.For(pkg, "main").WithError(err).Error("the schema isn't up to date, run `callme migrate up` to update it")
+			os.Exit(-1)
+		}
+		logger.For(pkg, "main").Info("schema is up to date, continuing")
+	}
+
+	// Serve the API from the worker if a port is set, so that a single process can use the memory backend.
+	if apiPort > 0 {
+		s := &http.Server{
+			Addr:           fmt.Sprintf(":%v", apiPort),
+			Handler:        routes.New(store, executors.Validate, web.NewRequestValidator(signingKeys), dedupeWindow),
+			ReadTimeout:    10 * time.Second,
+			WriteTimeout:   10 * time.Second,
+			MaxHeaderBytes: 1 << 20,
+		}
+		go func() {
+			logger.For(pkg, "main").WithField("port", apiPort).Info("starting API web server")
+			err := s.ListenAndServe()
+			logger.For(pkg, "main").WithError(err).Error("API web server stopped")
+		}()
+	} else if store.Backend == storage.Memory {
+		logger.For(pkg, "main").Warn("the memory backend isn't shared with the API, set CALLME_API_PORT to serve the API from the worker")
 	}
-	logger.For(pkg, "main").Info("updated schema, continuing")
 
 	hostName, _ := os.Hostname()
 	nodeName := fmt.Sprintf("callme_%v_%v", hostName, os.Getpid())
@@ -119,11 +210,13 @@ func main() {
 
 	for i := 0; i < jobWorkerCount; i++ {
 		go func(j int) {
-			sm := mysql.NewScheduleManager(connectionString)
 			scheduleWorkerFunction := scheduleworker.NewScheduleWorker(nodeName,
 				lockExpiryMinutes,
-				sm.GetSchedule,
-				sm.StartJobAndUpdateCron)
+				misfireThreshold,
+				store.ScheduleGetter,
+				store.ScheduledJobStarter,
+				store.CrontabSkipper,
+				store.ScheduleDeactivator)
 			repetitive.Work(nodeName+"_schedules_"+strconv.Itoa(j), scheduleWorkerFunction, time.Second*5, stopper)
 			waiter <- true
 		}(i)
@@ -131,12 +224,29 @@ func main() {
 	}
 
 	for i := 0; i < scheduleWorkerCount; i++ {
-		jm := mysql.NewJobManager(connectionString)
+		var deadLetterer data.JobDeadLetterer = store.JobDeadLetterer
+		if deadLetterARN != "" {
+			deadLetterer = deadletter.NewForwarder(deadLetterer, deadLetterARN, executors.Execute, store.DeadLetterForwardRecorder)
+		}
 		jobWorkerFunction := jobworker.NewJobWorker(nodeName,
 			lockExpiryMinutes,
-			jm.GetJob,
-			executor,
-			jm.CompleteJob)
+			store.JobGetter,
+			store.JobLeaseRenewer,
+			executors.Execute,
+			store.JobCompleter,
+			store.JobRetrier,
+			deadLetterer)
+		if jobBatchSize > 1 {
+			jobWorkerFunction = jobworker.NewBatchJobWorker(nodeName,
+				lockExpiryMinutes,
+				jobBatchSize,
+				store.JobsGetter,
+				store.JobLeaseRenewer,
+				executors.Execute,
+				store.JobsCompleter,
+				store.JobRetrier,
+				deadLetterer)
+		}
 		go func(j int) {
 			repetitive.Work(nodeName+"_jobs_"+strconv.Itoa(j), jobWorkerFunction, time.Second*5, stopper)
 			waiter <- true
@@ -144,6 +254,28 @@ func main() {
 		waitForUpTo(50)
 	}
 
+	if archiveURL != "" && jobRetention == 0 {
+		logger.For(pkg, "main").Warn("completed jobs are only archived when they're purged, set CALLME_RETAIN_JOB_DAYS to archive them")
+	}
+	if retentionWorkerCount > 0 {
+		// Archive completed jobs before they're purged, if an archive is set.
+		var jobsPurger data.JobsPurger = store.JobsPurger
+		if archiveURL != "" {
+			archiveStore := archive.Open(archiveURL, os.Getenv("CALLME_ARCHIVE_S3_ENDPOINT"))
+			jobsPurger = archive.NewArchiver(archiveStore, store.CompletedJobsGetter, store.CompletedJobsDeleter).Purge
+		}
+		retentionWorkerFunction := retention.NewRetentionWorker(nodeName, retentionBatchSize,
+			retention.Policy{Table: "job", Retention: jobRetention, Purger: jobsPurger},
+			retention.Policy{Table: "joblease", Retention: jobLeaseRetention, Purger: store.JobLeasesPurger},
+			retention.Policy{Table: "crontablease", Retention: crontabLeaseRetention, Purger: store.CrontabLeasesPurger},
+			retention.Policy{Table: "idempotencykey", Retention: idempotencyKeyRetention, Purger: store.IdempotencyKeysPurger},
+			retention.Policy{Table: "jobdedupe", Retention: dedupeKeyRetention, Purger: store.DedupeKeysPurger})
+		go func() {
+			repetitive.Work(nodeName+"_retention", retentionWorkerFunction, time.Minute, stopper)
+			waiter <- true
+		}()
+	}
+
 	logger.For(pkg, "main").Info("all processes started")
 	for i := 0; i < totalProcesses; i++ {
 		<-waiter